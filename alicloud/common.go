@@ -23,6 +23,7 @@ import (
 	"github.com/denverdino/aliyungo/common"
 	"github.com/google/uuid"
 	"github.com/mitchellh/go-homedir"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
 )
 
 type InstanceNetWork string
@@ -178,6 +179,32 @@ const (
 	SnapshotPolicyCreating  = Status("Creating")
 	SnapshotPolicyAvaliable = Status("avaliable")
 	SnapshotPolicyNormal    = Status("Normal")
+
+	ImageWaiting      = Status("Waiting")
+	ImageCreating     = Status("Creating")
+	ImageAvailable    = Status("Available")
+	ImageUnAvailable  = Status("UnAvailable")
+	ImageCreateFailed = Status("CreateFailed")
+
+	DedicatedHostUnderAssessment  = Status("UnderAssessment")
+	DedicatedHostPermanentFailure = Status("PermanentFailure")
+
+	InvocationRunning       = Status("Running")
+	InvocationFinished      = Status("Finished")
+	InvocationFailed        = Status("Failed")
+	InvocationPartialFailed = Status("PartialFailed")
+	InvocationStopped       = Status("Stopped")
+
+	FleetSubmitted = Status("submitted")
+	FleetActive    = Status("active")
+	FleetError     = Status("error")
+
+	FlowLogActivating = Status("Activating")
+
+	VbrPending    = Status("pending")
+	VbrActive     = Status("active")
+	VbrDeleting   = Status("deleting")
+	VbrTerminated = Status("terminated")
 )
 
 // timeout for common product, ecs e.g.
@@ -334,6 +361,18 @@ const (
 	TagResourceDisk          = TagResourceType("disk")
 	TagResourceSecurityGroup = TagResourceType("securitygroup")
 	TagResourceEni           = TagResourceType("eni")
+	TagResourceDedicatedHost = TagResourceType("ddh")
+
+	TagResourceVpc             = TagResourceType("vpc")
+	TagResourceVSwitch         = TagResourceType("vswitch")
+	TagResourceEip             = TagResourceType("eip")
+	TagResourceNatGateway      = TagResourceType("natgateway")
+	TagResourceKVStoreInstance = TagResourceType("kvstore")
+	TagResourceMongoDBInstance = TagResourceType("mongodb")
+	TagResourceKmsKey          = TagResourceType("kmskey")
+	TagResourceCenInstance     = TagResourceType("cen")
+	TagResourceDBInstance      = TagResourceType("dbinstance")
+	TagResourceSlb             = TagResourceType("slb")
 )
 
 type KubernetesNodeType string
@@ -421,6 +460,8 @@ func writeToFile(filePath string, data interface{}) error {
 	return nil
 }
 
+// Invoker retries a function while it fails with the errors of its catchers. The function is run again as a whole, so
+// it is only used for the calls which can be repeated.
 type Invoker struct {
 	catchers []*Catcher
 }
@@ -447,26 +488,42 @@ func (a *Invoker) AddCatcher(catcher Catcher) {
 	a.catchers = append(a.catchers, &catcher)
 }
 
+// Run calls f until it succeeds, fails with an error which is not caught, or a catcher runs out of its RetryCount.
+// The wait before each retry grows exponentially with jitter from one second up to the RetryWaitSeconds of the catcher.
 func (a *Invoker) Run(f func() error) error {
-	err := f()
+	retries := make(map[*Catcher]int)
+	for {
+		err := f()
+		if err == nil {
+			return nil
+		}
 
-	if err == nil {
-		return nil
+		catcher := a.catcherOf(err)
+		if catcher == nil {
+			return err
+		}
+		catcher.RetryCount--
+		if catcher.RetryCount <= 0 {
+			return fmt.Errorf("Retry timeout and got an error: %#v.", err)
+		}
+
+		retries[catcher]++
+		policy := connectivity.RetryPolicy{
+			BaseDelay: connectivity.DefaultRetryBaseDelay,
+			MaxDelay:  time.Duration(catcher.RetryWaitSeconds) * time.Second,
+			Jitter:    connectivity.DefaultRetryJitter,
+		}
+		time.Sleep(policy.Delay(retries[catcher]))
 	}
+}
 
+func (a *Invoker) catcherOf(err error) *Catcher {
 	for _, catcher := range a.catchers {
 		if IsExceptedErrors(err, []string{catcher.Reason}) {
-			catcher.RetryCount--
-
-			if catcher.RetryCount <= 0 {
-				return fmt.Errorf("Retry timeout and got an error: %#v.", err)
-			} else {
-				time.Sleep(time.Duration(catcher.RetryWaitSeconds) * time.Second)
-				return a.Run(f)
-			}
+			return catcher
 		}
 	}
-	return err
+	return nil
 }
 
 func buildClientToken(action string) string {
@@ -2,6 +2,8 @@ package connectivity
 
 import (
 	"github.com/aliyun/alibaba-cloud-sdk-go/sdk"
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/auth"
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/auth/credentials"
 	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/endpoints"
 	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/actiontrail"
@@ -44,6 +46,7 @@ import (
 
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
@@ -63,6 +66,8 @@ type AliyunClient struct {
 	SecretKey                    string
 	SecurityToken                string
 	OtsInstanceName              string
+	DefaultTags                  map[string]string
+	RetryPolicy                  *RetryPolicy
 	accountIdMutex               sync.RWMutex
 	config                       *Config
 	accountId                    string
@@ -95,13 +100,29 @@ type AliyunClient struct {
 	mnsconn                      *ali_mns.MNSClient
 	cloudapiconn                 *cloudapi.Client
 	tablestoreconnByInstanceName map[string]*tablestore.TableStoreClient
-	csprojectconnByKey           map[string]*cs.ProjectClient
-	drdsconn                     *drds.Client
-	elasticsearchconn            *elasticsearch.Client
-	actiontrailconn              *actiontrail.Client
-	casconn                      *cas.Client
-	ddoscooconn                  *ddoscoo.Client
-	bssopenapiconn               *bssopenapi.Client
+	// The credentials which the TableStore clients were built with
+	tablestoreTokenByInstanceName map[string]string
+	csprojectconnByKey            map[string]*cs.ProjectClient
+	drdsconn                      *drds.Client
+	elasticsearchconn             *elasticsearch.Client
+	actiontrailconn               *actiontrail.Client
+	casconn                       *cas.Client
+	ddoscooconn                   *ddoscoo.Client
+	bssopenapiconn                *bssopenapi.Client
+
+	// Each product client is initialized lazily under its own mutex, keyed by the connection name.
+	clientMutexes     map[string]*sync.Mutex
+	clientMutexesLock sync.Mutex
+	// callMutexes serialize the API calls of the products which are not in concurrentProducts, keyed by the product.
+	callMutexes map[string]*sync.Mutex
+	// requestSemaphore limits the number of in-flight API calls when max_concurrent_requests is set.
+	requestSemaphore chan struct{}
+	// tracer writes every API call to the trace_file if it is set.
+	tracer *apiTracer
+	// productCredentials keep the credentials of the product clients up to date, keyed by the product.
+	productCredentials map[string]*productCredential
+	// regionCache keeps the regions of ECS which the region of the provider is validated by.
+	regionCache *regionCache
 }
 
 type ApiVersion string
@@ -119,262 +140,585 @@ const DefaultClientRetryCountMedium = 10
 const DefaultClientRetryCountLarge = 15
 const Terraform = "HashiCorp-Terraform"
 
-var goSdkMutex = sync.RWMutex{} // The Go SDK is not thread-safe
+// The Go SDK keeps its endpoint mapping in a package-level map which is not thread-safe, and reads it in every API call.
+// The mapping is written once per provider configuration, before any of its calls, and endpointMutex serializes the
+// writes of the provider configurations. A mapping is only written again if a configuration changes it.
+var endpointMutex = sync.Mutex{}
+
+// concurrentProducts are the products whose SDK clients are safe for concurrent use. The clients of alibaba-cloud-sdk-go
+// set their timeouts and proxy on their shared HTTP client in every call, so the calls of each of them are serialized.
+var concurrentProducts = map[string]bool{"oss": true, "log": true, "fc": true, "tablestore": true, "mns": true, "datahub": true, "cs": true}
 var version = strings.TrimSuffix(terraform.VersionString(), "-dev")
 
 // Client for AliyunClient
 func (c *Config) Client() (*AliyunClient, error) {
-	// Get the auth and region. This can fail if keys/regions were not
+	catalog, err := loadEndpointCatalog(c.EndpointsFile)
+	if err != nil {
+		return nil, err
+	}
+	c.endpointCatalog = catalog
+
+	var requestSemaphore chan struct{}
+	if c.MaxConcurrentRequests > 0 {
+		requestSemaphore = make(chan struct{}, c.MaxConcurrentRequests)
+	}
+
+	if err := c.initCredentialChain(); err != nil {
+		return nil, err
+	}
+
+	var tracer *apiTracer
+	if c.TraceFile != "" {
+		var err error
+		if tracer, err = newApiTracer(c.TraceFile); err != nil {
+			return nil, fmt.Errorf("unable to open the trace file %s: %#v", c.TraceFile, err)
+		}
+	}
+
+	retryPolicy := c.RetryPolicy
+	if retryPolicy == nil {
+		retryPolicy = NewRetryPolicy()
+	}
+
+	client := &AliyunClient{
+		config:                        c,
+		Region:                        c.Region,
+		RegionId:                      c.RegionId,
+		AccessKey:                     c.AccessKey,
+		SecretKey:                     c.SecretKey,
+		SecurityToken:                 c.SecurityToken,
+		OtsInstanceName:               c.OtsInstanceName,
+		DefaultTags:                   c.DefaultTags,
+		RetryPolicy:                   retryPolicy,
+		accountId:                     c.AccountId,
+		tablestoreconnByInstanceName:  make(map[string]*tablestore.TableStoreClient),
+		tablestoreTokenByInstanceName: make(map[string]string),
+		csprojectconnByKey:            make(map[string]*cs.ProjectClient),
+		clientMutexes:                 make(map[string]*sync.Mutex),
+		callMutexes:                   make(map[string]*sync.Mutex),
+		productCredentials:            make(map[string]*productCredential),
+		requestSemaphore:              requestSemaphore,
+		tracer:                        tracer,
+		regionCache:                   newRegionCache(),
+	}
+	client.initEndpointMappings()
+	if err := client.initRoleCredential(); err != nil {
+		return nil, err
+	}
+	// The region is validated by the API calls once the client is ready. This can fail if keys/regions were not
 	// specified and we're attempting to use the environment.
 	if !c.SkipRegionValidation {
-		err := c.loadAndValidate()
-		if err != nil {
+		if err := client.validateRegion(); err != nil {
 			return nil, err
 		}
 	}
+	return client, nil
+}
+
+// initClient runs init under the mutex of the named product client. It makes sure every client is built only once
+// without blocking the API calls of other products.
+func (client *AliyunClient) initClient(name string, init func() error) error {
+	mutex := client.mutexOf(client.clientMutexes, name)
+	mutex.Lock()
+	defer mutex.Unlock()
+	return init()
+}
+
+// mutexOf returns the mutex of the name in the mutexes, which is created the first time it is asked for.
+func (client *AliyunClient) mutexOf(mutexes map[string]*sync.Mutex, name string) *sync.Mutex {
+	client.clientMutexesLock.Lock()
+	defer client.clientMutexesLock.Unlock()
+	mutex, ok := mutexes[name]
+	if !ok {
+		mutex = &sync.Mutex{}
+		mutexes[name] = mutex
+	}
+	return mutex
+}
+
+// invoke runs an API call of the product. The calls of different products run concurrently, up to max_concurrent_requests
+// if it is set, and so do the calls of the products in concurrentProducts.
+// A request which failed with a throttling error, or a busy error when it is idempotent, is sent again by the RetryPolicy,
+// and it gives up its slot while waiting. The other errors are left to the callers, which know whether the call can be
+// repeated.
+func (client *AliyunClient) invoke(product string, do func() (interface{}, error)) (interface{}, error) {
+	for attempt := 1; ; attempt++ {
+		raw, err := client.invokeOnce(product, attempt, do)
+		if err == nil || attempt >= client.RetryPolicy.MaxAttempts || !client.RetryPolicy.IsRetryable(err, isIdempotentRequest(raw)) {
+			return raw, err
+		}
+		delay := client.RetryPolicy.Delay(attempt)
+		log.Printf("[DEBUG] Retrying the API call after %s (attempt %d): %s", delay, attempt, err)
+		time.Sleep(delay)
+	}
+}
+
+func (client *AliyunClient) invokeOnce(product string, attempt int, do func() (interface{}, error)) (interface{}, error) {
+	// The call waits for the previous one of its product client before it takes a slot of max_concurrent_requests
+	if !concurrentProducts[product] {
+		mutex := client.mutexOf(client.callMutexes, product)
+		mutex.Lock()
+		defer mutex.Unlock()
+	}
+	if client.requestSemaphore != nil {
+		client.requestSemaphore <- struct{}{}
+		defer func() { <-client.requestSemaphore }()
+	}
+
+	release, err := client.syncCredentials(product)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	start := time.Now()
+	raw, err := do()
+	if client.tracer != nil {
+		client.tracer.trace(product, client.RegionId, attempt, time.Since(start), raw, err)
+	}
+	return raw, err
+}
+
+// productCredential keeps the credentials of the clients of a product up to date. The refreshed credentials are applied
+// by a call of the product when none of its calls is in flight, so that neither the refresh nor the call waits for them,
+// and no request is signed while its credentials are being changed. The old credentials are still valid within the
+// refresh window, and a call waits for the ones in flight only if they have expired.
+type productCredential struct {
+	mutex sync.Mutex
+	idle  *sync.Cond
+	// The number of the calls in flight
+	inFlight int
+	// The generation and expiration of the credentials applied to the clients
+	generation int
+	expiration time.Time
+	appliers   []func(accessKey, secretKey, token string)
+}
+
+func (p *productCredential) expired() bool {
+	return !p.expiration.IsZero() && time.Now().After(p.expiration)
+}
+
+// productCredentialOf returns the credential of the product, which is created the first time it is asked for.
+func (client *AliyunClient) productCredentialOf(product string) *productCredential {
+	client.clientMutexesLock.Lock()
+	defer client.clientMutexesLock.Unlock()
+	credential, ok := client.productCredentials[product]
+	if !ok {
+		credential = &productCredential{}
+		credential.idle = sync.NewCond(&credential.mutex)
+		client.productCredentials[product] = credential
+	}
+	return credential
+}
 
-	return &AliyunClient{
-		config:                       c,
-		Region:                       c.Region,
-		RegionId:                     c.RegionId,
-		AccessKey:                    c.AccessKey,
-		SecretKey:                    c.SecretKey,
-		SecurityToken:                c.SecurityToken,
-		OtsInstanceName:              c.OtsInstanceName,
-		accountId:                    c.AccountId,
-		tablestoreconnByInstanceName: make(map[string]*tablestore.TableStoreClient),
-		csprojectconnByKey:           make(map[string]*cs.ProjectClient),
+// onCredentialRefresh makes the calls of the product apply the credentials returned by getAuthCredentialByEcsRoleName
+// to its client by apply, whenever they are refreshed.
+func (client *AliyunClient) onCredentialRefresh(product string, apply func(accessKey, secretKey, token string)) {
+	if client.config.getRefreshingCredential() == nil {
+		return
+	}
+	credential := client.productCredentialOf(product)
+	credential.mutex.Lock()
+	defer credential.mutex.Unlock()
+	credential.appliers = append(credential.appliers, apply)
+	// The client may have been built with older credentials than the other ones, so all of them are applied again
+	credential.generation = 0
+}
+
+// getAuthCredential returns the credential of a client of the product which is built on alibaba-cloud-sdk-go. A copy of
+// the credentials of the credential chain is kept up to date by the calls of the product.
+func (client *AliyunClient) getAuthCredential(product string, stsSupported bool) auth.Credential {
+	credential := client.config.getAuthCredential(stsSupported)
+	if stsCredential, ok := credential.(*credentials.StsTokenCredential); ok && client.config.sessionCredential != nil {
+		client.onCredentialRefresh(product, func(accessKey, secretKey, token string) {
+			stsCredential.AccessKeyId, stsCredential.AccessKeySecret, stsCredential.AccessKeyStsToken = accessKey, secretKey, token
+		})
+	}
+	return credential
+}
+
+// syncCredentials refreshes the credentials if they are about to expire, and applies them to the clients of the product
+// if they have not been. The returned release must be called when the call of the product is done.
+func (client *AliyunClient) syncCredentials(product string) (release func(), err error) {
+	refreshing := client.config.getRefreshingCredential()
+	if refreshing == nil {
+		return func() {}, nil
+	}
+	if err := refreshing.refreshIfExpiring(); err != nil {
+		return nil, err
+	}
+	session, generation := refreshing.current()
+
+	credential := client.productCredentialOf(product)
+	credential.mutex.Lock()
+	defer credential.mutex.Unlock()
+	if session != nil && credential.generation != generation {
+		for credential.inFlight > 0 && credential.expired() {
+			credential.idle.Wait()
+		}
+		if credential.inFlight == 0 {
+			for _, apply := range credential.appliers {
+				apply(session.AccessKeyId, session.AccessKeySecret, session.SecurityToken)
+			}
+			credential.generation, credential.expiration = generation, session.Expiration
+		}
+	}
+	credential.inFlight++
+	return func() {
+		credential.mutex.Lock()
+		defer credential.mutex.Unlock()
+		if credential.inFlight--; credential.inFlight == 0 {
+			credential.idle.Broadcast()
+		}
 	}, nil
 }
 
-func (client *AliyunClient) WithEcsClient(do func(*ecs.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
+// Close releases the resources held by the client, which is the trace file.
+func (client *AliyunClient) Close() error {
+	if client.tracer != nil {
+		return client.tracer.close()
+	}
+	return nil
+}
+
+// tracingTransport wraps the transport of an SDK, which is http.DefaultTransport if it is nil, to trace its requests.
+func (client *AliyunClient) tracingTransport(product string, base http.RoundTripper) *tracingTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &tracingTransport{tracer: client.tracer, product: product, region: client.RegionId, base: base}
+}
 
-	// Initialize the ECS client if necessary
-	if client.ecsconn == nil {
-		endpoint := client.config.EcsEndpoint
+// initEndpointMappings maps the products of alibaba-cloud-sdk-go to their endpoints, which are the ones of their provider
+// arguments or the endpoint catalog. The clients of the products are built lazily, but their mappings are written here,
+// before the provider makes any API call.
+func (client *AliyunClient) initEndpointMappings() {
+	c := client.config
+	configured := map[ServiceCode]string{
+		ECSCode:           c.EcsEndpoint,
+		RDSCode:           c.RdsEndpoint,
+		SLBCode:           c.SlbEndpoint,
+		VPCCode:           c.VpcEndpoint,
+		NASCode:           "",
+		CENCode:           c.CenEndpoint,
+		ESSCode:           c.EssEndpoint,
+		DNSCode:           c.DnsEndpoint,
+		RAMCode:           c.RamEndpoint,
+		CRCode:            c.CrEndpoint,
+		CDNCode:           c.CdnEndpoint,
+		KMSCode:           c.KmsEndpoint,
+		OTSCode:           c.OtsEndpoint,
+		PVTZCode:          c.PvtzEndpoint,
+		STSCode:           c.StsEndpoint,
+		DDSCode:           c.DdsEndpoint,
+		GPDBCode:          c.GpdbEnpoint,
+		KVSTORECode:       c.KVStoreEndpoint,
+		CLOUDAPICode:      c.ApigatewayEndpoint,
+		ELASTICSEARCHCode: c.ElasticsearchEndpoint,
+		ACTIONTRAILCode:   c.ActionTrailEndpoint,
+		BSSOPENAPICode:    c.BssOpenApiEndpoint,
+		ONSCode:           c.OnsEndpoint,
+	}
+	for code, endpoint := range configured {
 		if endpoint == "" {
-			endpoint = loadEndpoint(client.config.RegionId, ECSCode)
+			endpoint = c.loadEndpoint(code)
 		}
-		if endpoint != "" {
-			endpoints.AddEndpointMapping(client.config.RegionId, string(ECSCode), endpoint)
+		productId := string(code)
+		switch code {
+		case CRCode:
+			if endpoint == "" {
+				endpoint = fmt.Sprintf("cr.%s.aliyuncs.com", c.RegionId)
+			}
+		case PVTZCode:
+			if endpoint == "" {
+				endpoint = "pvtz.aliyuncs.com"
+			}
+		case KVSTORECode:
+			productId = fmt.Sprintf("R-%s", string(KVSTORECode))
+		case CLOUDAPICode:
+			productId = "CLOUDAPI"
 		}
-		ecsconn, err := ecs.NewClientWithOptions(client.config.RegionId, client.getSdkConfig().WithTimeout(time.Duration(60)*time.Second), client.config.getAuthCredential(true))
-		if err != nil {
-			return nil, fmt.Errorf("unable to initialize the ECS client: %#v", err)
+		if endpoint != "" {
+			addEndpointMapping(c.RegionId, productId, endpoint)
 		}
+	}
+}
 
-		if _, err := ecsconn.DescribeRegions(ecs.CreateDescribeRegionsRequest()); err != nil {
-			return nil, err
+// addEndpointMapping maps the product to the domain of the endpoint. Its scheme is set by getSdkConfigByEndpoint.
+func addEndpointMapping(regionId, productId, endpoint string) {
+	_, domain := splitEndpointScheme(endpoint)
+	endpointMutex.Lock()
+	defer endpointMutex.Unlock()
+	resolver := &endpoints.MappingResolver{}
+	if mapped, ok, _ := resolver.TryResolve(&endpoints.ResolveParam{RegionId: regionId, Product: productId}); ok && mapped == domain {
+		return
+	}
+	endpoints.AddEndpointMapping(regionId, productId, domain)
+}
+
+func (client *AliyunClient) WithEcsClient(do func(*ecs.Client) (interface{}, error)) (interface{}, error) {
+	err := client.initClient("ecsconn", func() error {
+		// Initialize the ECS client if necessary
+		if client.ecsconn == nil {
+			endpoint := client.config.EcsEndpoint
+			if endpoint == "" {
+				endpoint = client.config.loadEndpoint(ECSCode)
+			}
+			ecsconn, err := ecs.NewClientWithOptions(client.config.RegionId, client.getSdkConfigByEndpoint(endpoint).WithTimeout(time.Duration(60)*time.Second), client.getAuthCredential("ecs", true))
+			if err != nil {
+				return fmt.Errorf("unable to initialize the ECS client: %#v", err)
+			}
+
+			// The regions of ECS check the credentials, unless they have been described and cached by a recent run
+			if !client.ecsRegionsCached() {
+				if _, err := client.invoke("ecs", func() (interface{}, error) {
+					return client.describeEcsRegions(ecsconn)
+				}); err != nil {
+					return err
+				}
+			}
+			ecsconn.AppendUserAgent(Terraform, version)
+			client.ecsconn = ecsconn
 		}
-		ecsconn.AppendUserAgent(Terraform, version)
-		client.ecsconn = ecsconn
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return do(client.ecsconn)
+	return client.invoke("ecs", func() (interface{}, error) {
+		return do(client.ecsconn)
+	})
 }
 
 func (client *AliyunClient) WithRdsClient(do func(*rds.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
+	err := client.initClient("rdsconn", func() error {
+		// Initialize the RDS client if necessary
+		if client.rdsconn == nil {
+			endpoint := client.config.RdsEndpoint
+			if endpoint == "" {
+				endpoint = client.config.loadEndpoint(RDSCode)
+			}
+			rdsconn, err := rds.NewClientWithOptions(client.config.RegionId, client.getSdkConfigByEndpoint(endpoint), client.getAuthCredential("rds", true))
+			if err != nil {
+				return fmt.Errorf("unable to initialize the RDS client: %#v", err)
+			}
 
-	// Initialize the RDS client if necessary
-	if client.rdsconn == nil {
-		endpoint := client.config.RdsEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.config.RegionId, RDSCode)
-		}
-		if endpoint != "" {
-			endpoints.AddEndpointMapping(client.config.RegionId, string(RDSCode), endpoint)
+			rdsconn.AppendUserAgent(Terraform, version)
+			client.rdsconn = rdsconn
 		}
-		rdsconn, err := rds.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
-		if err != nil {
-			return nil, fmt.Errorf("unable to initialize the RDS client: %#v", err)
-		}
-
-		rdsconn.AppendUserAgent(Terraform, version)
-		client.rdsconn = rdsconn
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return do(client.rdsconn)
+	return client.invoke("rds", func() (interface{}, error) {
+		return do(client.rdsconn)
+	})
 }
 
 func (client *AliyunClient) WithSlbClient(do func(*slb.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
+	err := client.initClient("slbconn", func() error {
+		// Initialize the SLB client if necessary
+		if client.slbconn == nil {
+			endpoint := client.config.SlbEndpoint
+			if endpoint == "" {
+				endpoint = client.config.loadEndpoint(SLBCode)
+			}
+			slbconn, err := slb.NewClientWithOptions(client.config.RegionId, client.getSdkConfigByEndpoint(endpoint), client.getAuthCredential("slb", true))
+			if err != nil {
+				return fmt.Errorf("unable to initialize the SLB client: %#v", err)
+			}
 
-	// Initialize the SLB client if necessary
-	if client.slbconn == nil {
-		endpoint := client.config.SlbEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.config.RegionId, SLBCode)
+			slbconn.AppendUserAgent(Terraform, version)
+			client.slbconn = slbconn
 		}
-		if endpoint != "" {
-			endpoints.AddEndpointMapping(client.config.RegionId, string(SLBCode), endpoint)
-		}
-		slbconn, err := slb.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
-		if err != nil {
-			return nil, fmt.Errorf("unable to initialize the SLB client: %#v", err)
-		}
-
-		slbconn.AppendUserAgent(Terraform, version)
-		client.slbconn = slbconn
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return do(client.slbconn)
+	return client.invoke("slb", func() (interface{}, error) {
+		return do(client.slbconn)
+	})
 }
 
 func (client *AliyunClient) WithVpcClient(do func(*vpc.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
+	err := client.initClient("vpcconn", func() error {
+		// Initialize the VPC client if necessary
+		if client.vpcconn == nil {
+			endpoint := client.config.VpcEndpoint
+			if endpoint == "" {
+				endpoint = client.config.loadEndpoint(VPCCode)
+			}
+			vpcconn, err := vpc.NewClientWithOptions(client.config.RegionId, client.getSdkConfigByEndpoint(endpoint), client.getAuthCredential("vpc", true))
+			if err != nil {
+				return fmt.Errorf("unable to initialize the VPC client: %#v", err)
+			}
 
-	// Initialize the VPC client if necessary
-	if client.vpcconn == nil {
-		endpoint := client.config.VpcEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.config.RegionId, VPCCode)
-		}
-		if endpoint != "" {
-			endpoints.AddEndpointMapping(client.config.RegionId, string(VPCCode), endpoint)
-		}
-		vpcconn, err := vpc.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
-		if err != nil {
-			return nil, fmt.Errorf("unable to initialize the VPC client: %#v", err)
+			vpcconn.AppendUserAgent(Terraform, version)
+			client.vpcconn = vpcconn
 		}
-
-		vpcconn.AppendUserAgent(Terraform, version)
-		client.vpcconn = vpcconn
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return do(client.vpcconn)
+	return client.invoke("vpc", func() (interface{}, error) {
+		return do(client.vpcconn)
+	})
 }
 
 func (client *AliyunClient) WithNasClient(do func(*nas.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
-
-	// Initialize the Nas client if necessary
-	if client.nasconn == nil {
-		endpoint := loadEndpoint(client.config.RegionId, NASCode)
-		if endpoint != "" {
-			endpoints.AddEndpointMapping(client.config.RegionId, string(NASCode), endpoint)
-		}
-		nasconn, err := nas.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
-		if err != nil {
-			return nil, fmt.Errorf("unable to initialize the NAS client: %#v", err)
+	err := client.initClient("nasconn", func() error {
+		// Initialize the Nas client if necessary
+		if client.nasconn == nil {
+			endpoint := client.config.loadEndpoint(NASCode)
+			nasconn, err := nas.NewClientWithOptions(client.config.RegionId, client.getSdkConfigByEndpoint(endpoint), client.getAuthCredential("nas", true))
+			if err != nil {
+				return fmt.Errorf("unable to initialize the NAS client: %#v", err)
+			}
+			nasconn.AppendUserAgent(Terraform, version)
+			client.nasconn = nasconn
 		}
-		nasconn.AppendUserAgent(Terraform, version)
-		client.nasconn = nasconn
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return do(client.nasconn)
+	return client.invoke("nas", func() (interface{}, error) {
+		return do(client.nasconn)
+	})
 }
 
 func (client *AliyunClient) WithCenClient(do func(*cbn.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
+	err := client.initClient("cenconn", func() error {
+		// Initialize the CEN client if necessary
+		if client.cenconn == nil {
+			endpoint := client.config.CenEndpoint
+			if endpoint == "" {
+				endpoint = client.config.loadEndpoint(CENCode)
+			}
+			cenconn, err := cbn.NewClientWithOptions(client.config.RegionId, client.getSdkConfigByEndpoint(endpoint), client.getAuthCredential("cen", true))
+			if err != nil {
+				return fmt.Errorf("unable to initialize the CEN client: %#v", err)
+			}
 
-	// Initialize the CEN client if necessary
-	if client.cenconn == nil {
-		endpoint := client.config.CenEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.config.RegionId, CENCode)
-		}
-		if endpoint != "" {
-			endpoints.AddEndpointMapping(client.config.RegionId, string(CENCode), endpoint)
-		}
-		cenconn, err := cbn.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
-		if err != nil {
-			return nil, fmt.Errorf("unable to initialize the CEN client: %#v", err)
+			cenconn.AppendUserAgent(Terraform, version)
+			client.cenconn = cenconn
 		}
-
-		cenconn.AppendUserAgent(Terraform, version)
-		client.cenconn = cenconn
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return do(client.cenconn)
+	return client.invoke("cen", func() (interface{}, error) {
+		return do(client.cenconn)
+	})
 }
 
 func (client *AliyunClient) WithEssClient(do func(*ess.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
+	err := client.initClient("essconn", func() error {
+		// Initialize the ESS client if necessary
+		if client.essconn == nil {
+			endpoint := client.config.EssEndpoint
+			if endpoint == "" {
+				endpoint = client.config.loadEndpoint(ESSCode)
+			}
+			essconn, err := ess.NewClientWithOptions(client.config.RegionId, client.getSdkConfigByEndpoint(endpoint), client.getAuthCredential("ess", true))
+			if err != nil {
+				return fmt.Errorf("unable to initialize the ESS client: %#v", err)
+			}
 
-	// Initialize the ESS client if necessary
-	if client.essconn == nil {
-		endpoint := client.config.EssEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.config.RegionId, ESSCode)
-		}
-		if endpoint != "" {
-			endpoints.AddEndpointMapping(client.config.RegionId, string(ESSCode), endpoint)
+			essconn.AppendUserAgent(Terraform, version)
+			client.essconn = essconn
 		}
-		essconn, err := ess.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
-		if err != nil {
-			return nil, fmt.Errorf("unable to initialize the ESS client: %#v", err)
-		}
-
-		essconn.AppendUserAgent(Terraform, version)
-		client.essconn = essconn
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return do(client.essconn)
+	return client.invoke("ess", func() (interface{}, error) {
+		return do(client.essconn)
+	})
 }
 
 func (client *AliyunClient) WithOssClient(do func(*oss.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
-
-	// Initialize the OSS client if necessary
-	if client.ossconn == nil {
-		schma := "https"
-		endpoint := client.config.OssEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.config.RegionId, OSSCode)
-		}
-		if endpoint == "" {
-			endpointItem, _ := client.describeEndpointForService(strings.ToLower(string(OSSCode)))
-			if endpointItem != nil {
-				if len(endpointItem.Protocols.Protocols) > 0 {
-					// HTTP or HTTPS
-					schma = strings.ToLower(endpointItem.Protocols.Protocols[0])
-					for _, p := range endpointItem.Protocols.Protocols {
-						if strings.ToLower(p) == "https" {
-							schma = strings.ToLower(p)
-							break
+	err := client.initClient("ossconn", func() error {
+		// Initialize the OSS client if necessary
+		if client.ossconn == nil {
+			schma := "https"
+			endpoint := client.config.OssEndpoint
+			if endpoint == "" {
+				endpoint = client.config.loadEndpoint(OSSCode)
+			}
+			if endpoint == "" {
+				endpointItem, _ := client.describeEndpointForService(strings.ToLower(string(OSSCode)))
+				if endpointItem != nil {
+					if len(endpointItem.Protocols.Protocols) > 0 {
+						// HTTP or HTTPS
+						schma = strings.ToLower(endpointItem.Protocols.Protocols[0])
+						for _, p := range endpointItem.Protocols.Protocols {
+							if strings.ToLower(p) == "https" {
+								schma = strings.ToLower(p)
+								break
+							}
 						}
 					}
+					endpoint = endpointItem.Endpoint
+				} else {
+					endpoint = fmt.Sprintf("oss-%s.aliyuncs.com", client.RegionId)
 				}
-				endpoint = endpointItem.Endpoint
-			} else {
-				endpoint = fmt.Sprintf("oss-%s.aliyuncs.com", client.RegionId)
 			}
-		}
-		if !strings.HasPrefix(endpoint, "http") {
-			endpoint = fmt.Sprintf("%s://%s", schma, endpoint)
-		}
+			if !strings.HasPrefix(endpoint, "http") {
+				endpoint = fmt.Sprintf("%s://%s", schma, endpoint)
+			}
 
-		log.Printf("[DEBUG] Instantiate OSS client using endpoint: %#v", endpoint)
-		accessKey, secretKey, securityToken, err := client.config.getAuthCredentialByEcsRoleName()
-		if err != nil {
-			return nil, err
-		}
-		clientOptions := []oss.ClientOption{oss.UserAgent(client.getUserAgent()),
-			oss.SecurityToken(securityToken)}
-		proxyUrl := client.getHttpProxyUrl()
-		if proxyUrl != nil {
-			clientOptions = append(clientOptions, oss.Proxy(proxyUrl.String()))
-		}
+			log.Printf("[DEBUG] Instantiate OSS client using endpoint: %#v", endpoint)
+			accessKey, secretKey, securityToken, err := client.config.getAuthCredentialByEcsRoleName()
+			if err != nil {
+				return err
+			}
+			clientOptions := []oss.ClientOption{oss.UserAgent(client.getUserAgent()),
+				oss.SecurityToken(securityToken)}
+			proxyUrl := client.getHttpProxyUrl()
+			if proxyUrl != nil {
+				clientOptions = append(clientOptions, oss.Proxy(proxyUrl.String()))
+			}
+			if client.tracer != nil {
+				// The HTTP client replaces the one of the SDK, so it takes the proxy as well
+				transport := &http.Transport{Proxy: http.ProxyFromEnvironment}
+				if proxyUrl != nil {
+					transport.Proxy = http.ProxyURL(proxyUrl)
+				}
+				clientOptions = append(clientOptions, oss.HTTPClient(&http.Client{Transport: client.tracingTransport("oss", transport)}))
+			}
 
-		ossconn, err := oss.New(endpoint, accessKey, secretKey, clientOptions...)
-		if err != nil {
-			return nil, fmt.Errorf("unable to initialize the OSS client: %#v", err)
-		}
+			ossconn, err := oss.New(endpoint, accessKey, secretKey, clientOptions...)
+			if err != nil {
+				return fmt.Errorf("unable to initialize the OSS client: %#v", err)
+			}
+			client.onCredentialRefresh("oss", func(accessKey, secretKey, token string) {
+				ossconn.Config.AccessKeyID, ossconn.Config.AccessKeySecret, ossconn.Config.SecurityToken = accessKey, secretKey, token
+			})
 
-		client.ossconn = ossconn
+			client.ossconn = ossconn
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return do(client.ossconn)
+	return client.invoke("oss", func() (interface{}, error) {
+		return do(client.ossconn)
+	})
 }
 
 func (client *AliyunClient) WithOssBucketByName(bucketName string, do func(*oss.Bucket) (interface{}, error)) (interface{}, error) {
@@ -388,571 +732,655 @@ func (client *AliyunClient) WithOssBucketByName(bucketName string, do func(*oss.
 }
 
 func (client *AliyunClient) WithDnsClient(do func(*alidns.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
-
-	// Initialize the DNS client if necessary
-	if client.dnsconn == nil {
-		endpoint := client.config.DnsEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.config.RegionId, DNSCode)
-		}
-		if endpoint != "" {
-			endpoints.AddEndpointMapping(client.config.RegionId, string(DNSCode), endpoint)
-		}
+	err := client.initClient("dnsconn", func() error {
+		// Initialize the DNS client if necessary
+		if client.dnsconn == nil {
+			endpoint := client.config.DnsEndpoint
+			if endpoint == "" {
+				endpoint = client.config.loadEndpoint(DNSCode)
+			}
 
-		dnsconn, err := alidns.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
-		if err != nil {
-			return nil, fmt.Errorf("unable to initialize the DNS client: %#v", err)
+			dnsconn, err := alidns.NewClientWithOptions(client.config.RegionId, client.getSdkConfigByEndpoint(endpoint), client.getAuthCredential("dns", true))
+			if err != nil {
+				return fmt.Errorf("unable to initialize the DNS client: %#v", err)
+			}
+			dnsconn.AppendUserAgent(Terraform, version)
+			client.dnsconn = dnsconn
 		}
-		dnsconn.AppendUserAgent(Terraform, version)
-		client.dnsconn = dnsconn
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return do(client.dnsconn)
+	return client.invoke("dns", func() (interface{}, error) {
+		return do(client.dnsconn)
+	})
 }
 
 func (client *AliyunClient) WithRamClient(do func(*ram.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
-
-	// Initialize the RAM client if necessary
-	if client.ramconn == nil {
-		endpoint := client.config.RamEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.config.RegionId, RAMCode)
-		}
-		if strings.HasPrefix(endpoint, "http") {
-			endpoint = fmt.Sprintf("https://%s", strings.TrimPrefix(endpoint, "http://"))
-		}
-		if endpoint != "" {
-			endpoints.AddEndpointMapping(client.config.RegionId, string(RAMCode), endpoint)
-		}
+	err := client.initClient("ramconn", func() error {
+		// Initialize the RAM client if necessary
+		if client.ramconn == nil {
+			endpoint := client.config.RamEndpoint
+			if endpoint == "" {
+				endpoint = client.config.loadEndpoint(RAMCode)
+			}
+			// RAM only accepts HTTPS, whatever the protocol of the provider is
+			if strings.HasPrefix(endpoint, "http") {
+				endpoint = fmt.Sprintf("https://%s", strings.TrimPrefix(endpoint, "http://"))
+			}
 
-		ramconn, err := ram.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
-		if err != nil {
-			return nil, fmt.Errorf("unable to initialize the RAM client: %#v", err)
+			ramconn, err := ram.NewClientWithOptions(client.config.RegionId, client.getSdkConfigByEndpoint(endpoint), client.getAuthCredential("ram", true))
+			if err != nil {
+				return fmt.Errorf("unable to initialize the RAM client: %#v", err)
+			}
+			ramconn.AppendUserAgent(Terraform, version)
+			client.ramconn = ramconn
 		}
-		ramconn.AppendUserAgent(Terraform, version)
-		client.ramconn = ramconn
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return do(client.ramconn)
+	return client.invoke("ram", func() (interface{}, error) {
+		return do(client.ramconn)
+	})
 }
 
 func (client *AliyunClient) WithCsClient(do func(*cs.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
-
-	// Initialize the CS client if necessary
-	if client.csconn == nil {
-		accessKey, secretKey, securityToken, err := client.config.getAuthCredentialByEcsRoleName()
-		if err != nil {
-			return nil, err
-		}
-		csconn := cs.NewClientForAussumeRole(accessKey, secretKey, securityToken)
-		csconn.SetUserAgent(client.getUserAgent())
-		endpoint := client.config.CsEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.config.RegionId, CONTAINCode)
-		}
-		if endpoint != "" {
-			if !strings.HasPrefix(endpoint, "http") {
-				endpoint = fmt.Sprintf("https://%s", strings.TrimPrefix(endpoint, "://"))
+	err := client.initClient("csconn", func() error {
+		// Initialize the CS client if necessary
+		if client.csconn == nil {
+			accessKey, secretKey, securityToken, err := client.config.getAuthCredentialByEcsRoleName()
+			if err != nil {
+				return err
+			}
+			csconn := cs.NewClientForAussumeRole(accessKey, secretKey, securityToken)
+			csconn.SetUserAgent(client.getUserAgent())
+			client.onCredentialRefresh("cs", func(accessKey, secretKey, token string) {
+				csconn.AccessKeyId, csconn.AccessKeySecret, csconn.SecurityToken = accessKey, secretKey, token
+			})
+			endpoint := client.config.CsEndpoint
+			if endpoint == "" {
+				endpoint = client.config.loadEndpoint(CONTAINCode)
+			}
+			if endpoint != "" {
+				csconn.SetEndpoint(client.withScheme(endpoint))
 			}
-			csconn.SetEndpoint(endpoint)
+			client.csconn = csconn
 		}
-		client.csconn = csconn
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return do(client.csconn)
+	return client.invoke("cs", func() (interface{}, error) {
+		return do(client.csconn)
+	})
 }
 
 func (client *AliyunClient) WithCrClient(do func(*cr.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
-
-	// Initialize the CR client if necessary
-	if client.crconn == nil {
-		endpoint := client.config.CrEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.config.RegionId, CRCode)
+	err := client.initClient("crconn", func() error {
+		// Initialize the CR client if necessary
+		if client.crconn == nil {
+			endpoint := client.config.CrEndpoint
 			if endpoint == "" {
-				endpoint = fmt.Sprintf("cr.%s.aliyuncs.com", client.config.RegionId)
+				endpoint = client.config.loadEndpoint(CRCode)
+				if endpoint == "" {
+					endpoint = fmt.Sprintf("cr.%s.aliyuncs.com", client.config.RegionId)
+				}
 			}
+			crconn, err := cr.NewClientWithOptions(client.config.RegionId, client.getSdkConfigByEndpoint(endpoint), client.getAuthCredential("cr", true))
+			if err != nil {
+				return fmt.Errorf("unable to initialize the CR client: %#v", err)
+			}
+			crconn.AppendUserAgent(Terraform, version)
+			client.crconn = crconn
 		}
-		if endpoint != "" {
-			endpoints.AddEndpointMapping(client.config.RegionId, string(CRCode), endpoint)
-		}
-		crconn, err := cr.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
-		if err != nil {
-			return nil, fmt.Errorf("unable to initialize the CR client: %#v", err)
-		}
-		crconn.AppendUserAgent(Terraform, version)
-		client.crconn = crconn
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return do(client.crconn)
+	return client.invoke("cr", func() (interface{}, error) {
+		return do(client.crconn)
+	})
 }
 
 func (client *AliyunClient) WithCdnClient(do func(*cdn.CdnClient) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
-
-	// Initialize the CDN client if necessary
-	if client.cdnconn == nil {
-		accessKey, secretKey, securityToken, err := client.config.getAuthCredentialByEcsRoleName()
-		if err != nil {
-			return nil, err
-		}
-		cdnconn := cdn.NewClient(accessKey, secretKey)
-		cdnconn.SetBusinessInfo(businessInfoKey)
-		cdnconn.SetUserAgent(client.getUserAgent())
-		cdnconn.SetSecurityToken(securityToken)
-		endpoint := client.config.CdnEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.config.RegionId, CDNCode)
-		}
-		if endpoint != "" && !strings.HasPrefix(endpoint, "http") {
-			cdnconn.SetEndpoint(fmt.Sprintf("https://%s", strings.TrimPrefix(endpoint, "://")))
+	err := client.initClient("cdnconn", func() error {
+		// Initialize the CDN client if necessary
+		if client.cdnconn == nil {
+			accessKey, secretKey, securityToken, err := client.config.getAuthCredentialByEcsRoleName()
+			if err != nil {
+				return err
+			}
+			cdnconn := cdn.NewClient(accessKey, secretKey)
+			cdnconn.SetBusinessInfo(businessInfoKey)
+			cdnconn.SetUserAgent(client.getUserAgent())
+			cdnconn.SetSecurityToken(securityToken)
+			client.onCredentialRefresh("cdn", func(accessKey, secretKey, token string) {
+				cdnconn.SetAccessKeyId(accessKey)
+				cdnconn.SetAccessKeySecret(secretKey)
+				cdnconn.SetSecurityToken(token)
+			})
+			endpoint := client.config.CdnEndpoint
+			if endpoint == "" {
+				endpoint = client.config.loadEndpoint(CDNCode)
+			}
+			if endpoint != "" {
+				cdnconn.SetEndpoint(client.withScheme(endpoint))
+			}
+			client.cdnconn = cdnconn
 		}
-		client.cdnconn = cdnconn
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	return do(client.cdnconn)
+
+	return client.invoke("cdn", func() (interface{}, error) {
+		return do(client.cdnconn)
+	})
 }
 
 func (client *AliyunClient) WithCdnClient_new(do func(*cdn_new.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
+	err := client.initClient("cdnconn_new", func() error {
+		// Initialize the CDN client if necessary
+		if client.cdnconn_new == nil {
+			endpoint := client.config.CdnEndpoint
+			if endpoint == "" {
+				endpoint = client.config.loadEndpoint(CDNCode)
+			}
+			cdnconn, err := cdn_new.NewClientWithOptions(client.config.RegionId, client.getSdkConfigByEndpoint(endpoint), client.getAuthCredential("cdn", true))
+			if err != nil {
+				return fmt.Errorf("unable to initialize the CDN client: %#v", err)
+			}
 
-	// Initialize the CDN client if necessary
-	if client.cdnconn_new == nil {
-		endpoint := client.config.CdnEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.config.RegionId, CDNCode)
-		}
-		if endpoint != "" {
-			endpoints.AddEndpointMapping(client.config.RegionId, string(CDNCode), endpoint)
-		}
-		cdnconn, err := cdn_new.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
-		if err != nil {
-			return nil, fmt.Errorf("unable to initialize the CDN client: %#v", err)
+			cdnconn.AppendUserAgent(Terraform, version)
+			client.cdnconn_new = cdnconn
 		}
-
-		cdnconn.AppendUserAgent(Terraform, version)
-		client.cdnconn_new = cdnconn
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return do(client.cdnconn_new)
+	return client.invoke("cdn", func() (interface{}, error) {
+		return do(client.cdnconn_new)
+	})
 }
 
 func (client *AliyunClient) WithKmsClient(do func(*kms.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
+	err := client.initClient("kmsconn", func() error {
+		// Initialize the KMS client if necessary
+		if client.kmsconn == nil {
 
-	// Initialize the KMS client if necessary
-	if client.kmsconn == nil {
-
-		endpoint := client.config.KmsEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.config.RegionId, KMSCode)
-		}
-		if endpoint != "" {
-			endpoints.AddEndpointMapping(client.config.RegionId, string(KMSCode), endpoint)
-		}
-		kmsconn, err := kms.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
-		if err != nil {
-			return nil, fmt.Errorf("unable to initialize the kms client: %#v", err)
+			endpoint := client.config.KmsEndpoint
+			if endpoint == "" {
+				endpoint = client.config.loadEndpoint(KMSCode)
+			}
+			kmsconn, err := kms.NewClientWithOptions(client.config.RegionId, client.getSdkConfigByEndpoint(endpoint), client.getAuthCredential("kms", true))
+			if err != nil {
+				return fmt.Errorf("unable to initialize the kms client: %#v", err)
+			}
+			kmsconn.AppendUserAgent(Terraform, version)
+			client.kmsconn = kmsconn
 		}
-		kmsconn.AppendUserAgent(Terraform, version)
-		client.kmsconn = kmsconn
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	return do(client.kmsconn)
+
+	return client.invoke("kms", func() (interface{}, error) {
+		return do(client.kmsconn)
+	})
 }
 
 func (client *AliyunClient) WithOtsClient(do func(*ots.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
+	err := client.initClient("otsconn", func() error {
+		// Initialize the OTS client if necessary
+		if client.otsconn == nil {
+			endpoint := client.config.OtsEndpoint
+			if endpoint == "" {
+				endpoint = client.config.loadEndpoint(OTSCode)
+			}
+			otsconn, err := ots.NewClientWithOptions(client.config.RegionId, client.getSdkConfigByEndpoint(endpoint), client.getAuthCredential("ots", true))
+			if err != nil {
+				return fmt.Errorf("unable to initialize the OTS client: %#v", err)
+			}
 
-	// Initialize the OTS client if necessary
-	if client.otsconn == nil {
-		endpoint := client.config.OtsEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.config.RegionId, OTSCode)
-		}
-		if endpoint != "" {
-			endpoints.AddEndpointMapping(client.config.RegionId, string(OTSCode), endpoint)
-		}
-		otsconn, err := ots.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
-		if err != nil {
-			return nil, fmt.Errorf("unable to initialize the OTS client: %#v", err)
+			otsconn.AppendUserAgent(Terraform, version)
+			client.otsconn = otsconn
 		}
-
-		otsconn.AppendUserAgent(Terraform, version)
-		client.otsconn = otsconn
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return do(client.otsconn)
+	return client.invoke("ots", func() (interface{}, error) {
+		return do(client.otsconn)
+	})
 }
 
 func (client *AliyunClient) WithCmsClient(do func(*cms.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
+	err := client.initClient("cmsconn", func() error {
+		// Initialize the CMS client if necessary
+		if client.cmsconn == nil {
+			cmsconn, err := cms.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.getAuthCredential("cms", false))
+			if err != nil {
+				return fmt.Errorf("unable to initialize the CMS client: %#v", err)
+			}
 
-	// Initialize the CMS client if necessary
-	if client.cmsconn == nil {
-		cmsconn, err := cms.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(false))
-		if err != nil {
-			return nil, fmt.Errorf("unable to initialize the CMS client: %#v", err)
+			cmsconn.AppendUserAgent(Terraform, version)
+			client.cmsconn = cmsconn
 		}
-
-		cmsconn.AppendUserAgent(Terraform, version)
-		client.cmsconn = cmsconn
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return do(client.cmsconn)
+	return client.invoke("cms", func() (interface{}, error) {
+		return do(client.cmsconn)
+	})
 }
 
 func (client *AliyunClient) WithPvtzClient(do func(*pvtz.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
+	err := client.initClient("pvtzconn", func() error {
+		// Initialize the PVTZ client if necessary
+		if client.pvtzconn == nil {
+			endpoint := client.config.PvtzEndpoint
+			if endpoint == "" {
+				endpoint = client.config.loadEndpoint(PVTZCode)
+			}
+			pvtzconn, err := pvtz.NewClientWithOptions(client.config.RegionId, client.getSdkConfigByEndpoint(endpoint), client.getAuthCredential("pvtz", true))
+			if err != nil {
+				return fmt.Errorf("unable to initialize the PVTZ client: %#v", err)
+			}
 
-	// Initialize the PVTZ client if necessary
-	if client.pvtzconn == nil {
-		endpoint := client.config.PvtzEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.config.RegionId, PVTZCode)
+			pvtzconn.AppendUserAgent(Terraform, version)
+			client.pvtzconn = pvtzconn
 		}
-		if endpoint != "" {
-			endpoints.AddEndpointMapping(client.config.RegionId, string(PVTZCode), endpoint)
-		} else {
-			endpoints.AddEndpointMapping(client.config.RegionId, string(PVTZCode), "pvtz.aliyuncs.com")
-		}
-		pvtzconn, err := pvtz.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
-		if err != nil {
-			return nil, fmt.Errorf("unable to initialize the PVTZ client: %#v", err)
-		}
-
-		pvtzconn.AppendUserAgent(Terraform, version)
-		client.pvtzconn = pvtzconn
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return do(client.pvtzconn)
+	return client.invoke("pvtz", func() (interface{}, error) {
+		return do(client.pvtzconn)
+	})
 }
 
 func (client *AliyunClient) WithStsClient(do func(*sts.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
+	err := client.initClient("stsconn", func() error {
+		// Initialize the STS client if necessary
+		if client.stsconn == nil {
+			endpoint := client.config.StsEndpoint
+			if endpoint == "" {
+				endpoint = client.config.loadEndpoint(STSCode)
+			}
+			stsconn, err := sts.NewClientWithOptions(client.config.RegionId, client.getSdkConfigByEndpoint(endpoint), client.getAuthCredential("sts", true))
+			if err != nil {
+				return fmt.Errorf("unable to initialize the STS client: %#v", err)
+			}
 
-	// Initialize the STS client if necessary
-	if client.stsconn == nil {
-		endpoint := client.config.StsEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.config.RegionId, STSCode)
-		}
-		if endpoint != "" {
-			endpoints.AddEndpointMapping(client.config.RegionId, string(STSCode), endpoint)
-		}
-		stsconn, err := sts.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
-		if err != nil {
-			return nil, fmt.Errorf("unable to initialize the STS client: %#v", err)
+			stsconn.AppendUserAgent(Terraform, version)
+			client.stsconn = stsconn
 		}
-
-		stsconn.AppendUserAgent(Terraform, version)
-		client.stsconn = stsconn
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return do(client.stsconn)
+	return client.invoke("sts", func() (interface{}, error) {
+		return do(client.stsconn)
+	})
 }
 
 func (client *AliyunClient) WithLogClient(do func(*sls.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
-
-	// Initialize the LOG client if necessary
-	if client.logconn == nil {
-		endpoint := client.config.LogEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.config.RegionId, LOGCode)
+	err := client.initClient("logconn", func() error {
+		// Initialize the LOG client if necessary
+		if client.logconn == nil {
+			endpoint := client.config.LogEndpoint
 			if endpoint == "" {
-				endpoint = fmt.Sprintf("%s.log.aliyuncs.com", client.config.RegionId)
-			}
-		}
-		if !strings.HasPrefix(endpoint, "http") {
-			endpoint = fmt.Sprintf("https://%s", strings.TrimPrefix(endpoint, "://"))
-		}
-		accessKey, secretKey, securityToken, err := client.config.getAuthCredentialByEcsRoleName()
-		if err != nil {
-			return nil, err
-		}
-		client.logconn = &sls.Client{
-			AccessKeyID:     accessKey,
-			AccessKeySecret: secretKey,
-			Endpoint:        endpoint,
-			SecurityToken:   securityToken,
-			UserAgent:       client.getUserAgent(),
+				endpoint = client.config.loadEndpoint(LOGCode)
+				if endpoint == "" {
+					endpoint = fmt.Sprintf("%s.log.aliyuncs.com", client.config.RegionId)
+				}
+			}
+			endpoint = client.withScheme(endpoint)
+			accessKey, secretKey, securityToken, err := client.config.getAuthCredentialByEcsRoleName()
+			if err != nil {
+				return err
+			}
+			logconn := &sls.Client{
+				AccessKeyID:     accessKey,
+				AccessKeySecret: secretKey,
+				Endpoint:        endpoint,
+				SecurityToken:   securityToken,
+				UserAgent:       client.getUserAgent(),
+			}
+			client.onCredentialRefresh("log", logconn.ResetAccessKeyToken)
+			client.logconn = logconn
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return do(client.logconn)
+	return client.invoke("log", func() (interface{}, error) {
+		return do(client.logconn)
+	})
 }
 
 func (client *AliyunClient) WithDrdsClient(do func(*drds.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
-
-	// Initialize the DRDS client if necessary
-	if client.drdsconn == nil {
-		endpoint := client.config.DrdsEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.config.RegionId, DRDSCode)
+	err := client.initClient("drdsconn", func() error {
+		// Initialize the DRDS client if necessary
+		if client.drdsconn == nil {
+			endpoint := client.config.DrdsEndpoint
 			if endpoint == "" {
-				endpoint = fmt.Sprintf("%s.drds.aliyuncs.com", client.config.RegionId)
+				endpoint = client.config.loadEndpoint(DRDSCode)
+				if endpoint == "" {
+					endpoint = fmt.Sprintf("%s.drds.aliyuncs.com", client.config.RegionId)
+				}
 			}
-		}
 
-		drdsconn, err := drds.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
-		if err != nil {
-			return nil, fmt.Errorf("unable to initialize the DRDS client: %#v", err)
+			drdsconn, err := drds.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.getAuthCredential("drds", true))
+			if err != nil {
+				return fmt.Errorf("unable to initialize the DRDS client: %#v", err)
 
-		}
+			}
 
-		drdsconn.AppendUserAgent(Terraform, version)
-		client.drdsconn = drdsconn
+			drdsconn.AppendUserAgent(Terraform, version)
+			client.drdsconn = drdsconn
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return do(client.drdsconn)
+	return client.invoke("drds", func() (interface{}, error) {
+		return do(client.drdsconn)
+	})
 }
 
 func (client *AliyunClient) WithDdsClient(do func(*dds.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
+	err := client.initClient("ddsconn", func() error {
+		// Initialize the DDS client if necessary
+		if client.ddsconn == nil {
+			endpoint := client.config.DdsEndpoint
+			if endpoint == "" {
+				endpoint = client.config.loadEndpoint(DDSCode)
+			}
+			ddsconn, err := dds.NewClientWithOptions(client.config.RegionId, client.getSdkConfigByEndpoint(endpoint), client.getAuthCredential("dds", true))
+			if err != nil {
+				return fmt.Errorf("unable to initialize the DDS client: %#v", err)
+			}
 
-	// Initialize the DDS client if necessary
-	if client.ddsconn == nil {
-		endpoint := client.config.DdsEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.config.RegionId, DDSCode)
-		}
-		if endpoint != "" {
-			endpoints.AddEndpointMapping(client.config.RegionId, string(DDSCode), endpoint)
-		}
-		ddsconn, err := dds.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
-		if err != nil {
-			return nil, fmt.Errorf("unable to initialize the DDS client: %#v", err)
+			ddsconn.AppendUserAgent(Terraform, version)
+			client.ddsconn = ddsconn
 		}
-
-		ddsconn.AppendUserAgent(Terraform, version)
-		client.ddsconn = ddsconn
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return do(client.ddsconn)
+	return client.invoke("dds", func() (interface{}, error) {
+		return do(client.ddsconn)
+	})
 }
 
 func (client *AliyunClient) WithGpdbClient(do func(*gpdb.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
+	err := client.initClient("gpdbconn", func() error {
+		// Initialize the GPDB client if necessary
+		if client.gpdbconn == nil {
+			endpoint := client.config.GpdbEnpoint
+			if endpoint == "" {
+				endpoint = client.config.loadEndpoint(GPDBCode)
+			}
+			gpdbconn, err := gpdb.NewClientWithOptions(client.config.RegionId, client.getSdkConfigByEndpoint(endpoint), client.getAuthCredential("gpdb", true))
+			if err != nil {
+				return fmt.Errorf("unable to initialize the GPDB client: %#v", err)
+			}
 
-	// Initialize the GPDB client if necessary
-	if client.gpdbconn == nil {
-		endpoint := client.config.GpdbEnpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.config.RegionId, GPDBCode)
-		}
-		if endpoint != "" {
-			endpoints.AddEndpointMapping(client.config.RegionId, string(GPDBCode), endpoint)
-		}
-		gpdbconn, err := gpdb.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
-		if err != nil {
-			return nil, fmt.Errorf("unable to initialize the GPDB client: %#v", err)
+			gpdbconn.AppendUserAgent(Terraform, version)
+			client.gpdbconn = gpdbconn
 		}
-
-		gpdbconn.AppendUserAgent(Terraform, version)
-		client.gpdbconn = gpdbconn
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return do(client.gpdbconn)
+	return client.invoke("gpdb", func() (interface{}, error) {
+		return do(client.gpdbconn)
+	})
 }
 
 func (client *AliyunClient) WithRkvClient(do func(*r_kvstore.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
+	err := client.initClient("rkvconn", func() error {
+		// Initialize the RKV client if necessary
+		if client.rkvconn == nil {
+			endpoint := client.config.KVStoreEndpoint
+			if endpoint == "" {
+				endpoint = client.config.loadEndpoint(KVSTORECode)
+			}
+			rkvconn, err := r_kvstore.NewClientWithOptions(client.config.RegionId, client.getSdkConfigByEndpoint(endpoint), client.getAuthCredential("r-kvstore", true))
+			if err != nil {
+				return fmt.Errorf("unable to initialize the RKV client: %#v", err)
+			}
 
-	// Initialize the RKV client if necessary
-	if client.rkvconn == nil {
-		endpoint := client.config.KVStoreEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.config.RegionId, KVSTORECode)
-		}
-		if endpoint != "" {
-			endpoints.AddEndpointMapping(client.config.RegionId, fmt.Sprintf("R-%s", string(KVSTORECode)), endpoint)
-		}
-		rkvconn, err := r_kvstore.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
-		if err != nil {
-			return nil, fmt.Errorf("unable to initialize the RKV client: %#v", err)
+			rkvconn.AppendUserAgent(Terraform, version)
+			client.rkvconn = rkvconn
 		}
-
-		rkvconn.AppendUserAgent(Terraform, version)
-		client.rkvconn = rkvconn
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return do(client.rkvconn)
+	return client.invoke("r-kvstore", func() (interface{}, error) {
+		return do(client.rkvconn)
+	})
 }
 
 func (client *AliyunClient) WithFcClient(do func(*fc.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
-
-	// Initialize the FC client if necessary
-	if client.fcconn == nil {
-		endpoint := client.config.FcEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.config.RegionId, FCCode)
+	err := client.initClient("fcconn", func() error {
+		// Initialize the FC client if necessary
+		if client.fcconn == nil {
+			endpoint := client.config.FcEndpoint
 			if endpoint == "" {
-				endpoint = fmt.Sprintf("%s.fc.aliyuncs.com", client.config.RegionId)
+				endpoint = client.config.loadEndpoint(FCCode)
+				if endpoint == "" {
+					endpoint = fmt.Sprintf("%s.fc.aliyuncs.com", client.config.RegionId)
+				}
+			}
+			scheme, endpoint := splitEndpointScheme(endpoint)
+			if scheme == "" {
+				scheme = "HTTPS"
+			}
+			accountId, err := client.AccountId()
+			if err != nil {
+				return err
+			}
+			accessKey, secretKey, securityToken, err := client.config.getAuthCredentialByEcsRoleName()
+			if err != nil {
+				return err
+			}
+			config := client.getSdkConfig()
+			clientOptions := []fc.ClientOption{fc.WithSecurityToken(securityToken), fc.WithTransport(config.HttpTransport),
+				fc.WithTimeout(30), fc.WithRetryCount(DefaultClientRetryCountSmall)}
+			fcconn, err := fc.NewClient(fmt.Sprintf("%s://%s.%s", strings.ToLower(scheme), accountId, endpoint), string(ApiVersion20160815), accessKey, secretKey, clientOptions...)
+			if err != nil {
+				return fmt.Errorf("unable to initialize the FC client: %#v", err)
 			}
-		}
-		if strings.HasPrefix(endpoint, "http") {
-			endpoint = strings.TrimPrefix(strings.TrimPrefix(endpoint, "http://"), "https://")
-		}
-		accountId, err := client.AccountId()
-		if err != nil {
-			return nil, err
-		}
-		accessKey, secretKey, securityToken, err := client.config.getAuthCredentialByEcsRoleName()
-		if err != nil {
-			return nil, err
-		}
-		config := client.getSdkConfig()
-		clientOptions := []fc.ClientOption{fc.WithSecurityToken(securityToken), fc.WithTransport(config.HttpTransport),
-			fc.WithTimeout(30), fc.WithRetryCount(DefaultClientRetryCountSmall)}
-		fcconn, err := fc.NewClient(fmt.Sprintf("https://%s.%s", accountId, endpoint), string(ApiVersion20160815), accessKey, secretKey, clientOptions...)
-		if err != nil {
-			return nil, fmt.Errorf("unable to initialize the FC client: %#v", err)
-		}
 
-		fcconn.Config.UserAgent = client.getUserAgent()
-		fcconn.Config.SecurityToken = client.config.SecurityToken
-		client.fcconn = fcconn
+			fcconn.Config.UserAgent = client.getUserAgent()
+			client.onCredentialRefresh("fc", func(accessKey, secretKey, token string) {
+				fcconn.Config.AccessKeyID, fcconn.Config.AccessKeySecret, fcconn.Config.SecurityToken = accessKey, secretKey, token
+			})
+			client.fcconn = fcconn
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return do(client.fcconn)
+	return client.invoke("fc", func() (interface{}, error) {
+		return do(client.fcconn)
+	})
 }
 
 func (client *AliyunClient) WithCloudApiClient(do func(*cloudapi.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
+	err := client.initClient("cloudapiconn", func() error {
+		// Initialize the Cloud API client if necessary
+		if client.cloudapiconn == nil {
+			endpoint := client.config.ApigatewayEndpoint
+			if endpoint == "" {
+				endpoint = client.config.loadEndpoint(CLOUDAPICode)
+			}
+			cloudapiconn, err := cloudapi.NewClientWithOptions(client.RegionId, client.getSdkConfigByEndpoint(endpoint), client.getAuthCredential("cloudapi", true))
+			if err != nil {
+				return fmt.Errorf("unable to initialize the CloudAPI client: %#v", err)
+			}
 
-	// Initialize the Cloud API client if necessary
-	if client.cloudapiconn == nil {
-		endpoint := client.config.ApigatewayEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.RegionId, CLOUDAPICode)
-		}
-		if endpoint != "" {
-			endpoints.AddEndpointMapping(client.RegionId, "CLOUDAPI", endpoint)
-		}
-		cloudapiconn, err := cloudapi.NewClientWithOptions(client.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
-		if err != nil {
-			return nil, fmt.Errorf("unable to initialize the CloudAPI client: %#v", err)
+			cloudapiconn.AppendUserAgent(Terraform, version)
+			client.cloudapiconn = cloudapiconn
 		}
-
-		cloudapiconn.AppendUserAgent(Terraform, version)
-		client.cloudapiconn = cloudapiconn
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return do(client.cloudapiconn)
+	return client.invoke("cloudapi", func() (interface{}, error) {
+		return do(client.cloudapiconn)
+	})
 }
 
 func (client *AliyunClient) WithDataHubClient(do func(*datahub.DataHub) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
-
-	// Initialize the DataHub client if necessary
-	if client.dhconn == nil {
-		endpoint := client.config.DatahubEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.RegionId, DATAHUBCode)
-		}
-		if endpoint == "" {
-			if client.RegionId == string(APSouthEast1) {
-				endpoint = "dh-singapore.aliyuncs.com"
-			} else {
-				endpoint = fmt.Sprintf("dh-%s.aliyuncs.com", client.RegionId)
+	err := client.initClient("dhconn", func() error {
+		// Initialize the DataHub client if necessary
+		if client.dhconn == nil {
+			endpoint := client.config.DatahubEndpoint
+			if endpoint == "" {
+				endpoint = client.config.loadEndpoint(DATAHUBCode)
+			}
+			if endpoint == "" {
+				if client.RegionId == string(APSouthEast1) {
+					endpoint = "dh-singapore.aliyuncs.com"
+				} else {
+					endpoint = fmt.Sprintf("dh-%s.aliyuncs.com", client.RegionId)
+				}
+			}
+			endpoint = client.withScheme(endpoint)
+			accessKey, secretKey, securityToken, err := client.config.getAuthCredentialByEcsRoleName()
+			if err != nil {
+				return err
+			}
+			account := datahub.NewStsCredential(accessKey, secretKey, securityToken)
+			client.onCredentialRefresh("datahub", func(accessKey, secretKey, token string) {
+				account.AccessId, account.AccessKey, account.SecurityToken = accessKey, secretKey, token
+			})
+			config := &datahub.Config{
+				UserAgent: client.getUserAgent(),
 			}
-		}
-		if !strings.HasPrefix(endpoint, "http") {
-			endpoint = fmt.Sprintf("https://%s", endpoint)
-		}
-		accessKey, secretKey, securityToken, err := client.config.getAuthCredentialByEcsRoleName()
-		if err != nil {
-			return nil, err
-		}
-		account := datahub.NewStsCredential(accessKey, secretKey, securityToken)
-		config := &datahub.Config{
-			UserAgent: client.getUserAgent(),
-		}
 
-		client.dhconn = datahub.NewClientWithConfig(endpoint, config, account)
+			client.dhconn = datahub.NewClientWithConfig(endpoint, config, account)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return do(client.dhconn)
+	return client.invoke("datahub", func() (interface{}, error) {
+		return do(client.dhconn)
+	})
 }
 
 func (client *AliyunClient) WithMnsClient(do func(*ali_mns.MNSClient) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
-
-	// Initialize the MNS client if necessary
-	if client.mnsconn == nil {
-		endpoint := client.config.MnsEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.config.RegionId, MNSCode)
+	err := client.initClient("mnsconn", func() error {
+		// Initialize the MNS client if necessary
+		if client.mnsconn == nil {
+			endpoint := client.config.MnsEndpoint
 			if endpoint == "" {
-				endpoint = fmt.Sprintf("%s.aliyuncs.com", client.config.RegionId)
+				endpoint = client.config.loadEndpoint(MNSCode)
+				if endpoint == "" {
+					endpoint = fmt.Sprintf("%s.aliyuncs.com", client.config.RegionId)
+				}
 			}
-		}
 
-		accountId, err := client.AccountId()
-		if err != nil {
-			return nil, err
-		}
-		if strings.HasPrefix(endpoint, "http") {
-			endpoint = strings.TrimPrefix(strings.TrimPrefix(endpoint, "http://"), "https://")
-		}
-		mnsUrl := fmt.Sprintf("https://%s.mns.%s", accountId, endpoint)
+			accountId, err := client.AccountId()
+			if err != nil {
+				return err
+			}
+			scheme, endpoint := splitEndpointScheme(endpoint)
+			if scheme == "" {
+				scheme = "HTTPS"
+			}
+			mnsUrl := fmt.Sprintf("%s://%s.mns.%s", strings.ToLower(scheme), accountId, endpoint)
 
-		mnsClient := ali_mns.NewAliMNSClient(mnsUrl, client.config.AccessKey, client.config.SecretKey)
+			accessKey, secretKey, securityToken, err := client.config.getAuthCredentialByEcsRoleName()
+			if err != nil {
+				return err
+			}
+			mnsClient := newStsMnsClient(mnsUrl, accessKey, secretKey, securityToken)
+			mnsClient.tracer, mnsClient.region = client.tracer, client.RegionId
+			client.onCredentialRefresh("mns", mnsClient.reset)
 
-		client.mnsconn = &mnsClient
+			var mnsconn ali_mns.MNSClient = mnsClient
+			client.mnsconn = &mnsconn
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return do(client.mnsconn)
+	return client.invoke("mns", func() (interface{}, error) {
+		return do(client.mnsconn)
+	})
 }
 
 func (client *AliyunClient) WithElasticsearchClient(do func(*elasticsearch.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
+	err := client.initClient("elasticsearchconn", func() error {
+		// Initialize the Elasticsearch client if necessary
+		if client.elasticsearchconn == nil {
+			endpoint := client.config.ElasticsearchEndpoint
+			if endpoint == "" {
+				endpoint = client.config.loadEndpoint(ELASTICSEARCHCode)
+			}
+			elasticsearchconn, err := elasticsearch.NewClientWithOptions(client.config.RegionId, client.getSdkConfigByEndpoint(endpoint), client.getAuthCredential("elasticsearch", true))
+			if err != nil {
+				return fmt.Errorf("unable to initialize the Elasticsearch client: %#v", err)
+			}
 
-	// Initialize the Elasticsearch client if necessary
-	if client.elasticsearchconn == nil {
-		endpoint := client.config.ElasticsearchEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.config.RegionId, ELASTICSEARCHCode)
+			elasticsearchconn.AppendUserAgent(Terraform, version)
+			client.elasticsearchconn = elasticsearchconn
 		}
-		if endpoint != "" {
-			endpoints.AddEndpointMapping(client.config.RegionId, string(ELASTICSEARCHCode), endpoint)
-		}
-		elasticsearchconn, err := elasticsearch.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
-		if err != nil {
-			return nil, fmt.Errorf("unable to initialize the Elasticsearch client: %#v", err)
-		}
-
-		elasticsearchconn.AppendUserAgent(Terraform, version)
-		client.elasticsearchconn = elasticsearchconn
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return do(client.elasticsearchconn)
+	return client.invoke("elasticsearch", func() (interface{}, error) {
+		return do(client.elasticsearchconn)
+	})
 }
 
 func (client *AliyunClient) WithMnsQueueManager(do func(ali_mns.AliQueueManager) (interface{}, error)) (interface{}, error) {
@@ -977,57 +1405,79 @@ func (client *AliyunClient) WithMnsSubscriptionManagerByTopicName(topicName stri
 }
 
 func (client *AliyunClient) WithTableStoreClient(instanceName string, do func(*tablestore.TableStoreClient) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
-
-	// Initialize the TABLESTORE client if necessary
-	tableStoreClient, ok := client.tablestoreconnByInstanceName[instanceName]
-	if !ok {
-		endpoint := client.config.OtsEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.RegionId, OTSCode)
-		}
-		if endpoint == "" {
-			endpoint = fmt.Sprintf("%s.%s.ots.aliyuncs.com", instanceName, client.RegionId)
-		}
-		if !strings.HasPrefix(endpoint, "https") && !strings.HasPrefix(endpoint, "http") {
-			endpoint = fmt.Sprintf("https://%s", endpoint)
-		}
+	var tableStoreClient *tablestore.TableStoreClient
+	err := client.initClient("tablestoreconn", func() error {
+		// Initialize the TABLESTORE client if necessary
 		accessKey, secretKey, securityToken, err := client.config.getAuthCredentialByEcsRoleName()
 		if err != nil {
-			return nil, err
+			return err
+		}
+		// The SDK does not allow changing the credentials of a client, so it is built again after they are refreshed
+		var ok bool
+		tableStoreClient, ok = client.tablestoreconnByInstanceName[instanceName]
+		if !ok || client.tablestoreTokenByInstanceName[instanceName] != accessKey+securityToken {
+			endpoint := client.config.OtsEndpoint
+			if endpoint == "" {
+				endpoint = client.config.loadEndpoint(OTSCode)
+			}
+			if endpoint == "" {
+				endpoint = fmt.Sprintf("%s.%s.ots.aliyuncs.com", instanceName, client.RegionId)
+			}
+			endpoint = client.withScheme(endpoint)
+			config := tablestore.NewDefaultTableStoreConfig()
+			if client.tracer != nil {
+				// The transport replaces the one of the SDK, so it is built by the same config
+				config.Transport = client.tracingTransport("tablestore", &http.Transport{
+					MaxIdleConnsPerHost: config.MaxIdleConnections,
+					Dial:                (&net.Dialer{Timeout: config.HTTPTimeout.ConnectionTimeout}).Dial,
+				})
+			}
+			tableStoreClient = tablestore.NewClientWithConfig(endpoint, instanceName, accessKey, secretKey, securityToken, config)
+			client.tablestoreconnByInstanceName[instanceName] = tableStoreClient
+			client.tablestoreTokenByInstanceName[instanceName] = accessKey + securityToken
 		}
-		tableStoreClient = tablestore.NewClientWithConfig(endpoint, instanceName, accessKey, secretKey, securityToken, tablestore.NewDefaultTableStoreConfig())
-		client.tablestoreconnByInstanceName[instanceName] = tableStoreClient
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return do(tableStoreClient)
+	return client.invoke("tablestore", func() (interface{}, error) {
+		return do(tableStoreClient)
+	})
 }
 
 func (client *AliyunClient) WithCsProjectClient(clusterId, endpoint string, clusterCerts cs.ClusterCerts, do func(*cs.ProjectClient) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
-
-	// Initialize the PROJECT client if necessary
-	key := fmt.Sprintf("%s|%s|%s|%s|%s", clusterId, endpoint, clusterCerts.CA, clusterCerts.Cert, clusterCerts.Key)
-	csProjectClient, ok := client.csprojectconnByKey[key]
-	if !ok {
-		var err error
-		csProjectClient, err = cs.NewProjectClient(clusterId, endpoint, clusterCerts)
-		if err != nil {
-			return nil, fmt.Errorf("Getting Application Client failed by cluster id %s: %#v.", clusterCerts, err)
+	var csProjectClient *cs.ProjectClient
+	err := client.initClient("csprojectconn", func() error {
+		// Initialize the PROJECT client if necessary
+		key := fmt.Sprintf("%s|%s|%s|%s|%s", clusterId, endpoint, clusterCerts.CA, clusterCerts.Cert, clusterCerts.Key)
+		var ok bool
+		csProjectClient, ok = client.csprojectconnByKey[key]
+		if !ok {
+			var err error
+			csProjectClient, err = cs.NewProjectClient(clusterId, endpoint, clusterCerts)
+			if err != nil {
+				return fmt.Errorf("Getting Application Client failed by cluster id %s: %#v.", clusterCerts, err)
+			}
+			csProjectClient.SetDebug(false)
+			csProjectClient.SetUserAgent(client.getUserAgent())
+			client.csprojectconnByKey[key] = csProjectClient
 		}
-		csProjectClient.SetDebug(false)
-		csProjectClient.SetUserAgent(client.getUserAgent())
-		client.csprojectconnByKey[key] = csProjectClient
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return do(csProjectClient)
+	return client.invoke("cs", func() (interface{}, error) {
+		return do(csProjectClient)
+	})
 }
 
 func (client *AliyunClient) NewCommonRequest(product, serviceCode, schema string, apiVersion ApiVersion) (*requests.CommonRequest, error) {
 	request := requests.NewCommonRequest()
-	endpoint := loadEndpoint(client.RegionId, ServiceCode(strings.ToUpper(product)))
+	endpoint := client.config.loadEndpoint(ServiceCode(strings.ToUpper(product)))
 	if endpoint == "" {
 		endpointItem, err := client.describeEndpointForService(serviceCode)
 		if err != nil {
@@ -1039,6 +1489,9 @@ func (client *AliyunClient) NewCommonRequest(product, serviceCode, schema string
 	}
 	// Use product code to find product domain
 	if endpoint != "" {
+		if scheme, domain := splitEndpointScheme(endpoint); scheme != "" {
+			endpoint, schema = domain, scheme
+		}
 		request.Domain = endpoint
 	} else {
 		// When getting endpoint failed by location, using custom endpoint instead
@@ -1069,6 +1522,15 @@ func (client *AliyunClient) AccountId() (string, error) {
 	return client.accountId, nil
 }
 
+// withScheme prefixes the endpoint of the clients which are not built on alibaba-cloud-sdk-go with HTTPS, unless it has
+// a scheme of its own, like http://oss.example.com.
+func (client *AliyunClient) withScheme(endpoint string) string {
+	if scheme, _ := splitEndpointScheme(endpoint); scheme != "" {
+		return endpoint
+	}
+	return fmt.Sprintf("https://%s", strings.TrimPrefix(endpoint, "://"))
+}
+
 func (client *AliyunClient) getSdkConfig() *sdk.Config {
 	return sdk.NewConfig().
 		WithMaxRetryTime(DefaultClientRetryCountSmall).
@@ -1079,6 +1541,16 @@ func (client *AliyunClient) getSdkConfig() *sdk.Config {
 		WithScheme("HTTPS")
 }
 
+// getSdkConfigByEndpoint returns the SDK config whose scheme is the one of the endpoint, like http://ecs.example.com,
+// or HTTPS if the endpoint has none.
+func (client *AliyunClient) getSdkConfigByEndpoint(endpoint string) *sdk.Config {
+	config := client.getSdkConfig()
+	if scheme, _ := splitEndpointScheme(endpoint); scheme != "" {
+		config.WithScheme(scheme)
+	}
+	return config
+}
+
 func (client *AliyunClient) getUserAgent() string {
 	return fmt.Sprintf("HashiCorp-Terraform-v%s", version)
 }
@@ -1122,11 +1594,14 @@ func (client *AliyunClient) describeEndpointForService(serviceCode string) (*loc
 	args.Id = client.config.RegionId
 	args.Domain = client.config.LocationEndpoint
 	if args.Domain == "" {
-		args.Domain = loadEndpoint(client.RegionId, LOCATIONCode)
+		args.Domain = client.config.loadEndpoint(LOCATIONCode)
 	}
 	if args.Domain == "" {
 		args.Domain = "location-readonly.aliyuncs.com"
 	}
+	if scheme, domain := splitEndpointScheme(args.Domain); scheme != "" {
+		args.Domain, args.Scheme = domain, scheme
+	}
 
 	locationClient, err := location.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
 	if err != nil {
@@ -1148,15 +1623,83 @@ func (client *AliyunClient) describeEndpointForService(serviceCode string) (*loc
 	return nil, fmt.Errorf("There is no any available endpoint for %s in region %s.", serviceCode, client.RegionId)
 }
 
+// initRoleCredential prepares the STS credentials of the ECS role or assume_role for the clients which are not built
+// on alibaba-cloud-sdk-go. The credentials of the SDK clients are refreshed by the SDK itself, except the ones of the
+// role assumed by the credentials of the credential chain, which are copied into each of the clients.
+func (client *AliyunClient) initRoleCredential() error {
+	c := client.config
+	if c.sessionCredential != nil {
+		if c.RamRoleArn == "" {
+			return nil
+		}
+		session, _ := c.sessionCredential.current()
+		provider, err := client.newAssumeRoleCredentialProvider(credentials.NewStsTokenCredential(session.AccessKeyId, session.AccessKeySecret, session.SecurityToken))
+		if err != nil {
+			return err
+		}
+		provider.source = c.sessionCredential
+		c.roleCredential = newRefreshingCredential(provider)
+		// The SDK clients are signed by the credentials of the role, so they are fetched at once
+		return c.roleCredential.refresh()
+	}
+	if c.AccessKey != "" && c.SecretKey != "" {
+		if c.RamRoleArn == "" {
+			return nil
+		}
+		provider, err := client.newAssumeRoleCredentialProvider(credentials.NewStsTokenCredential(c.AccessKey, c.SecretKey, c.SecurityToken))
+		if err != nil {
+			return err
+		}
+		c.roleCredential = newRefreshingCredential(provider)
+		return nil
+	}
+	if c.EcsRoleName != "" {
+		c.roleCredential = newRefreshingCredential(&uriCredentialProvider{uri: securityCredURL + c.EcsRoleName, source: "ecs_role_name"})
+	}
+	return nil
+}
+
+// newAssumeRoleCredentialProvider returns the provider of the assume_role credentials, which calls AssumeRole with
+// the given credentials.
+func (client *AliyunClient) newAssumeRoleCredentialProvider(credential *credentials.StsTokenCredential) (*assumeRoleCredentialProvider, error) {
+	c := client.config
+	stsClient, err := sts.NewClientWithOptions(c.RegionId, client.getSdkConfig(), credential)
+	if err != nil {
+		return nil, fmt.Errorf("unable to initialize the STS client: %#v", err)
+	}
+	stsClient.AppendUserAgent(Terraform, version)
+	domain := c.StsEndpoint
+	if domain == "" {
+		domain = c.loadEndpoint(STSCode)
+	}
+	sessionName := c.RamRoleSessionName
+	if sessionName == "" {
+		sessionName = "terraform"
+	}
+	return &assumeRoleCredentialProvider{
+		stsClient:       stsClient,
+		credential:      credential,
+		domain:          domain,
+		roleArn:         c.RamRoleArn,
+		sessionName:     sessionName,
+		policy:          c.RamRolePolicy,
+		durationSeconds: c.RamRoleSessionExpiration,
+	}, nil
+}
+
 func (client *AliyunClient) getCallerIdentity() (*sts.GetCallerIdentityResponse, error) {
 	args := sts.CreateGetCallerIdentityRequest()
 
 	endpoint := client.config.StsEndpoint
 	if endpoint == "" {
-		endpoint = loadEndpoint(client.config.RegionId, STSCode)
+		endpoint = client.config.loadEndpoint(STSCode)
 	}
 	if endpoint != "" {
-		endpoints.AddEndpointMapping(client.config.RegionId, string(STSCode), endpoint)
+		scheme, domain := splitEndpointScheme(endpoint)
+		args.Domain = domain
+		if scheme != "" {
+			args.Scheme = scheme
+		}
 	}
 	stsClient, err := sts.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
 	if err != nil {
@@ -1164,10 +1707,13 @@ func (client *AliyunClient) getCallerIdentity() (*sts.GetCallerIdentityResponse,
 	}
 
 	stsClient.AppendUserAgent(Terraform, version)
-	identity, err := stsClient.GetCallerIdentity(args)
+	raw, err := client.invoke("sts", func() (interface{}, error) {
+		return stsClient.GetCallerIdentity(args)
+	})
 	if err != nil {
 		return nil, err
 	}
+	identity, _ := raw.(*sts.GetCallerIdentityResponse)
 	if identity == nil {
 		return nil, fmt.Errorf("caller identity not found")
 	}
@@ -1175,108 +1721,125 @@ func (client *AliyunClient) getCallerIdentity() (*sts.GetCallerIdentityResponse,
 }
 
 func (client *AliyunClient) WithActionTrailClient(do func(*actiontrail.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
-	if client.actiontrailconn == nil {
-		endpoint := client.config.ActionTrailEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.config.RegionId, ACTIONTRAILCode)
-		}
-		if endpoint != "" {
-			endpoints.AddEndpointMapping(client.config.RegionId, string(ACTIONTRAILCode), endpoint)
-		}
-		actiontrailconn, err := actiontrail.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
-		if err != nil {
-			return nil, fmt.Errorf("unable to initialize the ACTIONTRAIL client: %#v", err)
-		}
+	err := client.initClient("actiontrailconn", func() error {
+		if client.actiontrailconn == nil {
+			endpoint := client.config.ActionTrailEndpoint
+			if endpoint == "" {
+				endpoint = client.config.loadEndpoint(ACTIONTRAILCode)
+			}
+			actiontrailconn, err := actiontrail.NewClientWithOptions(client.config.RegionId, client.getSdkConfigByEndpoint(endpoint), client.getAuthCredential("actiontrail", true))
+			if err != nil {
+				return fmt.Errorf("unable to initialize the ACTIONTRAIL client: %#v", err)
+			}
 
-		actiontrailconn.AppendUserAgent(Terraform, version)
-		client.actiontrailconn = actiontrailconn
+			actiontrailconn.AppendUserAgent(Terraform, version)
+			client.actiontrailconn = actiontrailconn
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return do(client.actiontrailconn)
+	return client.invoke("actiontrail", func() (interface{}, error) {
+		return do(client.actiontrailconn)
+	})
 }
 
 func (client *AliyunClient) WithCasClient(do func(*cas.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
+	err := client.initClient("casconn", func() error {
+		// Initialize the CAS client if necessary
+		if client.casconn == nil {
+			casconn, err := cas.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.getAuthCredential("cas", true))
+			if err != nil {
+				return fmt.Errorf("unable to initialize the CAS client: %#v", err)
+			}
 
-	// Initialize the CAS client if necessary
-	if client.casconn == nil {
-		casconn, err := cas.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
-		if err != nil {
-			return nil, fmt.Errorf("unable to initialize the CAS client: %#v", err)
+			casconn.AppendUserAgent(Terraform, version)
+			client.casconn = casconn
 		}
-
-		casconn.AppendUserAgent(Terraform, version)
-		client.casconn = casconn
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return do(client.casconn)
+	return client.invoke("cas", func() (interface{}, error) {
+		return do(client.casconn)
+	})
 }
 
 func (client *AliyunClient) WithDdoscooClient(do func(*ddoscoo.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
+	err := client.initClient("ddoscooconn", func() error {
+		// Initialize the ddoscoo client if necessary
+		if client.ddoscooconn == nil {
+			ddoscooconn, err := ddoscoo.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.getAuthCredential("ddoscoo", true))
+			if err != nil {
+				return fmt.Errorf("unable to initialize the DDOSCOO client: %#v", err)
+			}
 
-	// Initialize the ddoscoo client if necessary
-	if client.ddoscooconn == nil {
-		ddoscooconn, err := ddoscoo.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
-		if err != nil {
-			return nil, fmt.Errorf("unable to initialize the DDOSCOO client: %#v", err)
+			client.ddoscooconn = ddoscooconn
 		}
-
-		client.ddoscooconn = ddoscooconn
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return do(client.ddoscooconn)
+	return client.invoke("ddoscoo", func() (interface{}, error) {
+		return do(client.ddoscooconn)
+	})
 }
 
 func (client *AliyunClient) WithBssopenapiClient(do func(*bssopenapi.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
+	err := client.initClient("bssopenapiconn", func() error {
+		// Initialize the bssopenapi client if necessary
+		if client.bssopenapiconn == nil {
+			endpoint := client.config.BssOpenApiEndpoint
+			if endpoint == "" {
+				endpoint = client.config.loadEndpoint(BSSOPENAPICode)
+			}
 
-	// Initialize the bssopenapi client if necessary
-	if client.bssopenapiconn == nil {
-		endpoint := client.config.BssOpenApiEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.config.RegionId, BSSOPENAPICode)
-		}
-		if endpoint != "" {
-			endpoints.AddEndpointMapping(client.config.RegionId, string(BSSOPENAPICode), endpoint)
-		}
+			bssopenapiconn, err := bssopenapi.NewClientWithOptions(client.config.RegionId, client.getSdkConfigByEndpoint(endpoint), client.getAuthCredential("bssopenapi", true))
+			if err != nil {
+				return fmt.Errorf("unable to initialize the BSSOPENAPI client: %#v", err)
+			}
 
-		bssopenapiconn, err := bssopenapi.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
-		if err != nil {
-			return nil, fmt.Errorf("unable to initialize the BSSOPENAPI client: %#v", err)
+			client.bssopenapiconn = bssopenapiconn
 		}
-
-		client.bssopenapiconn = bssopenapiconn
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return do(client.bssopenapiconn)
+	return client.invoke("bssopenapi", func() (interface{}, error) {
+		return do(client.bssopenapiconn)
+	})
 }
 
 func (client *AliyunClient) WithOnsClient(do func(*ons.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
-
-	// Initialize the ons client if necessary
-	if client.onsconn == nil {
-		endpoint := client.config.OnsEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.config.RegionId, ONSCode)
-		}
-		if endpoint != "" {
-			endpoints.AddEndpointMapping(client.config.RegionId, string(ONSCode), endpoint)
-		}
-		onsconn, err := ons.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
-		if err != nil {
-			return nil, fmt.Errorf("unable to initialize the ONS client: %#v", err)
+	err := client.initClient("onsconn", func() error {
+		// Initialize the ons client if necessary
+		if client.onsconn == nil {
+			endpoint := client.config.OnsEndpoint
+			if endpoint == "" {
+				endpoint = client.config.loadEndpoint(ONSCode)
+			}
+			onsconn, err := ons.NewClientWithOptions(client.config.RegionId, client.getSdkConfigByEndpoint(endpoint), client.getAuthCredential("ons", true))
+			if err != nil {
+				return fmt.Errorf("unable to initialize the ONS client: %#v", err)
+			}
+			onsconn.AppendUserAgent(Terraform, version)
+			client.onsconn = onsconn
 		}
-		onsconn.AppendUserAgent(Terraform, version)
-		client.onsconn = onsconn
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return do(client.onsconn)
+	return client.invoke("ons", func() (interface{}, error) {
+		return do(client.onsconn)
+	})
 }
@@ -0,0 +1,234 @@
+package connectivity
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ess"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/rds"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/slb"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+)
+
+// inFlightServer answers every call after the expected number of calls are in flight at the same time, or after the
+// timeout if they never are. It records the most calls which were in flight at once.
+type inFlightServer struct {
+	*httptest.Server
+	expected int
+	timeout  time.Duration
+	mutex    sync.Mutex
+	cond     *sync.Cond
+	inFlight int
+	max      int
+	// released is set once the expected calls have been in flight, which lets all of the calls through
+	released bool
+}
+
+func newInFlightServer(expected int, timeout time.Duration) *inFlightServer {
+	s := &inFlightServer{expected: expected, timeout: timeout}
+	s.cond = sync.NewCond(&s.mutex)
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+func (s *inFlightServer) serve(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	s.inFlight++
+	if s.inFlight > s.max {
+		s.max = s.inFlight
+	}
+	if s.inFlight >= s.expected {
+		s.released = true
+		s.cond.Broadcast()
+	}
+	timeout := time.AfterFunc(s.timeout, s.cond.Broadcast)
+	deadline := time.Now().Add(s.timeout)
+	for !s.released && time.Now().Before(deadline) {
+		s.cond.Wait()
+	}
+	timeout.Stop()
+	s.inFlight--
+	s.mutex.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte(`{"RequestId": "fake-request", "TotalCount": 0}`))
+}
+
+func (s *inFlightServer) maxInFlight() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.max
+}
+
+func newInFlightClient(t *testing.T, maxConcurrentRequests int, endpoint string) *AliyunClient {
+	config := &Config{
+		AccessKey:             "fake-access-key",
+		SecretKey:             "fake-secret-key",
+		Region:                Region("cn-hangzhou"),
+		RegionId:              "cn-hangzhou",
+		VpcEndpoint:           endpoint,
+		SlbEndpoint:           endpoint,
+		RdsEndpoint:           endpoint,
+		EssEndpoint:           endpoint,
+		SkipRegionValidation:  true,
+		MaxConcurrentRequests: maxConcurrentRequests,
+	}
+	client, err := config.Client()
+	if err != nil {
+		t.Fatalf("Creating the client got an error: %#v.", err)
+	}
+	return client
+}
+
+// describeConcurrently makes the calls at the same time and fails the test if one of them fails.
+func describeConcurrently(t *testing.T, calls ...func() (interface{}, error)) {
+	var wg sync.WaitGroup
+	errs := make(chan error, len(calls))
+	for _, call := range calls {
+		wg.Add(1)
+		go func(call func() (interface{}, error)) {
+			defer wg.Done()
+			_, err := call()
+			errs <- err
+		}(call)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("The call got an error: %#v.", err)
+		}
+	}
+}
+
+func describeVpcs(client *AliyunClient) func() (interface{}, error) {
+	return func() (interface{}, error) {
+		return client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.DescribeVpcs(vpc.CreateDescribeVpcsRequest())
+		})
+	}
+}
+
+func describeLoadBalancers(client *AliyunClient) func() (interface{}, error) {
+	return func() (interface{}, error) {
+		return client.WithSlbClient(func(slbClient *slb.Client) (interface{}, error) {
+			return slbClient.DescribeLoadBalancers(slb.CreateDescribeLoadBalancersRequest())
+		})
+	}
+}
+
+func describeDBInstances(client *AliyunClient) func() (interface{}, error) {
+	return func() (interface{}, error) {
+		return client.WithRdsClient(func(rdsClient *rds.Client) (interface{}, error) {
+			return rdsClient.DescribeDBInstances(rds.CreateDescribeDBInstancesRequest())
+		})
+	}
+}
+
+func describeScalingGroups(client *AliyunClient) func() (interface{}, error) {
+	return func() (interface{}, error) {
+		return client.WithEssClient(func(essClient *ess.Client) (interface{}, error) {
+			return essClient.DescribeScalingGroups(ess.CreateDescribeScalingGroupsRequest())
+		})
+	}
+}
+
+func TestUnitClientConcurrentRequests(t *testing.T) {
+	server := newInFlightServer(4, 2*time.Second)
+	defer server.Close()
+
+	client := newInFlightClient(t, 0, server.URL)
+	describeConcurrently(t, describeVpcs(client), describeLoadBalancers(client), describeDBInstances(client), describeScalingGroups(client))
+	if max := server.maxInFlight(); max != 4 {
+		t.Fatalf("Expected the calls of the 4 products to be in flight at the same time, got at most %d.", max)
+	}
+}
+
+func TestUnitClientMaxConcurrentRequests(t *testing.T) {
+	server := newInFlightServer(2, 2*time.Second)
+	defer server.Close()
+
+	client := newInFlightClient(t, 2, server.URL)
+	describeConcurrently(t, describeVpcs(client), describeVpcs(client), describeLoadBalancers(client),
+		describeLoadBalancers(client), describeDBInstances(client), describeDBInstances(client))
+	if max := server.maxInFlight(); max != 2 {
+		t.Fatalf("Expected at most 2 calls in flight by max_concurrent_requests, got %d.", max)
+	}
+}
+
+func TestUnitClientSerializedRequests(t *testing.T) {
+	server := newInFlightServer(2, 200*time.Millisecond)
+	defer server.Close()
+
+	client := newInFlightClient(t, 0, server.URL)
+	describeConcurrently(t, describeVpcs(client), describeVpcs(client), describeVpcs(client))
+	if max := server.maxInFlight(); max != 1 {
+		t.Fatalf("Expected the calls of the VPC client to be made one by one, got %d calls in flight.", max)
+	}
+}
+
+func TestUnitClientValidateRegion(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tf-regions-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer os.Unsetenv(regionCacheFileEnv)
+	os.Setenv(regionCacheFileEnv, filepath.Join(dir, "regions.json"))
+
+	var mutex sync.Mutex
+	described := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		described++
+		mutex.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"RequestId": "fake-request", "Regions": {"Region": [{"RegionId": "cn-hangzhou"}, {"RegionId": "ap-northeast-2"}]}}`))
+	}))
+	defer server.Close()
+
+	newClient := func() {
+		config := &Config{
+			AccessKey:   "fake-access-key",
+			SecretKey:   "fake-secret-key",
+			Region:      Region("ap-northeast-2"),
+			RegionId:    "ap-northeast-2",
+			EcsEndpoint: server.URL,
+		}
+		if _, err := config.Client(); err != nil {
+			t.Fatalf("Creating the client got an error: %#v.", err)
+		}
+	}
+	// The regions described by the initialization of the ECS client validate the region as well
+	newClient()
+	if described != 1 {
+		t.Fatalf("Expected the regions of ECS to be described once, got %d calls.", described)
+	}
+	newClient()
+	if described != 1 {
+		t.Fatalf("Expected the cached regions of ECS to be used by the next provider, got %d calls.", described)
+	}
+}
+
+func TestUnitClientWithScheme(t *testing.T) {
+	cases := []struct {
+		endpoint string
+		expected string
+	}{
+		{"oss-cn-hangzhou.aliyuncs.com", "https://oss-cn-hangzhou.aliyuncs.com"},
+		{"http://127.0.0.1:8080", "http://127.0.0.1:8080"},
+		{"https://oss.example.com", "https://oss.example.com"},
+	}
+	for _, c := range cases {
+		client := &AliyunClient{config: &Config{}}
+		if actual := client.withScheme(c.endpoint); actual != c.expected {
+			t.Errorf("Expected the endpoint %s to be %s, got %s.", c.endpoint, c.expected, actual)
+		}
+	}
+}
@@ -1,18 +1,10 @@
 package connectivity
 
 import (
-	"fmt"
 	"log"
 
-	"encoding/json"
-	"net/http"
-	"strings"
-
 	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/auth"
 	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/auth/credentials"
-	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
-	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/responses"
-	"github.com/jmespath/go-jmespath"
 )
 
 var securityCredURL = "http://100.100.100.200/latest/meta-data/ram/security-credentials/"
@@ -27,12 +19,24 @@ type Config struct {
 	SecurityToken   string
 	OtsInstanceName string
 	AccountId       string
+	DefaultTags     map[string]string
+
+	// The credential chain falls back to CredentialsURI and then CredentialProcess when AccessKey and SecretKey are not set.
+	CredentialsURI    string
+	CredentialProcess string
+	sessionCredential *refreshingCredential
+	// The STS credentials of the EcsRoleName or RamRoleArn for the clients which are not built on alibaba-cloud-sdk-go
+	roleCredential *refreshingCredential
 
 	RamRoleArn               string
 	RamRoleSessionName       string
 	RamRolePolicy            string
 	RamRoleSessionExpiration int
 
+	// The endpoints files, separated like the PATH environment variable, and the endpoints loaded from them and the endpoints.xml
+	EndpointsFile   string
+	endpointCatalog *endpointCatalog
+
 	EcsEndpoint           string
 	RdsEndpoint           string
 	SlbEndpoint           string
@@ -67,27 +71,34 @@ type Config struct {
 	BssOpenApiEndpoint    string
 	DdoscooEndpoint       string
 
-	SkipRegionValidation bool
+	SkipRegionValidation  bool
+	MaxConcurrentRequests int
+	RetryPolicy           *RetryPolicy
+	TraceFile             string
 }
 
-func (c *Config) loadAndValidate() error {
-	err := c.validateRegion()
-	if err != nil {
-		return err
+// initCredentialChain fetches the first credentials of the credentials_uri or the credential_process if one of them
+// is the source of the credentials.
+func (c *Config) initCredentialChain() error {
+	if c.AccessKey != "" && c.SecretKey != "" {
+		return nil
 	}
-
-	return nil
-}
-
-func (c *Config) validateRegion() error {
-
-	for _, valid := range ValidRegions {
-		if c.Region == valid {
-			return nil
-		}
+	var provider credentialProvider
+	if c.CredentialsURI != "" {
+		provider = &uriCredentialProvider{uri: c.CredentialsURI, source: "credentials_uri"}
+	} else if c.CredentialProcess != "" {
+		provider = &processCredentialProvider{command: c.CredentialProcess}
+	} else {
+		return nil
 	}
 
-	return fmt.Errorf("Invalid Alibaba Cloud region: %s", c.RegionId)
+	// The credentials are fetched at once, so that a broken source fails the provider configuration
+	credential := newRefreshingCredential(provider)
+	if err := credential.refresh(); err != nil {
+		return err
+	}
+	c.sessionCredential = credential
+	return nil
 }
 
 func (c *Config) getAuthCredential(stsSupported bool) auth.Credential {
@@ -103,6 +114,11 @@ func (c *Config) getAuthCredential(stsSupported bool) auth.Credential {
 		}
 		return credentials.NewAccessKeyCredential(c.AccessKey, c.SecretKey)
 	}
+	if c.sessionCredential != nil {
+		// A copy of the credentials of the credential chain, or of the role assumed by them
+		session, _ := c.getRefreshingCredential().current()
+		return credentials.NewStsTokenCredential(session.AccessKeyId, session.AccessKeySecret, session.SecurityToken)
+	}
 	if c.EcsRoleName != "" {
 		return credentials.NewEcsRamRoleCredential(c.EcsRoleName)
 	}
@@ -110,77 +126,22 @@ func (c *Config) getAuthCredential(stsSupported bool) auth.Credential {
 	return credentials.NewAccessKeyCredential(c.AccessKey, c.SecretKey)
 }
 
-// getAuthCredentialByEcsRoleName aims to access meta to get sts credential
-// Actually, the job should be done by sdk, but currently not all resources and products support alibaba-cloud-sdk-go,
-// and their go sdk does support ecs role name.
-// This method is a temporary solution and it should be removed after all go sdk support ecs role name
+// getAuthCredentialByEcsRoleName returns the credentials of the clients whose SDKs do not support the ECS role and
+// assume role credentials of alibaba-cloud-sdk-go, like OSS and Log. They are the static access key, or the STS
+// credentials of the credential chain, ECS role or assume role, which are fetched when they are used at first.
+// The clients should keep their credentials up to date by AliyunClient.onCredentialRefresh.
 // The related PR: https://github.com/terraform-providers/terraform-provider-alicloud/pull/731
 func (c *Config) getAuthCredentialByEcsRoleName() (accessKey, secretKey, token string, err error) {
-	if c.AccessKey != "" {
-		return c.AccessKey, c.SecretKey, c.SecurityToken, nil
-	}
-	if c.EcsRoleName == "" {
-		return
-	}
-	requestUrl := securityCredURL + c.EcsRoleName
-	httpRequest, err := http.NewRequest(requests.GET, requestUrl, strings.NewReader(""))
-	if err != nil {
-		err = fmt.Errorf("build sts requests err: %s", err.Error())
-		return
-	}
-	httpClient := &http.Client{}
-	httpResponse, err := httpClient.Do(httpRequest)
-	if err != nil {
-		err = fmt.Errorf("get Ecs sts token err : %s", err.Error())
-		return
-	}
-
-	response := responses.NewCommonResponse()
-	err = responses.Unmarshal(response, httpResponse, "")
-	if err != nil {
-		err = fmt.Errorf("Unmarshal Ecs sts token response err : %s", err.Error())
-		return
-	}
-
-	if response.GetHttpStatus() != http.StatusOK {
-		err = fmt.Errorf("get Ecs sts token err, httpStatus: %d, message = %s", response.GetHttpStatus(), response.GetHttpContentString())
-		return
-	}
-	var data interface{}
-	err = json.Unmarshal(response.GetHttpContentBytes(), &data)
-	if err != nil {
-		err = fmt.Errorf("refresh Ecs sts token err, json.Unmarshal fail: %s", err.Error())
-		return
-	}
-	code, err := jmespath.Search("Code", data)
-	if err != nil {
-		err = fmt.Errorf("refresh Ecs sts token err, fail to get Code: %s", err.Error())
-		return
-	}
-	if code.(string) != "Success" {
-		err = fmt.Errorf("refresh Ecs sts token err, Code is not Success")
-		return
-	}
-	accessKeyId, err := jmespath.Search("AccessKeyId", data)
-	if err != nil {
-		err = fmt.Errorf("refresh Ecs sts token err, fail to get AccessKeyId: %s", err.Error())
-		return
-	}
-	accessKeySecret, err := jmespath.Search("AccessKeySecret", data)
-	if err != nil {
-		err = fmt.Errorf("refresh Ecs sts token err, fail to get AccessKeySecret: %s", err.Error())
-		return
-	}
-	securityToken, err := jmespath.Search("SecurityToken", data)
-	if err != nil {
-		err = fmt.Errorf("refresh Ecs sts token err, fail to get SecurityToken: %s", err.Error())
-		return
+	if credential := c.getRefreshingCredential(); credential != nil {
+		return credential.get()
 	}
+	return c.AccessKey, c.SecretKey, c.SecurityToken, nil
+}
 
-	if accessKeyId == nil || accessKeySecret == nil || securityToken == nil {
-		err = fmt.Errorf("there is no any available accesskey, secret and security token for Ecs role %s", c.EcsRoleName)
-		return
+// getRefreshingCredential returns the credentials which are refreshed before they expire, if there are any.
+func (c *Config) getRefreshingCredential() *refreshingCredential {
+	if c.roleCredential != nil {
+		return c.roleCredential
 	}
-
-	return accessKeyId.(string), accessKeySecret.(string), securityToken.(string), nil
+	return c.sessionCredential
 }
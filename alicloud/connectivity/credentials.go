@@ -0,0 +1,318 @@
+package connectivity
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/auth/credentials"
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/sts"
+	"github.com/dxh031/ali_mns"
+	"github.com/valyala/fasthttp"
+)
+
+// The credentials are refreshed when they expire in less than the window, so that no request is signed by expired ones.
+const credentialRefreshWindow = 5 * time.Minute
+
+// credentialProvider fetches credentials from a source outside of the provider configuration.
+type credentialProvider interface {
+	// retrieve returns the credentials and when they expire. The expiration is zero if they never expire.
+	retrieve() (*sessionCredentials, error)
+	String() string
+}
+
+type sessionCredentials struct {
+	AccessKeyId     string
+	AccessKeySecret string
+	SecurityToken   string
+	Expiration      time.Time
+}
+
+// uriCredentialProvider fetches a credential document by an HTTP GET, like the CredentialsURI mode of the aliyun CLI:
+// {"Code": "Success", "AccessKeyId": "...", "AccessKeySecret": "...", "SecurityToken": "...", "Expiration": "2019-07-30T08:00:00Z"}
+// The ECS metadata service returns the STS credentials of the instance RAM role in the same format.
+type uriCredentialProvider struct {
+	uri string
+	// The name of the source in logs and errors, like credentials_uri or ecs_role_name
+	source string
+}
+
+func (p *uriCredentialProvider) String() string {
+	return fmt.Sprintf("%s %s", p.source, p.uri)
+}
+
+func (p *uriCredentialProvider) retrieve() (*sessionCredentials, error) {
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	response, err := httpClient.Get(p.uri)
+	if err != nil {
+		return nil, fmt.Errorf("get credentials from %s err: %s", p.uri, err)
+	}
+	defer response.Body.Close()
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read credentials from %s err: %s", p.uri, err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("get credentials from %s err, httpStatus: %d, message = %s", p.uri, response.StatusCode, body)
+	}
+
+	var document struct {
+		Code            string
+		AccessKeyId     string
+		AccessKeySecret string
+		SecurityToken   string
+		Expiration      string
+	}
+	if err := json.Unmarshal(body, &document); err != nil {
+		return nil, fmt.Errorf("get credentials from %s err, json.Unmarshal fail: %s", p.uri, err)
+	}
+	if document.Code != "" && document.Code != "Success" {
+		return nil, fmt.Errorf("get credentials from %s err, Code is %s", p.uri, document.Code)
+	}
+	return newSessionCredentials(p, document.AccessKeyId, document.AccessKeySecret, document.SecurityToken, document.Expiration)
+}
+
+// processCredentialProvider runs a command and parses its output, like the External mode of the aliyun CLI:
+// {"mode": "StsToken", "access_key_id": "...", "access_key_secret": "...", "sts_token": "...", "expiration": "2019-07-30T08:00:00Z"}
+// The mode AK does not have the sts_token, and the expiration is optional.
+type processCredentialProvider struct {
+	command string
+}
+
+func (p *processCredentialProvider) String() string {
+	return fmt.Sprintf("credential_process %s", p.command)
+}
+
+// retrieve runs the command by the shell, like sh -c, so that its arguments can be quoted as in a terminal.
+func (p *processCredentialProvider) retrieve() (*sessionCredentials, error) {
+	if strings.TrimSpace(p.command) == "" {
+		return nil, fmt.Errorf("the credential_process is empty")
+	}
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+	output, err := exec.Command(shell, flag, p.command).Output()
+	if err != nil {
+		if exitError, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("run %s err: %s, stderr: %s", p.command, err, exitError.Stderr)
+		}
+		return nil, fmt.Errorf("run %s err: %s", p.command, err)
+	}
+
+	var document struct {
+		Mode            string `json:"mode"`
+		AccessKeyId     string `json:"access_key_id"`
+		AccessKeySecret string `json:"access_key_secret"`
+		StsToken        string `json:"sts_token"`
+		Expiration      string `json:"expiration"`
+	}
+	if err := json.Unmarshal(output, &document); err != nil {
+		return nil, fmt.Errorf("the output of %s is not a JSON credential document: %s", p.command, err)
+	}
+	if document.Mode != "" && document.Mode != "AK" && document.Mode != "StsToken" {
+		return nil, fmt.Errorf("the mode %s output by %s is not supported, it must be AK or StsToken", document.Mode, p.command)
+	}
+	return newSessionCredentials(p, document.AccessKeyId, document.AccessKeySecret, document.StsToken, document.Expiration)
+}
+
+// assumeRoleCredentialProvider gets the STS credentials of the assume_role by calling AssumeRole with the static access
+// key, or with the credentials of the credential chain.
+type assumeRoleCredentialProvider struct {
+	stsClient *sts.Client
+	// The credentials of the credential chain which the STS client is signed by, if there are any. They are copied into
+	// the credential of the STS client before each call.
+	source     *refreshingCredential
+	credential *credentials.StsTokenCredential
+	// The endpoint of STS, like sts.aliyuncs.com or http://sts.example.com, which is the default one if it is empty
+	domain          string
+	roleArn         string
+	sessionName     string
+	policy          string
+	durationSeconds int
+}
+
+func (p *assumeRoleCredentialProvider) String() string {
+	return fmt.Sprintf("assume_role %s", p.roleArn)
+}
+
+func (p *assumeRoleCredentialProvider) retrieve() (*sessionCredentials, error) {
+	request := sts.CreateAssumeRoleRequest()
+	request.RoleArn = p.roleArn
+	request.RoleSessionName = p.sessionName
+	request.Policy = p.policy
+	if p.durationSeconds > 0 {
+		request.DurationSeconds = requests.NewInteger(p.durationSeconds)
+	}
+	if p.domain != "" {
+		scheme, domain := splitEndpointScheme(p.domain)
+		request.Domain = domain
+		if scheme != "" {
+			request.Scheme = scheme
+		}
+	}
+	if p.source != nil {
+		// The calls of retrieve are serialized by the refresh of the role, so the STS client is not in use
+		accessKey, secretKey, token, err := p.source.get()
+		if err != nil {
+			return nil, err
+		}
+		p.credential.AccessKeyId, p.credential.AccessKeySecret, p.credential.AccessKeyStsToken = accessKey, secretKey, token
+	}
+	response, err := p.stsClient.AssumeRole(request)
+	if err != nil {
+		return nil, fmt.Errorf("assume the role %s err: %s", p.roleArn, err)
+	}
+	return newSessionCredentials(p, response.Credentials.AccessKeyId, response.Credentials.AccessKeySecret,
+		response.Credentials.SecurityToken, response.Credentials.Expiration)
+}
+
+// expiring reports whether the credentials expire within the refresh window. The ones without an expiration never do.
+func (s *sessionCredentials) expiring() bool {
+	return !s.Expiration.IsZero() && time.Now().Add(credentialRefreshWindow).After(s.Expiration)
+}
+
+func newSessionCredentials(provider credentialProvider, accessKeyId, accessKeySecret, securityToken, expiration string) (*sessionCredentials, error) {
+	if accessKeyId == "" || accessKeySecret == "" {
+		return nil, fmt.Errorf("there is no any available accesskey and secret from the %s", provider)
+	}
+	session := &sessionCredentials{
+		AccessKeyId:     accessKeyId,
+		AccessKeySecret: accessKeySecret,
+		SecurityToken:   securityToken,
+	}
+	if expiration != "" {
+		t, err := time.Parse(time.RFC3339, expiration)
+		if err != nil {
+			return nil, fmt.Errorf("the expiration %s from the %s is not in RFC 3339 format", expiration, provider)
+		}
+		session.Expiration = t
+	}
+	return session, nil
+}
+
+// refreshingCredential holds the credentials of a provider. They are replaced as a whole when they are refreshed, and
+// the lock is never held during an API call, so a refresh does not wait for the calls in flight. The SDK clients keep
+// copies of the credentials, which are brought up to date by their next calls, see AliyunClient.syncCredentials.
+type refreshingCredential struct {
+	provider credentialProvider
+	// refreshMutex makes the concurrent refreshes fetch the credentials only once
+	refreshMutex sync.Mutex
+	mutex        sync.RWMutex
+	session      *sessionCredentials
+	// generation counts the refreshes, so that the clients can tell whether their copies are up to date
+	generation int
+}
+
+// newRefreshingCredential returns the credential of the provider, which is not fetched until it is used.
+func newRefreshingCredential(provider credentialProvider) *refreshingCredential {
+	return &refreshingCredential{provider: provider}
+}
+
+// current returns the current credentials and their generation. The credentials are nil if they have not been fetched.
+func (r *refreshingCredential) current() (*sessionCredentials, int) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.session, r.generation
+}
+
+// stale reports whether the credentials have not been fetched or are about to expire.
+func (r *refreshingCredential) stale() bool {
+	session, _ := r.current()
+	return session == nil || session.expiring()
+}
+
+// refreshIfExpiring refreshes the credentials when they have been fetched and are about to expire.
+func (r *refreshingCredential) refreshIfExpiring() error {
+	if session, _ := r.current(); session == nil || !session.expiring() {
+		return nil
+	}
+	return r.refresh()
+}
+
+func (r *refreshingCredential) refresh() error {
+	r.refreshMutex.Lock()
+	defer r.refreshMutex.Unlock()
+	// Another call may have refreshed them while waiting for the lock
+	if session, _ := r.current(); session != nil && !session.expiring() {
+		return nil
+	}
+	session, err := r.provider.retrieve()
+	if err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] Refreshed the credentials from the %s, which expire at %s", r.provider, session.Expiration)
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.session = session
+	r.generation++
+	return nil
+}
+
+// get returns the current credentials, and fetches them if they have not been fetched or are about to expire.
+func (r *refreshingCredential) get() (accessKey, secretKey, token string, err error) {
+	if r.stale() {
+		if err = r.refresh(); err != nil {
+			return
+		}
+	}
+	session, _ := r.current()
+	return session.AccessKeyId, session.AccessKeySecret, session.SecurityToken, nil
+}
+
+// stsMnsClient sends the security token with the requests of the MNS SDK, which only supports access keys. The SDK
+// client cannot change its access key, so it is replaced by a new one when the credentials are refreshed.
+type stsMnsClient struct {
+	ali_mns.MNSClient
+	url           string
+	securityToken string
+	// tracer traces the requests of the client if the trace_file is set
+	tracer *apiTracer
+	region string
+}
+
+func newStsMnsClient(url, accessKey, secretKey, securityToken string) *stsMnsClient {
+	client := &stsMnsClient{url: url}
+	client.reset(accessKey, secretKey, securityToken)
+	return client
+}
+
+func (c *stsMnsClient) reset(accessKey, secretKey, securityToken string) {
+	c.MNSClient = ali_mns.NewAliMNSClient(c.url, accessKey, secretKey)
+	c.securityToken = securityToken
+}
+
+func (c *stsMnsClient) Send(method ali_mns.Method, headers map[string]string, message interface{}, resource string) (*fasthttp.Response, error) {
+	if c.securityToken != "" {
+		if headers == nil {
+			headers = make(map[string]string)
+		}
+		headers["security-token"] = c.securityToken
+	}
+	start := time.Now()
+	response, err := c.MNSClient.Send(method, headers, message, resource)
+	if c.tracer != nil {
+		status, header := 0, http.Header{}
+		if response != nil {
+			status = response.StatusCode()
+			response.Header.VisitAll(func(key, value []byte) {
+				header.Add(string(key), string(value))
+			})
+		}
+		path, query := "/"+resource, url.Values(nil)
+		if u, e := url.Parse(path); e == nil {
+			path, query = u.Path, u.Query()
+		}
+		c.tracer.traceHttp("mns", c.region, time.Since(start), string(method), path, query, status, header, err)
+	}
+	return response, err
+}
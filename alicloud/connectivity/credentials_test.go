@@ -0,0 +1,265 @@
+package connectivity
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/auth/credentials"
+	"github.com/aliyun/aliyun-tablestore-go-sdk/tablestore"
+)
+
+// fakeCredentialProvider returns new credentials, like key-1 and token-1, every time they are retrieved.
+type fakeCredentialProvider struct {
+	retrieved int
+	// The lifetime of the credentials, which never expire if it is zero
+	lifetime time.Duration
+}
+
+func (p *fakeCredentialProvider) String() string {
+	return "fake credential provider"
+}
+
+func (p *fakeCredentialProvider) retrieve() (*sessionCredentials, error) {
+	p.retrieved++
+	session := &sessionCredentials{
+		AccessKeyId:     fmt.Sprintf("key-%d", p.retrieved),
+		AccessKeySecret: fmt.Sprintf("secret-%d", p.retrieved),
+		SecurityToken:   fmt.Sprintf("token-%d", p.retrieved),
+	}
+	if p.lifetime > 0 {
+		session.Expiration = time.Now().Add(p.lifetime)
+	}
+	return session, nil
+}
+
+// expireSoon makes the credentials expire within the refresh window, like they do after running for a while.
+func expireSoon(r *refreshingCredential) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	session := *r.session
+	session.Expiration = time.Now().Add(credentialRefreshWindow / 2)
+	r.session = &session
+}
+
+// newCredentialTestClient returns a client of the config which can make the calls of invoke without any SDK client.
+func newCredentialTestClient(config *Config) *AliyunClient {
+	return &AliyunClient{
+		config:                        config,
+		RegionId:                      config.RegionId,
+		RetryPolicy:                   NewRetryPolicy(),
+		tablestoreconnByInstanceName:  make(map[string]*tablestore.TableStoreClient),
+		tablestoreTokenByInstanceName: make(map[string]string),
+		clientMutexes:                 make(map[string]*sync.Mutex),
+		callMutexes:                   make(map[string]*sync.Mutex),
+		productCredentials:            make(map[string]*productCredential),
+	}
+}
+
+func TestUnitRefreshingCredentialGet(t *testing.T) {
+	provider := &fakeCredentialProvider{lifetime: time.Hour}
+	credential := newRefreshingCredential(provider)
+	if provider.retrieved != 0 {
+		t.Fatalf("Expected the credentials not to be fetched until they are used, got %d fetches.", provider.retrieved)
+	}
+
+	accessKey, secretKey, token, err := credential.get()
+	if err != nil {
+		t.Fatalf("Getting the credentials got an error: %#v.", err)
+	}
+	if accessKey != "key-1" || secretKey != "secret-1" || token != "token-1" {
+		t.Fatalf("Expected the first credentials, got %s, %s and %s.", accessKey, secretKey, token)
+	}
+	if accessKey, _, _, _ = credential.get(); accessKey != "key-1" || provider.retrieved != 1 {
+		t.Fatalf("Expected the credentials which do not expire soon to be reused, got %s after %d fetches.", accessKey, provider.retrieved)
+	}
+
+	expireSoon(credential)
+	if accessKey, _, token, _ = credential.get(); accessKey != "key-2" || token != "token-2" {
+		t.Fatalf("Expected the credentials to be refreshed before they expire, got %s and %s.", accessKey, token)
+	}
+}
+
+func TestUnitRefreshingCredentialRefreshIfExpiring(t *testing.T) {
+	provider := &fakeCredentialProvider{lifetime: time.Hour}
+	credential := newRefreshingCredential(provider)
+
+	if err := credential.refreshIfExpiring(); err != nil || provider.retrieved != 0 {
+		t.Fatalf("Expected the credentials which have not been fetched not to be refreshed, got %d fetches and the error %v.", provider.retrieved, err)
+	}
+	if err := credential.refresh(); err != nil {
+		t.Fatalf("Refreshing the credentials got an error: %#v.", err)
+	}
+	if err := credential.refreshIfExpiring(); err != nil || provider.retrieved != 1 {
+		t.Fatalf("Expected the credentials which expire in an hour not to be refreshed, got %d fetches and the error %v.", provider.retrieved, err)
+	}
+	if err := credential.refresh(); err != nil || provider.retrieved != 1 {
+		t.Fatalf("Expected a refresh of the credentials which were just refreshed to be skipped, got %d fetches and the error %v.", provider.retrieved, err)
+	}
+
+	expireSoon(credential)
+	if err := credential.refreshIfExpiring(); err != nil || provider.retrieved != 2 {
+		t.Fatalf("Expected the credentials which are about to expire to be refreshed, got %d fetches and the error %v.", provider.retrieved, err)
+	}
+	if session, generation := credential.current(); session.AccessKeyId != "key-2" || session.SecurityToken != "token-2" || generation != 2 {
+		t.Fatalf("Expected the credentials to be replaced by the refreshed ones, got %s and %s of the generation %d.",
+			session.AccessKeyId, session.SecurityToken, generation)
+	}
+
+	never := newRefreshingCredential(&fakeCredentialProvider{})
+	never.get()
+	if session, _ := never.current(); session.expiring() {
+		t.Fatalf("Expected the credentials without an expiration never to expire.")
+	}
+}
+
+func TestUnitClientSyncCredentials(t *testing.T) {
+	credential := newRefreshingCredential(&fakeCredentialProvider{lifetime: time.Hour})
+	client := newCredentialTestClient(&Config{RegionId: "cn-hangzhou", sessionCredential: credential})
+
+	var applied []string
+	client.onCredentialRefresh("oss", func(accessKey, secretKey, token string) {
+		applied = append(applied, accessKey+"/"+secretKey+"/"+token)
+	})
+	call := func() {
+		if _, err := client.invoke("oss", func() (interface{}, error) { return nil, nil }); err != nil {
+			t.Fatalf("Calling the API got an error: %#v.", err)
+		}
+	}
+	credential.get()
+	call()
+	call()
+	if !reflect.DeepEqual(applied, []string{"key-1/secret-1/token-1"}) {
+		t.Fatalf("Expected the credentials to be applied once by the first call, got %v.", applied)
+	}
+
+	// The refresh does not wait for the call in flight, which keeps the old credentials, and neither do the other calls
+	release, err := client.syncCredentials("oss")
+	if err != nil {
+		t.Fatalf("Starting a call got an error: %#v.", err)
+	}
+	expireSoon(credential)
+	if err := credential.refreshIfExpiring(); err != nil {
+		t.Fatalf("Refreshing the credentials got an error: %#v.", err)
+	}
+	call()
+	if !reflect.DeepEqual(applied, []string{"key-1/secret-1/token-1"}) {
+		t.Fatalf("Expected the credentials not to be changed while a call is in flight, got %v.", applied)
+	}
+	release()
+	call()
+	if !reflect.DeepEqual(applied, []string{"key-1/secret-1/token-1", "key-2/secret-2/token-2"}) {
+		t.Fatalf("Expected the refreshed credentials to be applied once no call is in flight, got %v.", applied)
+	}
+
+	// A call waits for the ones in flight only if the applied credentials have expired
+	release, _ = client.syncCredentials("oss")
+	expireSoon(credential)
+	credential.refreshIfExpiring()
+	product := client.productCredentialOf("oss")
+	product.mutex.Lock()
+	product.expiration = time.Now().Add(-time.Minute)
+	product.mutex.Unlock()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		call()
+	}()
+	select {
+	case <-done:
+		t.Fatalf("Expected the call to wait for the one in flight when the applied credentials have expired.")
+	case <-time.After(100 * time.Millisecond):
+	}
+	release()
+	<-done
+	if applied[len(applied)-1] != "key-3/secret-3/token-3" {
+		t.Fatalf("Expected the call to apply the refreshed credentials after waiting, got %v.", applied)
+	}
+}
+
+func TestUnitClientOnCredentialRefresh(t *testing.T) {
+	session := newRefreshingCredential(&fakeCredentialProvider{lifetime: time.Hour})
+	role := newRefreshingCredential(&fakeCredentialProvider{lifetime: time.Hour})
+	client := newCredentialTestClient(&Config{RegionId: "cn-hangzhou", sessionCredential: session})
+
+	if _, _, token, err := client.config.getAuthCredentialByEcsRoleName(); err != nil || token != "token-1" {
+		t.Fatalf("Expected the credentials of the credential chain, got %s and the error %v.", token, err)
+	}
+
+	// The clients follow the assumed role rather than the credentials which it is assumed by
+	client.config.roleCredential = role
+	role.get()
+	ecsCredential, ok := client.getAuthCredential("ecs", true).(*credentials.StsTokenCredential)
+	if !ok || ecsCredential.AccessKeyStsToken != "token-1" {
+		t.Fatalf("Expected the SDK clients to be signed by a copy of the credentials of the assumed role, got %#v.", ecsCredential)
+	}
+	if other := client.getAuthCredential("vpc", true); other == ecsCredential {
+		t.Fatalf("Expected each of the SDK clients to have its own copy of the credentials.")
+	}
+
+	expireSoon(role)
+	role.refreshIfExpiring()
+	if ecsCredential.AccessKeyStsToken != "token-1" {
+		t.Fatalf("Expected the copy not to be changed until its client makes a call, got %s.", ecsCredential.AccessKeyStsToken)
+	}
+	client.invoke("ecs", func() (interface{}, error) { return nil, nil })
+	if ecsCredential.AccessKeyId != "key-2" || ecsCredential.AccessKeyStsToken != "token-2" {
+		t.Fatalf("Expected the call to apply the refreshed credentials of the role, got %s and %s.",
+			ecsCredential.AccessKeyId, ecsCredential.AccessKeyStsToken)
+	}
+}
+
+func TestUnitProcessCredentialProviderQuotedArguments(t *testing.T) {
+	// The document is a single argument of printf, with the spaces kept by its quotes
+	provider := &processCredentialProvider{command: `printf '%s' '{"mode": "StsToken", "access_key_id": "process key", "access_key_secret": "secret", "sts_token": "token"}'`}
+	session, err := provider.retrieve()
+	if err != nil {
+		t.Fatalf("Running the credential process got an error: %#v.", err)
+	}
+	if session.AccessKeyId != "process key" || session.SecurityToken != "token" {
+		t.Fatalf("Expected the credentials printed by the process, got %s and %s.", session.AccessKeyId, session.SecurityToken)
+	}
+}
+
+func TestUnitClientTableStoreCredentialRefresh(t *testing.T) {
+	credential := newRefreshingCredential(&fakeCredentialProvider{lifetime: time.Hour})
+	client := newCredentialTestClient(&Config{
+		RegionId:          "cn-hangzhou",
+		OtsEndpoint:       "http://127.0.0.1:8080",
+		sessionCredential: credential,
+	})
+	tableStoreClient := func() *tablestore.TableStoreClient {
+		raw, err := client.WithTableStoreClient("tf-instance", func(tableStoreClient *tablestore.TableStoreClient) (interface{}, error) {
+			return tableStoreClient, nil
+		})
+		if err != nil {
+			t.Fatalf("Getting the Table Store client got an error: %#v.", err)
+		}
+		return raw.(*tablestore.TableStoreClient)
+	}
+	// The SDK client keeps its credentials in unexported fields
+	credentialsOf := func(tableStoreClient *tablestore.TableStoreClient) string {
+		value := reflect.ValueOf(tableStoreClient).Elem()
+		return value.FieldByName("accessKeyId").String() + "/" + value.FieldByName("securityToken").String()
+	}
+
+	first := tableStoreClient()
+	if actual := credentialsOf(first); actual != "key-1/token-1" {
+		t.Fatalf("Expected the Table Store client to be built with the fetched credentials, got %s.", actual)
+	}
+	if tableStoreClient() != first {
+		t.Fatalf("Expected the Table Store client to be reused while the credentials are not refreshed.")
+	}
+
+	expireSoon(credential)
+	credential.refreshIfExpiring()
+	second := tableStoreClient()
+	if second == first {
+		t.Fatalf("Expected the Table Store client to be built again after the credentials are refreshed.")
+	}
+	if actual := credentialsOf(second); actual != "key-2/token-2" {
+		t.Fatalf("Expected the Table Store client to be built with the refreshed credentials, got %s.", actual)
+	}
+}
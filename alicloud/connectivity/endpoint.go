@@ -1,9 +1,7 @@
 package connectivity
 
 import (
-	"encoding/xml"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"strings"
 )
@@ -60,7 +58,7 @@ type Endpoint struct {
 }
 
 type RegionIds struct {
-	RegionId string `xml:"RegionId"`
+	RegionId []string `xml:"RegionId"`
 }
 
 type Products struct {
@@ -72,35 +70,18 @@ type Product struct {
 	DomainName  string `xml:"DomainName"`
 }
 
-func loadEndpoint(region string, serviceCode ServiceCode) string {
+// loadEndpoint returns the endpoint of the service in the region of the provider. The endpoints are resolved in order:
+// the environment variable <SERVICE>_ENDPOINT, the endpoints_file, and the endpoints.xml in the current path or
+// TF_ENDPOINT_PATH. The endpoints block of the provider has precedence over all of them, and the default endpoint of
+// the service is used if none of them has one.
+func (c *Config) loadEndpoint(serviceCode ServiceCode) string {
 	endpoint := strings.TrimSpace(os.Getenv(fmt.Sprintf("%s_ENDPOINT", string(serviceCode))))
 	if endpoint != "" {
 		return endpoint
 	}
 
-	// Load current path endpoint file endpoints.xml, if failed, it will load from environment variables TF_ENDPOINT_PATH
-	data, err := ioutil.ReadFile("./endpoints.xml")
-	if err != nil || len(data) <= 0 {
-		d, e := ioutil.ReadFile(os.Getenv("TF_ENDPOINT_PATH"))
-		if e != nil {
-			return ""
-		}
-		data = d
+	if c.endpointCatalog != nil {
+		return c.endpointCatalog.lookup(c.RegionId, serviceCode)
 	}
-	var endpoints Endpoints
-	err = xml.Unmarshal(data, &endpoints)
-	if err != nil {
-		return ""
-	}
-	for _, endpoint := range endpoints.Endpoint {
-		if endpoint.RegionIds.RegionId == string(region) {
-			for _, product := range endpoint.Products.Product {
-				if strings.ToLower(product.ProductName) == strings.ToLower(string(serviceCode)) {
-					return strings.TrimSpace(product.DomainName)
-				}
-			}
-		}
-	}
-
 	return ""
 }
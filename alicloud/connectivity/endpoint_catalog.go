@@ -0,0 +1,201 @@
+package connectivity
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// The region of the endpoints which apply to every region without its own endpoint of the service.
+const anyRegion = "*"
+
+// endpointCatalog holds the endpoints of the endpoints_file and the endpoints.xml, which are loaded once when the
+// provider is configured. It is typically used by the private clouds, like Apsara Stack, whose regions have their own
+// endpoints.
+type endpointCatalog struct {
+	// The endpoints of each region, keyed by the service code, like ECS
+	regions map[string]map[ServiceCode]string
+	// The endpoints of the endpoints.xml in the current path or TF_ENDPOINT_PATH, which are used only if the
+	// endpoints_file has none
+	legacy map[string]map[ServiceCode]string
+}
+
+// endpointsFile is the JSON or YAML format of the endpoints_file:
+//
+//	endpoints:
+//	  - regions: ["cn-qingdao-env1", "cn-qingdao-env2"]
+//	    services:
+//	      ecs: {domain: "ecs.env1.example.com"}
+//	      oss: {domain: "oss.env1.example.com", scheme: "http", port: 8080}
+type endpointsFile struct {
+	Endpoints []endpointsEntry `json:"endpoints" yaml:"endpoints"`
+}
+
+// endpointsEntry maps the services to the endpoints in all of its regions. It applies to every region if it has none.
+type endpointsEntry struct {
+	Regions  []string                   `json:"regions" yaml:"regions"`
+	Services map[string]serviceEndpoint `json:"services" yaml:"services"`
+}
+
+type serviceEndpoint struct {
+	Domain string `json:"domain" yaml:"domain"`
+	Scheme string `json:"scheme" yaml:"scheme"`
+	Port   int    `json:"port" yaml:"port"`
+}
+
+// address returns the endpoint in the format of loadEndpoint, like oss.example.com:8080 or http://oss.example.com.
+func (e serviceEndpoint) address() (string, error) {
+	domain := strings.TrimSpace(e.Domain)
+	if domain == "" {
+		return "", fmt.Errorf("the domain is empty")
+	}
+	if strings.Contains(domain, "://") {
+		return "", fmt.Errorf("the domain %s must not contain a scheme, which is set by the scheme", domain)
+	}
+	if e.Port < 0 || e.Port > 65535 {
+		return "", fmt.Errorf("the port %d is not between 0 and 65535", e.Port)
+	}
+	if e.Port > 0 {
+		domain = fmt.Sprintf("%s:%d", domain, e.Port)
+	}
+	switch strings.ToLower(e.Scheme) {
+	case "":
+		return domain, nil
+	case "http", "https":
+		return fmt.Sprintf("%s://%s", strings.ToLower(e.Scheme), domain), nil
+	}
+	return "", fmt.Errorf("the scheme %s is not http or https", e.Scheme)
+}
+
+// loadEndpointCatalog reads the endpoints files, which are separated like the PATH environment variable, and the
+// endpoints.xml. The endpoints of a later file override the ones of the earlier files.
+func loadEndpointCatalog(paths string) (*endpointCatalog, error) {
+	catalog := &endpointCatalog{
+		regions: make(map[string]map[ServiceCode]string),
+		legacy:  loadLegacyEndpoints(),
+	}
+	for _, path := range filepath.SplitList(paths) {
+		if path = strings.TrimSpace(path); path == "" {
+			continue
+		}
+		if err := catalog.load(path); err != nil {
+			return nil, err
+		}
+		log.Printf("[DEBUG] Loaded the endpoints file %s", path)
+	}
+	return catalog, nil
+}
+
+func (catalog *endpointCatalog) load(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading the endpoints file %s got an error: %s", path, err)
+	}
+
+	var file endpointsFile
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, &file)
+	case ".yaml", ".yml":
+		err = yaml.UnmarshalStrict(data, &file)
+	case ".xml":
+		// The format of endpoints.xml, whose domains have neither a scheme nor a port
+		var endpoints Endpoints
+		err = xml.Unmarshal(data, &endpoints)
+		for _, endpoint := range endpoints.Endpoint {
+			services := make(map[string]serviceEndpoint)
+			for _, product := range endpoint.Products.Product {
+				services[product.ProductName] = serviceEndpoint{Domain: product.DomainName}
+			}
+			file.Endpoints = append(file.Endpoints, endpointsEntry{Regions: endpoint.RegionIds.RegionId, Services: services})
+		}
+	default:
+		return fmt.Errorf("the endpoints file %s must be a .json, .yaml, .yml or .xml file", path)
+	}
+	if err != nil {
+		return fmt.Errorf("parsing the endpoints file %s got an error: %s", path, err)
+	}
+
+	for _, entry := range file.Endpoints {
+		regions := entry.Regions
+		if len(regions) == 0 {
+			regions = []string{anyRegion}
+		}
+		for name, endpoint := range entry.Services {
+			address, err := endpoint.address()
+			if err != nil {
+				return fmt.Errorf("the endpoint of %s in the endpoints file %s is invalid: %s", name, path, err)
+			}
+			for _, region := range regions {
+				region = strings.TrimSpace(region)
+				if catalog.regions[region] == nil {
+					catalog.regions[region] = make(map[ServiceCode]string)
+				}
+				catalog.regions[region][ServiceCode(strings.ToUpper(strings.TrimSpace(name)))] = address
+			}
+		}
+	}
+	return nil
+}
+
+// loadLegacyEndpoints reads the endpoints.xml in the current path, or the file of TF_ENDPOINT_PATH if there is none.
+// Unlike the endpoints_file, it is optional, so a missing or invalid file has no endpoints.
+func loadLegacyEndpoints() map[string]map[ServiceCode]string {
+	data, err := ioutil.ReadFile("./endpoints.xml")
+	if err != nil || len(data) <= 0 {
+		if data, err = ioutil.ReadFile(os.Getenv("TF_ENDPOINT_PATH")); err != nil {
+			return nil
+		}
+	}
+	var endpoints Endpoints
+	if err := xml.Unmarshal(data, &endpoints); err != nil {
+		log.Printf("[WARN] Ignoring the endpoints.xml which cannot be parsed: %s", err)
+		return nil
+	}
+	legacy := make(map[string]map[ServiceCode]string)
+	for _, endpoint := range endpoints.Endpoint {
+		for _, regionId := range endpoint.RegionIds.RegionId {
+			if legacy[regionId] == nil {
+				legacy[regionId] = make(map[ServiceCode]string)
+			}
+			for _, product := range endpoint.Products.Product {
+				serviceCode := ServiceCode(strings.ToUpper(product.ProductName))
+				// The first endpoint of a product wins, like it did when the file was read by every lookup
+				if _, ok := legacy[regionId][serviceCode]; !ok {
+					legacy[regionId][serviceCode] = strings.TrimSpace(product.DomainName)
+				}
+			}
+		}
+	}
+	return legacy
+}
+
+// lookup returns the endpoint of the service in the region, or the one of every region if the region has none.
+// The endpoints.xml is only looked up if the endpoints_file has neither of them.
+func (catalog *endpointCatalog) lookup(region string, serviceCode ServiceCode) string {
+	if endpoint, ok := catalog.regions[region][serviceCode]; ok {
+		return endpoint
+	}
+	if endpoint, ok := catalog.regions[anyRegion][serviceCode]; ok {
+		return endpoint
+	}
+	return catalog.legacy[region][serviceCode]
+}
+
+// splitEndpointScheme splits an endpoint like http://ecs.example.com into the scheme of the SDK, like HTTP, and the
+// domain. The scheme is empty if the endpoint has none.
+func splitEndpointScheme(endpoint string) (scheme, domain string) {
+	for _, s := range []string{"http", "https"} {
+		if strings.HasPrefix(strings.ToLower(endpoint), s+"://") {
+			return strings.ToUpper(s), endpoint[len(s)+3:]
+		}
+	}
+	return "", endpoint
+}
@@ -0,0 +1,66 @@
+package connectivity
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testLegacyEndpoints = `<?xml version="1.0" encoding="UTF-8"?>
+<Endpoints>
+  <Endpoint name="cn-qingdao-env1">
+    <RegionIds><RegionId>cn-qingdao-env1</RegionId></RegionIds>
+    <Products>
+      <Product><ProductName>Ecs</ProductName><DomainName>ecs.legacy.example.com</DomainName></Product>
+      <Product><ProductName>Vpc</ProductName><DomainName>vpc.legacy.example.com</DomainName></Product>
+    </Products>
+  </Endpoint>
+</Endpoints>`
+
+const testEndpointsFile = `endpoints:
+  - services:
+      vpc: {domain: "vpc.file.example.com"}
+`
+
+func TestUnitEndpointCatalogLegacy(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tf-endpoints")
+	if err != nil {
+		t.Fatalf("Creating the temporary directory got an error: %#v.", err)
+	}
+	defer os.RemoveAll(dir)
+	legacyPath := filepath.Join(dir, "endpoints.xml")
+	filePath := filepath.Join(dir, "endpoints.yaml")
+	if err := ioutil.WriteFile(legacyPath, []byte(testLegacyEndpoints), 0600); err != nil {
+		t.Fatalf("Writing the endpoints.xml got an error: %#v.", err)
+	}
+	if err := ioutil.WriteFile(filePath, []byte(testEndpointsFile), 0600); err != nil {
+		t.Fatalf("Writing the endpoints file got an error: %#v.", err)
+	}
+	defer os.Setenv("TF_ENDPOINT_PATH", os.Getenv("TF_ENDPOINT_PATH"))
+	os.Setenv("TF_ENDPOINT_PATH", legacyPath)
+
+	catalog, err := loadEndpointCatalog(filePath)
+	if err != nil {
+		t.Fatalf("Loading the endpoints got an error: %#v.", err)
+	}
+	// The endpoints.xml is read once, so the endpoints do not change with the file
+	if err := os.Remove(legacyPath); err != nil {
+		t.Fatalf("Removing the endpoints.xml got an error: %#v.", err)
+	}
+	config := &Config{RegionId: "cn-qingdao-env1", endpointCatalog: catalog}
+
+	if endpoint := config.loadEndpoint(ECSCode); endpoint != "ecs.legacy.example.com" {
+		t.Errorf("Expected the endpoint of ECS from the endpoints.xml, got %q.", endpoint)
+	}
+	if endpoint := config.loadEndpoint(VPCCode); endpoint != "vpc.file.example.com" {
+		t.Errorf("Expected the endpoint of VPC from the endpoints_file to override the endpoints.xml, got %q.", endpoint)
+	}
+	if endpoint := config.loadEndpoint(SLBCode); endpoint != "" {
+		t.Errorf("Expected no endpoint of SLB, got %q.", endpoint)
+	}
+	config.RegionId = "cn-hangzhou"
+	if endpoint := config.loadEndpoint(ECSCode); endpoint != "" {
+		t.Errorf("Expected the endpoints.xml not to apply to the other regions, got %q.", endpoint)
+	}
+}
@@ -0,0 +1,204 @@
+package connectivity
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+)
+
+// The regions of a product are described again when they were cached longer ago than the TTL.
+const regionCacheTTL = 24 * time.Hour
+
+// The environment variable which overrides the location of the regions cache file.
+const regionCacheFileEnv = "ALICLOUD_REGIONS_CACHE_FILE"
+
+// regionCache keeps the regions of ECS in a file between the runs of the provider, so that they are not described by
+// every plan. A region which is missing from the cached regions is looked up again, since it may have been opened
+// after they were cached.
+type regionCache struct {
+	path    string
+	mutex   sync.Mutex
+	loaded  bool
+	entries map[string]*regionCacheEntry
+	// The cache keys whose regions have been described by this provider
+	described map[string]bool
+}
+
+type regionCacheEntry struct {
+	Regions     []string  `json:"regions"`
+	DescribedAt time.Time `json:"described_at"`
+}
+
+// newRegionCache returns the cache in ALICLOUD_REGIONS_CACHE_FILE, or terraform-provider-alicloud/regions.json in the
+// cache directory of the user. The regions are kept in memory only if there is no cache directory.
+func newRegionCache() *regionCache {
+	path := os.Getenv(regionCacheFileEnv)
+	if path == "" {
+		if dir, err := os.UserCacheDir(); err == nil {
+			path = filepath.Join(dir, "terraform-provider-alicloud", "regions.json")
+		}
+	}
+	return &regionCache{
+		path:      path,
+		entries:   make(map[string]*regionCacheEntry),
+		described: make(map[string]bool),
+	}
+}
+
+// load reads the cache file once. A missing or broken file is the same as an empty one.
+func (cache *regionCache) load() {
+	if cache.loaded || cache.path == "" {
+		return
+	}
+	cache.loaded = true
+	data, err := ioutil.ReadFile(cache.path)
+	if err != nil {
+		return
+	}
+	if err := json.Unmarshal(data, &cache.entries); err != nil {
+		log.Printf("[WARN] Ignoring the regions cache file %s: %s", cache.path, err)
+		cache.entries = make(map[string]*regionCacheEntry)
+	}
+}
+
+// save writes the cache file by renaming a temporary one, so that the providers running at the same time never read
+// a partial file. The cache is only an optimization, so it is not an error if it cannot be written.
+func (cache *regionCache) save() {
+	if cache.path == "" {
+		return
+	}
+	data, err := json.MarshalIndent(cache.entries, "", "  ")
+	if err != nil {
+		return
+	}
+	dir := filepath.Dir(cache.path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		log.Printf("[WARN] Unable to write the regions cache file %s: %s", cache.path, err)
+		return
+	}
+	file, err := ioutil.TempFile(dir, filepath.Base(cache.path))
+	if err != nil {
+		log.Printf("[WARN] Unable to write the regions cache file %s: %s", cache.path, err)
+		return
+	}
+	_, err = file.Write(data)
+	if e := file.Close(); err == nil {
+		err = e
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), cache.path)
+	}
+	if err != nil {
+		os.Remove(file.Name())
+		log.Printf("[WARN] Unable to write the regions cache file %s: %s", cache.path, err)
+	}
+}
+
+// regionCacheKey returns the key of the regions of ECS in the cache. The private clouds, like Apsara Stack, have other
+// regions than the public one, so the regions described by a custom endpoint are keyed by the endpoint as well, like
+// ECS@ecs.env1.example.com.
+func (client *AliyunClient) regionCacheKey() string {
+	endpoint := client.config.EcsEndpoint
+	if endpoint == "" {
+		endpoint = client.config.loadEndpoint(ECSCode)
+	}
+	if endpoint == "" {
+		return string(ECSCode)
+	}
+	return fmt.Sprintf("%s@%s", ECSCode, endpoint)
+}
+
+// ecsRegionsCached reports whether the regions of ECS were cached less than a day ago.
+func (client *AliyunClient) ecsRegionsCached() bool {
+	cache := client.regionCache
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.load()
+	entry, ok := cache.entries[client.regionCacheKey()]
+	return ok && time.Since(entry.DescribedAt) < regionCacheTTL
+}
+
+// cachedEcsRegion reports whether ECS is available in the region of the provider by the cached regions, and whether
+// they tell it. They do not tell a missing region unless they have been described by this provider.
+func (client *AliyunClient) cachedEcsRegion() (supported, known bool) {
+	cache := client.regionCache
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.load()
+	key := client.regionCacheKey()
+	entry, ok := cache.entries[key]
+	if !ok {
+		return false, false
+	}
+	if containsRegion(entry.Regions, client.RegionId) {
+		return true, true
+	}
+	return false, cache.described[key] && time.Since(entry.DescribedAt) < regionCacheTTL
+}
+
+// describeEcsRegions calls the DescribeRegions API of ECS by the client and caches its regions. It is called in an API
+// call of ECS.
+func (client *AliyunClient) describeEcsRegions(ecsClient *ecs.Client) ([]string, error) {
+	response, err := ecsClient.DescribeRegions(ecs.CreateDescribeRegionsRequest())
+	if err != nil {
+		return nil, err
+	}
+	var regions []string
+	for _, region := range response.Regions.Region {
+		regions = append(regions, region.RegionId)
+	}
+	cache := client.regionCache
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.load()
+	key := client.regionCacheKey()
+	cache.entries[key] = &regionCacheEntry{Regions: regions, DescribedAt: time.Now()}
+	cache.described[key] = true
+	cache.save()
+	return regions, nil
+}
+
+// validateRegion checks the region of the provider against the ValidRegions, and then against the regions of ECS,
+// which is available in every region of Alibaba Cloud. It lets the provider work in a new region before the
+// ValidRegions of its version know about it.
+func (client *AliyunClient) validateRegion() error {
+	for _, valid := range ValidRegions {
+		if client.Region == valid {
+			return nil
+		}
+	}
+	// The regions may have been described by the initialization of the ECS client
+	raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		if supported, known := client.cachedEcsRegion(); known {
+			return supported, nil
+		}
+		regions, err := client.describeEcsRegions(ecsClient)
+		if err != nil {
+			return nil, err
+		}
+		return containsRegion(regions, client.RegionId), nil
+	})
+	if err != nil {
+		return fmt.Errorf("Unable to validate the region %s by the regions of ECS: %s. Set skip_region_validation to true to skip the validation.", client.RegionId, err)
+	}
+	if !raw.(bool) {
+		return fmt.Errorf("Invalid Alibaba Cloud region: %s", client.RegionId)
+	}
+	return nil
+}
+
+func containsRegion(regions []string, region string) bool {
+	for _, r := range regions {
+		if r == region {
+			return true
+		}
+	}
+	return false
+}
@@ -35,6 +35,7 @@ const (
 	ShanghaiFinance = Region("cn-shanghai-finance-1")
 )
 
+// ValidRegions are accepted without describing the regions of ECS. The other regions are validated by the API.
 var ValidRegions = []Region{
 	Hangzhou, Qingdao, Beijing, Shenzhen, Hongkong, Shanghai, Zhangjiakou, Huhehaote, ChengDu,
 	USWest1, USEast1,
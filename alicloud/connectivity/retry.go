@@ -0,0 +1,185 @@
+package connectivity
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/errors"
+	sls "github.com/aliyun/aliyun-log-go-sdk"
+	"github.com/aliyun/aliyun-oss-go-sdk/oss"
+	"github.com/aliyun/aliyun-tablestore-go-sdk/tablestore"
+	"github.com/aliyun/fc-go-sdk"
+	"github.com/denverdino/aliyungo/common"
+	"github.com/dxh031/ali_mns"
+)
+
+const (
+	DefaultRetryMaxAttempts = 10
+	DefaultRetryBaseDelay   = 1 * time.Second
+	DefaultRetryMaxDelay    = 30 * time.Second
+	DefaultRetryJitter      = 0.5
+)
+
+// The throttling error codes, which are always retried, as the throttled requests are rejected before they take any
+// effect. A code also matches the ones prefixed by it, like Throttling.User.
+var throttlingErrors = []string{"Throttling"}
+
+// The busy error codes, which are only retried for the idempotent requests, as a busy service may have done the
+// request in part.
+var busyErrors = []string{"ServiceUnavailable"}
+
+// The throttling error codes of the products whose SDKs do not follow the Throttling convention.
+var productThrottlingErrors = []string{
+	// Function Compute
+	"ResourceThrottled",
+	// Table Store
+	tablestore.QUOTA_EXHAUSTED,
+	// Message Service
+	"QpsLimitExceeded",
+}
+
+// The busy error codes of the products whose SDKs do not follow the ServiceUnavailable convention.
+var productBusyErrors = []string{
+	// Table Store
+	tablestore.SERVER_BUSY, tablestore.STORAGE_SERVER_BUSY, tablestore.SERVER_UNAVAILABLE,
+}
+
+// The prefixes of the RPC actions which only read, so that they can be sent again whatever happened to the first request.
+var idempotentActionPrefixes = []string{"Describe", "List", "Get", "Query", "Check"}
+
+// The errors of ali_mns only carry the error response of MNS in their message.
+var mnsErrorRegexp = regexp.MustCompile(`code: ([^,]*), message: (.*), resource: .* request id: ([^,]*), host id:`)
+
+// The jitter source is seeded per process, so that the providers of the pipelines running at the same time do not
+// retry in lockstep.
+var jitterRand = rand.New(rand.NewSource(time.Now().UnixNano()))
+var jitterMutex = sync.Mutex{}
+
+// RetryPolicy describes how an API call which failed with a retryable error is retried.
+// The delay before the n-th retry is BaseDelay * 2^(n-1), no longer than MaxDelay,
+// and the Jitter fraction of it is randomized to spread the retries of concurrent calls.
+type RetryPolicy struct {
+	MaxAttempts     int
+	BaseDelay       time.Duration
+	MaxDelay        time.Duration
+	Jitter          float64
+	RetryableErrors []string
+}
+
+func NewRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: DefaultRetryMaxAttempts,
+		BaseDelay:   DefaultRetryBaseDelay,
+		MaxDelay:    DefaultRetryMaxDelay,
+		Jitter:      DefaultRetryJitter,
+	}
+}
+
+// Delay returns how long to wait before the given retry, which starts from 1.
+func (p *RetryPolicy) Delay(retry int) time.Duration {
+	delay := float64(p.BaseDelay) * math.Pow(2, float64(retry-1))
+	if p.MaxDelay > 0 && delay > float64(p.MaxDelay) {
+		delay = float64(p.MaxDelay)
+	}
+	if p.Jitter > 0 {
+		jitterMutex.Lock()
+		r := jitterRand.Float64()
+		jitterMutex.Unlock()
+		delay = delay*(1-p.Jitter) + delay*p.Jitter*r
+	}
+	return time.Duration(delay)
+}
+
+// IsRetryable reports whether a request which failed with the error can be sent again. Throttling errors of any product
+// and the RetryableErrors are always retried, and service unavailable or busy errors only when the request is idempotent.
+func (p *RetryPolicy) IsRetryable(err error, idempotent bool) bool {
+	code := errorCode(err)
+	if code == "" {
+		return false
+	}
+	if matchErrorCode(code, throttlingErrors, true) || matchErrorCode(code, productThrottlingErrors, false) ||
+		matchErrorCode(code, p.RetryableErrors, false) {
+		return true
+	}
+	return idempotent && (matchErrorCode(code, busyErrors, true) || matchErrorCode(code, productBusyErrors, false))
+}
+
+// matchErrorCode reports whether the code is one of the codes, or one prefixed by them, like Throttling.User, when
+// prefixed is set.
+func matchErrorCode(code string, codes []string, prefixed bool) bool {
+	for _, c := range codes {
+		if code == c || (prefixed && strings.HasPrefix(code, c+".")) {
+			return true
+		}
+	}
+	return false
+}
+
+// isIdempotentRequest reports whether the API call which returned raw is a single request which can be sent again, which
+// is a read action of RPC or a GET or HEAD of ROA. Only the responses of alibaba-cloud-sdk-go keep their request, and the
+// calls of the other SDKs are taken as not idempotent.
+func isIdempotentRequest(raw interface{}) bool {
+	response, ok := raw.(sdkResponse)
+	if !ok || isNilPointer(raw) || response.GetOriginHttpResponse() == nil {
+		return false
+	}
+	request := response.GetOriginHttpResponse().Request
+	if request == nil {
+		return false
+	}
+	if action := requestParams(request).Get("Action"); action != "" {
+		for _, prefix := range idempotentActionPrefixes {
+			if strings.HasPrefix(action, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+	return request.Method == http.MethodGet || request.Method == http.MethodHead
+}
+
+func errorCode(err error) string {
+	switch e := err.(type) {
+	case *errors.ServerError:
+		return e.ErrorCode()
+	case *common.Error:
+		return e.Code
+	case *sls.Error:
+		return e.Code
+	case oss.ServiceError:
+		return e.Code
+	case *fc.ServiceError:
+		return e.ErrorCode
+	case *tablestore.OtsError:
+		return e.Code
+	case mnsError:
+		if response, ok := parseMnsError(e); ok {
+			return response.Code
+		}
+	}
+	return ""
+}
+
+// mnsError is implemented by the ErrCode errors of ali_mns, which come from a package the provider does not
+// depend on directly.
+type mnsError interface {
+	Namespace() string
+	Error() string
+}
+
+// parseMnsError returns the error response of MNS which the error of ali_mns was made from.
+func parseMnsError(err mnsError) (ali_mns.ErrorResponse, bool) {
+	if err.Namespace() != ali_mns.ALI_MNS_ERR_NS {
+		return ali_mns.ErrorResponse{}, false
+	}
+	m := mnsErrorRegexp.FindStringSubmatch(err.Error())
+	if m == nil {
+		return ali_mns.ErrorResponse{}, false
+	}
+	return ali_mns.ErrorResponse{Code: m[1], Message: m[2], RequestId: m[3]}, true
+}
@@ -0,0 +1,129 @@
+package connectivity
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/errors"
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/responses"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	sls "github.com/aliyun/aliyun-log-go-sdk"
+	"github.com/aliyun/aliyun-oss-go-sdk/oss"
+	"github.com/aliyun/aliyun-tablestore-go-sdk/tablestore"
+	"github.com/aliyun/fc-go-sdk"
+	"github.com/denverdino/aliyungo/common"
+	"github.com/dxh031/ali_mns"
+)
+
+func TestUnitRetryPolicyDelay(t *testing.T) {
+	policy := &RetryPolicy{BaseDelay: time.Second, MaxDelay: 10 * time.Second}
+	cases := []struct {
+		retry    int
+		expected time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{4, 8 * time.Second},
+		{5, 10 * time.Second},
+		{20, 10 * time.Second},
+	}
+	for _, c := range cases {
+		if actual := policy.Delay(c.retry); actual != c.expected {
+			t.Errorf("Expected the delay before the retry %d to be %s, got %s.", c.retry, c.expected, actual)
+		}
+	}
+
+	unlimited := &RetryPolicy{BaseDelay: time.Second}
+	if actual := unlimited.Delay(7); actual != 64*time.Second {
+		t.Errorf("Expected the delay without max_delay to be 1m4s, got %s.", actual)
+	}
+}
+
+func TestUnitRetryPolicyDelayJitter(t *testing.T) {
+	policy := &RetryPolicy{BaseDelay: time.Second, MaxDelay: 30 * time.Second, Jitter: 0.5}
+	for retry := 1; retry <= 10; retry++ {
+		full := (&RetryPolicy{BaseDelay: time.Second, MaxDelay: 30 * time.Second}).Delay(retry)
+		for i := 0; i < 20; i++ {
+			if actual := policy.Delay(retry); actual < full/2 || actual > full {
+				t.Fatalf("Expected the delay before the retry %d to be between %s and %s, got %s.", retry, full/2, full, actual)
+			}
+		}
+	}
+}
+
+func TestUnitRetryPolicyIsRetryable(t *testing.T) {
+	policy := &RetryPolicy{RetryableErrors: []string{"IncorrectVpcStatus"}}
+	serverError := func(code string) error {
+		return errors.NewServerError(400, fmt.Sprintf(`{"Code": "%s", "Message": "fake"}`, code), "")
+	}
+	mnsError := func(code string) error {
+		return ali_mns.ParseError(ali_mns.ErrorResponse{Code: code, Message: "fake"}, "queue")
+	}
+	cases := []struct {
+		name       string
+		err        error
+		idempotent bool
+		expected   bool
+	}{
+		{"nil", nil, true, false},
+		{"plain", fmt.Errorf("Throttling"), true, false},
+		{"throttling", serverError("Throttling"), false, true},
+		{"throttling user", serverError("Throttling.User"), false, true},
+		{"service unavailable", serverError("ServiceUnavailable"), true, true},
+		{"service unavailable not idempotent", serverError("ServiceUnavailable"), false, false},
+		{"throttling like", serverError("ThrottlingFake"), true, false},
+		{"not found", serverError("InvalidVpcId.NotFound"), true, false},
+		{"retryable errors", serverError("IncorrectVpcStatus"), false, true},
+		{"retryable errors prefix", serverError("IncorrectVpcStatus.Fake"), true, false},
+		{"aliyungo", &common.Error{ErrorResponse: common.ErrorResponse{Code: "Throttling"}}, false, true},
+		{"sls", &sls.Error{Code: "Throttling"}, false, true},
+		{"oss", oss.ServiceError{Code: "ServiceUnavailable"}, true, true},
+		{"oss not idempotent", oss.ServiceError{Code: "ServiceUnavailable"}, false, false},
+		{"fc throttled", &fc.ServiceError{ErrorCode: "ResourceThrottled"}, false, true},
+		{"fc not found", &fc.ServiceError{ErrorCode: "ServiceNotFound"}, true, false},
+		{"ots busy", &tablestore.OtsError{Code: tablestore.SERVER_BUSY}, true, true},
+		{"ots busy not idempotent", &tablestore.OtsError{Code: tablestore.SERVER_BUSY}, false, false},
+		{"ots quota", &tablestore.OtsError{Code: tablestore.QUOTA_EXHAUSTED}, false, true},
+		{"ots not exist", &tablestore.OtsError{Code: "OTSObjectNotExist"}, true, false},
+		{"mns qps", mnsError("QpsLimitExceeded"), false, true},
+		{"mns not exist", mnsError("QueueNotExist"), true, false},
+	}
+	for _, c := range cases {
+		if actual := policy.IsRetryable(c.err, c.idempotent); actual != c.expected {
+			t.Errorf("Expected IsRetryable of the %s error to be %t, got %t.", c.name, c.expected, actual)
+		}
+	}
+}
+
+func TestUnitRetryIsIdempotentRequest(t *testing.T) {
+	response := func(method, rawurl string) interface{} {
+		request, _ := http.NewRequest(method, rawurl, nil)
+		raw := vpc.CreateDescribeVpcsResponse()
+		responses.Unmarshal(raw, &http.Response{StatusCode: 200, Body: ioutil.NopCloser(strings.NewReader("{}")), Request: request}, "JSON")
+		return raw
+	}
+	cases := []struct {
+		name     string
+		raw      interface{}
+		expected bool
+	}{
+		{"nil", nil, false},
+		{"nil response", (*vpc.DescribeVpcsResponse)(nil), false},
+		{"no http response", vpc.CreateDescribeVpcsResponse(), false},
+		{"rpc read", response("POST", "https://vpc.aliyuncs.com/?Action=DescribeVpcs"), true},
+		{"rpc write", response("POST", "https://vpc.aliyuncs.com/?Action=CreateVpc"), false},
+		{"roa get", response("GET", "https://cr.cn-hangzhou.aliyuncs.com/repos"), true},
+		{"roa put", response("PUT", "https://cr.cn-hangzhou.aliyuncs.com/repos"), false},
+		{"other sdks", &fc.GetFunctionOutput{}, false},
+	}
+	for _, c := range cases {
+		if actual := isIdempotentRequest(c.raw); actual != c.expected {
+			t.Errorf("Expected isIdempotentRequest of the %s call to be %t, got %t.", c.name, c.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,312 @@
+package connectivity
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/errors"
+	sls "github.com/aliyun/aliyun-log-go-sdk"
+	"github.com/aliyun/aliyun-oss-go-sdk/oss"
+	"github.com/aliyun/aliyun-tablestore-go-sdk/tablestore"
+	"github.com/aliyun/fc-go-sdk"
+	"github.com/denverdino/aliyungo/common"
+)
+
+const redacted = "REDACTED"
+
+// The parameters whose names contain one of the words are never written to the trace file.
+var sensitiveParams = []string{"accesskey", "secret", "password", "token", "signature", "authorization", "credential", "privatekey", "userdata"}
+
+// Some errors, like the ones of net/http, contain the whole URL of the request and its signed parameters.
+var sensitiveQuery = regexp.MustCompile(`(?i)((?:AccessKeyId|AccessKeySecret|SecurityToken|Signature|Password)=)[^&\s"]*`)
+
+const providerPackage = "github.com/terraform-providers/terraform-provider-alicloud/alicloud."
+
+// apiTracer writes one JSON line per API call to the trace_file, including its request ID, to help troubleshooting
+// with Alibaba Cloud. The lines of concurrent calls are written whole, one by one.
+type apiTracer struct {
+	mutex sync.Mutex
+	file  *os.File
+}
+
+type apiTrace struct {
+	Time       string            `json:"time"`
+	Product    string            `json:"product"`
+	Action     string            `json:"action,omitempty"`
+	Region     string            `json:"region,omitempty"`
+	RequestId  string            `json:"request_id,omitempty"`
+	LatencyMs  int64             `json:"latency_ms"`
+	Attempt    int               `json:"attempt,omitempty"`
+	HttpStatus int               `json:"http_status,omitempty"`
+	ErrorCode  string            `json:"error_code,omitempty"`
+	Error      string            `json:"error,omitempty"`
+	Caller     string            `json:"caller,omitempty"`
+	Params     map[string]string `json:"params,omitempty"`
+}
+
+func newApiTracer(path string) (*apiTracer, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &apiTracer{file: file}, nil
+}
+
+// httpTracedProducts are the products whose SDKs do not return their HTTP responses. Their HTTP requests are traced
+// one by one instead, by a tracingTransport or the MNS client, so their API calls are only traced when they fail.
+// The log SDK sends its requests by its own HTTP client, which cannot be given a transport, so its calls are traced here.
+var httpTracedProducts = map[string]bool{"oss": true, "tablestore": true, "mns": true}
+
+// trace describes an API call by its result. The SDK responses keep the HTTP request, which gives the action and
+// parameters, and the FC outputs tell their action by their type and keep the request ID in their headers. A call which
+// failed before it got a response is described by the request in its error.
+func (t *apiTracer) trace(product, region string, attempt int, latency time.Duration, raw interface{}, err error) {
+	if err == nil && httpTracedProducts[product] {
+		return
+	}
+	record := apiTrace{
+		Time:      time.Now().Format(time.RFC3339Nano),
+		Product:   product,
+		Region:    region,
+		LatencyMs: int64(latency / time.Millisecond),
+		Attempt:   attempt,
+		Caller:    apiCaller(),
+	}
+	if response, ok := raw.(sdkResponse); ok && !isNilPointer(raw) {
+		describeHttpResponse(&record, response)
+	} else if output, ok := raw.(fcOutput); ok && !isNilPointer(raw) {
+		record.Action = strings.TrimSuffix(reflect.Indirect(reflect.ValueOf(raw)).Type().Name(), "Output")
+		record.RequestId = output.GetRequestID()
+	}
+	if err != nil {
+		describeError(&record, err)
+		if record.Action == "" {
+			describeFailedRequest(&record, err)
+		}
+	}
+	t.write(record)
+}
+
+// traceHttp describes an HTTP request of the products in httpTracedProducts by its method, path and query, and the
+// request ID header of its response.
+func (t *apiTracer) traceHttp(product, region string, latency time.Duration, method, path string, query url.Values, status int, header http.Header, err error) {
+	record := apiTrace{
+		Time:       time.Now().Format(time.RFC3339Nano),
+		Product:    product,
+		Action:     method + " " + path,
+		Region:     region,
+		RequestId:  headerRequestId(header),
+		LatencyMs:  int64(latency / time.Millisecond),
+		HttpStatus: status,
+		Caller:     apiCaller(),
+		Params:     redactParams(query),
+	}
+	if err != nil {
+		record.Error = sensitiveQuery.ReplaceAllString(err.Error(), "${1}"+redacted)
+	}
+	t.write(record)
+}
+
+func (t *apiTracer) write(record apiTrace) {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.file != nil {
+		t.file.Write(append(line, '\n'))
+	}
+}
+
+// close closes the trace file. The calls made after it are not traced any more.
+func (t *apiTracer) close() error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.file == nil {
+		return nil
+	}
+	err := t.file.Close()
+	t.file = nil
+	return err
+}
+
+// tracingTransport traces the HTTP requests of the SDKs which take an HTTP client or transport.
+type tracingTransport struct {
+	tracer  *apiTracer
+	product string
+	region  string
+	base    http.RoundTripper
+}
+
+func (t *tracingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	start := time.Now()
+	response, err := t.base.RoundTrip(request)
+	status, header := 0, http.Header(nil)
+	if response != nil {
+		status, header = response.StatusCode, response.Header
+	}
+	t.tracer.traceHttp(t.product, t.region, time.Since(start), request.Method, request.URL.Path, request.URL.Query(), status, header, err)
+	return response, err
+}
+
+// fcOutput is implemented by the outputs of fc-go-sdk.
+type fcOutput interface {
+	GetRequestID() string
+}
+
+// headerRequestId returns the request ID header of the response, which every product names in its own way, like
+// X-Oss-Request-Id, X-Log-Requestid and X-Ots-Requestid.
+func headerRequestId(header http.Header) string {
+	for key, values := range header {
+		if strings.HasSuffix(strings.Replace(strings.ToLower(key), "-", "", -1), "requestid") && len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+// sdkResponse is implemented by the responses of alibaba-cloud-sdk-go.
+type sdkResponse interface {
+	GetOriginHttpResponse() *http.Response
+	GetHttpContentBytes() []byte
+}
+
+func describeHttpResponse(record *apiTrace, sdkResponse sdkResponse) {
+	response := sdkResponse.GetOriginHttpResponse()
+	if response == nil {
+		return
+	}
+	record.HttpStatus = response.StatusCode
+	record.RequestId = response.Header.Get("X-Acs-Request-Id")
+	if record.RequestId == "" {
+		var body struct{ RequestId string }
+		json.Unmarshal(sdkResponse.GetHttpContentBytes(), &body)
+		record.RequestId = body.RequestId
+	}
+
+	if request := response.Request; request != nil {
+		describeRequest(record, request.Method, request.URL.Path, requestParams(request))
+	}
+}
+
+// describeFailedRequest describes a call which failed without a response, like a timeout, by the request in its error.
+func describeFailedRequest(record *apiTrace, err error) {
+	if e, ok := err.(interface{ OriginError() error }); ok && e.OriginError() != nil {
+		err = e.OriginError()
+	}
+	e, ok := err.(*url.Error)
+	if !ok {
+		return
+	}
+	u, parseErr := url.Parse(e.URL)
+	if parseErr != nil {
+		return
+	}
+	describeRequest(record, strings.ToUpper(e.Op), u.Path, u.Query())
+}
+
+// describeRequest describes the action, region and parameters of a call by its request.
+func describeRequest(record *apiTrace, method, path string, params url.Values) {
+	record.Params = redactParams(params)
+	// RPC calls tell their action in the parameters, and ROA calls are described by their method and path
+	record.Action = params.Get("Action")
+	if record.Action == "" {
+		record.Action = method + " " + path
+	}
+	if regionId := params.Get("RegionId"); regionId != "" {
+		record.Region = regionId
+	}
+}
+
+// requestParams returns the query and form parameters of the request.
+func requestParams(request *http.Request) url.Values {
+	params := request.URL.Query()
+	if request.GetBody != nil && strings.Contains(request.Header.Get("Content-Type"), "form") {
+		if body, err := request.GetBody(); err == nil {
+			content, _ := ioutil.ReadAll(body)
+			if form, err := url.ParseQuery(string(content)); err == nil {
+				for key, values := range form {
+					params[key] = values
+				}
+			}
+		}
+	}
+	return params
+}
+
+func describeError(record *apiTrace, err error) {
+	switch e := err.(type) {
+	case *errors.ServerError:
+		record.RequestId, record.ErrorCode, record.HttpStatus, record.Error = e.RequestId(), e.ErrorCode(), e.HttpStatus(), e.Message()
+		return
+	case *common.Error:
+		record.RequestId, record.ErrorCode, record.HttpStatus, record.Error = e.RequestId, e.Code, e.StatusCode, e.Message
+		return
+	case oss.ServiceError:
+		record.RequestId, record.ErrorCode, record.HttpStatus, record.Error = e.RequestID, e.Code, e.StatusCode, e.Message
+		return
+	case *sls.Error:
+		record.RequestId, record.ErrorCode, record.HttpStatus, record.Error = e.RequestID, e.Code, int(e.HTTPCode), e.Message
+		return
+	case *fc.ServiceError:
+		record.RequestId, record.ErrorCode, record.HttpStatus, record.Error = e.RequestID, e.ErrorCode, e.HTTPStatus, e.ErrorMessage
+		return
+	case *tablestore.OtsError:
+		record.RequestId, record.ErrorCode, record.HttpStatus, record.Error = e.RequestId, e.Code, e.HttpStatusCode, e.Message
+		return
+	case *errors.ClientError:
+		record.ErrorCode = e.ErrorCode()
+	case mnsError:
+		if response, ok := parseMnsError(e); ok {
+			record.RequestId, record.ErrorCode, record.Error = response.RequestId, response.Code, response.Message
+			return
+		}
+	}
+	record.Error = sensitiveQuery.ReplaceAllString(err.Error(), "${1}"+redacted)
+}
+
+func redactParams(params url.Values) map[string]string {
+	result := make(map[string]string)
+	for key := range params {
+		value := params.Get(key)
+		lower := strings.ToLower(key)
+		for _, word := range sensitiveParams {
+			if strings.Contains(lower, word) {
+				value = redacted
+				break
+			}
+		}
+		result[key] = value
+	}
+	return result
+}
+
+// apiCaller returns the provider function which made the API call, like (*VpcService).DescribeVpc.
+func apiCaller() string {
+	pcs := make([]uintptr, 16)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	for {
+		frame, more := frames.Next()
+		if strings.HasPrefix(frame.Function, providerPackage) {
+			return strings.TrimPrefix(frame.Function, providerPackage)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+func isNilPointer(i interface{}) bool {
+	v := reflect.ValueOf(i)
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
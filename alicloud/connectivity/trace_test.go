@@ -0,0 +1,143 @@
+package connectivity
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/errors"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/aliyun/fc-go-sdk"
+	"github.com/dxh031/ali_mns"
+)
+
+func newTestTracer(t *testing.T) (*apiTracer, func() []apiTrace) {
+	dir, err := ioutil.TempDir("", "tf-trace")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "trace.jsonl")
+	tracer, err := newApiTracer(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tracer, func() []apiTrace {
+		defer os.RemoveAll(dir)
+		if err := tracer.close(); err != nil {
+			t.Fatal(err)
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var traces []apiTrace
+		for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+			if line == "" {
+				continue
+			}
+			var trace apiTrace
+			if err := json.Unmarshal([]byte(line), &trace); err != nil {
+				t.Fatalf("The trace line %s is not JSON: %s.", line, err)
+			}
+			traces = append(traces, trace)
+		}
+		return traces
+	}
+}
+
+func TestUnitTracingTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Oss-Request-Id", "fake-oss-request")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	tracer, traces := newTestTracer(t)
+	client := &AliyunClient{RegionId: "cn-hangzhou", tracer: tracer}
+	httpClient := &http.Client{Transport: client.tracingTransport("oss", nil)}
+	response, err := httpClient.Get(server.URL + "/tf-bucket?acl=&OSSAccessKeyId=fake-access-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+
+	// The successful calls of the products traced by HTTP are not traced again
+	tracer.trace("oss", "cn-hangzhou", 1, time.Second, nil, nil)
+
+	result := traces()
+	if len(result) != 1 {
+		t.Fatalf("Expected one trace of the HTTP request, got %#v.", result)
+	}
+	trace := result[0]
+	if trace.Product != "oss" || trace.Action != "GET /tf-bucket" || trace.RequestId != "fake-oss-request" || trace.HttpStatus != 200 || trace.Region != "cn-hangzhou" {
+		t.Errorf("Unexpected trace of the HTTP request: %#v.", trace)
+	}
+	if _, ok := trace.Params["acl"]; !ok || trace.Params["OSSAccessKeyId"] != redacted {
+		t.Errorf("Expected the query parameters to be traced and redacted, got %#v.", trace.Params)
+	}
+}
+
+func TestUnitTraceFcOutput(t *testing.T) {
+	tracer, traces := newTestTracer(t)
+	output := &fc.GetServiceOutput{Header: http.Header{fc.HTTPHeaderRequestID: []string{"fake-fc-request"}}}
+	tracer.trace("fc", "cn-hangzhou", 1, time.Second, output, nil)
+
+	result := traces()
+	if len(result) != 1 || result[0].Action != "GetService" || result[0].RequestId != "fake-fc-request" {
+		t.Errorf("Expected the FC call to be traced with its action and request ID, got %#v.", result)
+	}
+}
+
+func TestUnitTraceMnsError(t *testing.T) {
+	tracer, traces := newTestTracer(t)
+	err := ali_mns.ParseError(ali_mns.ErrorResponse{Code: "QueueNotExist", Message: "The queue name you provided is not exist.", RequestId: "fake-mns-request"}, "tf-queue")
+	tracer.trace("mns", "cn-hangzhou", 1, time.Second, nil, err)
+
+	result := traces()
+	if len(result) != 1 {
+		t.Fatalf("Expected the failed MNS call to be traced, got %#v.", result)
+	}
+	if trace := result[0]; trace.ErrorCode != "QueueNotExist" || trace.RequestId != "fake-mns-request" || trace.Error != "The queue name you provided is not exist." {
+		t.Errorf("Expected the MNS error to be decoded, got %#v.", trace)
+	}
+}
+
+func TestUnitTraceFailedRequest(t *testing.T) {
+	tracer, traces := newTestTracer(t)
+	timeout := &url.Error{Op: "Post", URL: "https://vpc.aliyuncs.com/?Action=CreateVpc&AccessKeyId=fake-access-key&RegionId=cn-beijing", Err: fmt.Errorf("i/o timeout")}
+	// The response of a call which timed out has no HTTP response
+	tracer.trace("vpc", "cn-hangzhou", 1, time.Second, vpc.CreateCreateVpcResponse(), errors.NewClientError(errors.TimeoutErrorCode, "timeout", timeout))
+	tracer.trace("cr", "cn-hangzhou", 2, time.Second, nil, &url.Error{Op: "Get", URL: "https://cr.cn-hangzhou.aliyuncs.com/repos", Err: fmt.Errorf("i/o timeout")})
+
+	result := traces()
+	if len(result) != 2 {
+		t.Fatalf("Expected the failed calls to be traced, got %#v.", result)
+	}
+	if trace := result[0]; trace.Action != "CreateVpc" || trace.Region != "cn-beijing" || trace.Params["AccessKeyId"] != redacted || trace.ErrorCode != errors.TimeoutErrorCode {
+		t.Errorf("Expected the RPC call to be described by its request, got %#v.", trace)
+	}
+	if trace := result[1]; trace.Action != "GET /repos" || trace.Region != "cn-hangzhou" {
+		t.Errorf("Expected the ROA call to be described by its request, got %#v.", trace)
+	}
+}
+
+func TestUnitTracerClose(t *testing.T) {
+	tracer, traces := newTestTracer(t)
+	client := &AliyunClient{tracer: tracer}
+	if err := client.Close(); err != nil {
+		t.Fatal(err)
+	}
+	// The calls after the client is closed are dropped
+	tracer.trace("vpc", "cn-hangzhou", 1, time.Second, nil, nil)
+	if result := traces(); len(result) != 0 {
+		t.Errorf("Expected no trace after the client is closed, got %#v.", result)
+	}
+}
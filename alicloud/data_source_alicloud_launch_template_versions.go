@@ -0,0 +1,179 @@
+package alicloud
+
+import (
+	"strconv"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func dataSourceAlicloudLaunchTemplateVersions() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAlicloudLaunchTemplateVersionsRead,
+
+		Schema: map[string]*schema.Schema{
+			"launch_template_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"default_version": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"output_file": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			//Computed value
+			"versions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"version_number": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"default_version": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"version_description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"launch_template_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"launch_template_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"image_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"instance_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created_by": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"creation_time": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"modified_time": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAlicloudLaunchTemplateVersionsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+
+	idsMap := make(map[string]string)
+	if v, ok := d.GetOk("ids"); ok {
+		for _, vv := range v.([]interface{}) {
+			idsMap[vv.(string)] = vv.(string)
+		}
+	}
+
+	request := ecs.CreateDescribeLaunchTemplateVersionsRequest()
+	request.RegionId = client.RegionId
+	request.LaunchTemplateId = d.Get("launch_template_id").(string)
+	request.DetailFlag = requests.NewBoolean(true)
+	if v, ok := d.GetOkExists("default_version"); ok {
+		request.DefaultVersion = requests.NewBoolean(v.(bool))
+	}
+	request.PageNumber = requests.NewInteger(1)
+	request.PageSize = requests.NewInteger(PageSizeLarge)
+
+	var versions []ecs.LaunchTemplateVersionSet
+	for {
+		raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.DescribeLaunchTemplateVersions(request)
+		})
+		if err != nil {
+			return WrapErrorf(err, DataDefaultErrorMsg, "alicloud_launch_template_versions", request.GetActionName(), AlibabaCloudSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw)
+		response, _ := raw.(*ecs.DescribeLaunchTemplateVersionsResponse)
+		for _, version := range response.LaunchTemplateVersionSets.LaunchTemplateVersionSet {
+			if len(idsMap) > 0 {
+				if _, ok := idsMap[strconv.FormatInt(version.VersionNumber, 10)]; !ok {
+					continue
+				}
+			}
+			versions = append(versions, version)
+		}
+		if len(response.LaunchTemplateVersionSets.LaunchTemplateVersionSet) < PageSizeLarge {
+			break
+		}
+		if page, err := getNextpageNumber(request.PageNumber); err != nil {
+			return WrapError(err)
+		} else {
+			request.PageNumber = page
+		}
+	}
+
+	return launchTemplateVersionsDescriptionAttributes(d, versions)
+}
+
+func launchTemplateVersionsDescriptionAttributes(d *schema.ResourceData, versions []ecs.LaunchTemplateVersionSet) error {
+	var ids []string
+	var s []map[string]interface{}
+	for _, version := range versions {
+		id := strconv.FormatInt(version.VersionNumber, 10)
+		mapping := map[string]interface{}{
+			"id":                   id,
+			"version_number":       int(version.VersionNumber),
+			"default_version":      version.DefaultVersion,
+			"version_description":  version.VersionDescription,
+			"launch_template_id":   version.LaunchTemplateId,
+			"launch_template_name": version.LaunchTemplateName,
+			"image_id":             version.LaunchTemplateData.ImageId,
+			"instance_type":        version.LaunchTemplateData.InstanceType,
+			"created_by":           version.CreatedBy,
+			"creation_time":        version.CreateTime,
+			"modified_time":        version.ModifiedTime,
+		}
+		ids = append(ids, id)
+		s = append(s, mapping)
+	}
+
+	d.SetId(dataResourceIdHash(append([]string{d.Get("launch_template_id").(string)}, ids...)))
+	if err := d.Set("versions", s); err != nil {
+		return WrapError(err)
+	}
+	if err := d.Set("ids", ids); err != nil {
+		return WrapError(err)
+	}
+	// create a json file in current directory and write data source to it.
+	if output, ok := d.GetOk("output_file"); ok && output.(string) != "" {
+		writeToFile(output.(string), s)
+	}
+	return nil
+}
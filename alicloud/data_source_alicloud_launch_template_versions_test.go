@@ -0,0 +1,86 @@
+package alicloud
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+)
+
+func TestAccAlicloudLaunchTemplateVersionsDataSourceBasic(t *testing.T) {
+	rand := acctest.RandIntRange(1000, 9999)
+	idsConf := dataSourceTestAccConfig{
+		existConfig: testAccCheckAlicloudLaunchTemplateVersionsDataSourceConfig(rand, map[string]string{
+			"ids": `["1"]`,
+		}),
+		fakeConfig: testAccCheckAlicloudLaunchTemplateVersionsDataSourceConfig(rand, map[string]string{
+			"ids": `["2"]`,
+		}),
+	}
+	defaultVersionConf := dataSourceTestAccConfig{
+		existConfig: testAccCheckAlicloudLaunchTemplateVersionsDataSourceConfig(rand, map[string]string{
+			"default_version": "true",
+		}),
+		fakeConfig: testAccCheckAlicloudLaunchTemplateVersionsDataSourceConfig(rand, map[string]string{
+			"default_version": "false",
+		}),
+	}
+	allConf := dataSourceTestAccConfig{
+		existConfig: testAccCheckAlicloudLaunchTemplateVersionsDataSourceConfig(rand, map[string]string{
+			"ids":             `["1"]`,
+			"default_version": "true",
+		}),
+		fakeConfig: testAccCheckAlicloudLaunchTemplateVersionsDataSourceConfig(rand, map[string]string{
+			"ids":             `["2"]`,
+			"default_version": "true",
+		}),
+	}
+	launchTemplateVersionsCheckInfo.dataSourceTestCheck(t, rand, idsConf, defaultVersionConf, allConf)
+}
+
+func testAccCheckAlicloudLaunchTemplateVersionsDataSourceConfig(rand int, attrMap map[string]string) string {
+	var pairs []string
+	for k, v := range attrMap {
+		pairs = append(pairs, k+" = "+v)
+	}
+
+	config := fmt.Sprintf(`
+resource "alicloud_launch_template" "default" {
+  name          = "tf-testAccLaunchTemplateVersionsDataSource%d"
+  instance_type = "ecs.g5.large"
+}
+
+data "alicloud_launch_template_versions" "default" {
+  launch_template_id = "${alicloud_launch_template.default.id}"
+  %s
+}`, rand, strings.Join(pairs, "\n  "))
+	return config
+}
+
+var existLaunchTemplateVersionsMapFunc = func(rand int) map[string]string {
+	return map[string]string{
+		"ids.#":                           "1",
+		"versions.#":                      "1",
+		"versions.0.id":                   "1",
+		"versions.0.version_number":       "1",
+		"versions.0.default_version":      "true",
+		"versions.0.launch_template_id":   CHECKSET,
+		"versions.0.launch_template_name": fmt.Sprintf("tf-testAccLaunchTemplateVersionsDataSource%d", rand),
+		"versions.0.instance_type":        "ecs.g5.large",
+		"versions.0.creation_time":        CHECKSET,
+	}
+}
+
+var fakeLaunchTemplateVersionsMapFunc = func(rand int) map[string]string {
+	return map[string]string{
+		"ids.#":      "0",
+		"versions.#": "0",
+	}
+}
+
+var launchTemplateVersionsCheckInfo = dataSourceAttr{
+	resourceId:   "data.alicloud_launch_template_versions.default",
+	existMapFunc: existLaunchTemplateVersionsMapFunc,
+	fakeMapFunc:  fakeLaunchTemplateVersionsMapFunc,
+}
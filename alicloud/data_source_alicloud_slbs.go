@@ -141,7 +141,7 @@ func dataSourceAlicloudSlbs() *schema.Resource {
 
 func dataSourceAlicloudSlbsRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*connectivity.AliyunClient)
-	slbService := &SlbService{client}
+	tagService := &TagService{client}
 
 	request := slb.CreateDescribeLoadBalancersRequest()
 
@@ -236,15 +236,15 @@ func dataSourceAlicloudSlbsRead(d *schema.ResourceData, meta interface{}) error
 		filteredLoadBalancersTemp = allLoadBalancers
 	}
 
-	return slbsDescriptionAttributes(d, filteredLoadBalancersTemp, slbService)
+	return slbsDescriptionAttributes(d, filteredLoadBalancersTemp, tagService)
 }
 
-func slbsDescriptionAttributes(d *schema.ResourceData, loadBalancers []slb.LoadBalancer, slbService *SlbService) error {
+func slbsDescriptionAttributes(d *schema.ResourceData, loadBalancers []slb.LoadBalancer, tagService *TagService) error {
 	var ids []string
 	var names []string
 	var s []map[string]interface{}
 	for _, loadBalancer := range loadBalancers {
-		tags, _ := slbService.describeTags(loadBalancer.LoadBalancerId)
+		tags, _ := tagService.ListTags(loadBalancer.LoadBalancerId, TagResourceSlb)
 		mapping := map[string]interface{}{
 			"id":                       loadBalancer.LoadBalancerId,
 			"region_id":                loadBalancer.RegionId,
@@ -258,7 +258,7 @@ func slbsDescriptionAttributes(d *schema.ResourceData, loadBalancers []slb.LoadB
 			"address":                  loadBalancer.Address,
 			"internet":                 loadBalancer.AddressType == strings.ToLower(string(Internet)),
 			"creation_time":            loadBalancer.CreateTime,
-			"tags":                     slbService.slbTagsToMap(tags),
+			"tags":                     tags,
 		}
 
 		ids = append(ids, loadBalancer.LoadBalancerId)
@@ -0,0 +1,189 @@
+package alicloud
+
+import (
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func dataSourceAlicloudVpcIpv6Addresses() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAlicloudVpcIpv6AddressesRead,
+
+		Schema: map[string]*schema.Schema{
+			"vpc_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"vswitch_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"associated_instance_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"status": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateAllowedStringValue([]string{string(Pending), string(Available)}),
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"output_file": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			// Computed values
+			"addresses": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ipv6_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"vpc_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"vswitch_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ipv6_gateway_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"associated_instance_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"associated_instance_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"network_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"internet_bandwidth_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAlicloudVpcIpv6AddressesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+
+	request := vpc.CreateDescribeIpv6AddressesRequest()
+	request.RegionId = client.RegionId
+	request.VpcId = d.Get("vpc_id").(string)
+	request.VSwitchId = d.Get("vswitch_id").(string)
+	request.AssociatedInstanceId = d.Get("associated_instance_id").(string)
+	request.PageSize = requests.NewInteger(PageSizeLarge)
+	request.PageNumber = requests.NewInteger(1)
+
+	idsMap := make(map[string]string)
+	if v, ok := d.GetOk("ids"); ok {
+		for _, vv := range v.([]interface{}) {
+			idsMap[Trim(vv.(string))] = Trim(vv.(string))
+		}
+	}
+	status := d.Get("status").(string)
+
+	var addresses []vpc.Ipv6Address
+	for {
+		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.DescribeIpv6Addresses(request)
+		})
+		if err != nil {
+			return WrapErrorf(err, DataDefaultErrorMsg, "alicloud_vpc_ipv6_addresses", request.GetActionName(), AlibabaCloudSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw)
+		response := raw.(*vpc.DescribeIpv6AddressesResponse)
+
+		for _, address := range response.Ipv6Addresses.Ipv6Address {
+			if status != "" && address.Status != status {
+				continue
+			}
+			if len(idsMap) > 0 {
+				if _, ok := idsMap[address.Ipv6AddressId]; !ok {
+					continue
+				}
+			}
+			addresses = append(addresses, address)
+		}
+
+		if len(response.Ipv6Addresses.Ipv6Address) < PageSizeLarge {
+			break
+		}
+		if page, err := getNextpageNumber(request.PageNumber); err != nil {
+			return WrapError(err)
+		} else {
+			request.PageNumber = page
+		}
+	}
+
+	return vpcIpv6AddressesDescriptionAttributes(d, addresses)
+}
+
+func vpcIpv6AddressesDescriptionAttributes(d *schema.ResourceData, addresses []vpc.Ipv6Address) error {
+	var ids []string
+	var s []map[string]interface{}
+	for _, address := range addresses {
+		mapping := map[string]interface{}{
+			"id":                       address.Ipv6AddressId,
+			"ipv6_address":             address.Ipv6Address,
+			"name":                     address.Ipv6AddressName,
+			"vpc_id":                   address.VpcId,
+			"vswitch_id":               address.VSwitchId,
+			"ipv6_gateway_id":          address.Ipv6GatewayId,
+			"associated_instance_id":   address.AssociatedInstanceId,
+			"associated_instance_type": address.AssociatedInstanceType,
+			"network_type":             address.NetworkType,
+			"internet_bandwidth_id":    address.Ipv6InternetBandwidth.Ipv6InternetBandwidthId,
+			"status":                   address.Status,
+		}
+		ids = append(ids, address.Ipv6AddressId)
+		s = append(s, mapping)
+	}
+
+	d.SetId(dataResourceIdHash(ids))
+	if err := d.Set("addresses", s); err != nil {
+		return WrapError(err)
+	}
+	if err := d.Set("ids", ids); err != nil {
+		return WrapError(err)
+	}
+
+	// create a json file in current directory and write data source to it.
+	if output, ok := d.GetOk("output_file"); ok && output.(string) != "" {
+		writeToFile(output.(string), s)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package alicloud
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+)
+
+func TestAccAlicloudVpcIpv6AddressesDataSourceBasic(t *testing.T) {
+	rand := acctest.RandIntRange(10000, 99999)
+	instanceIdConf := dataSourceTestAccConfig{
+		existConfig: testAccCheckAlicloudVpcIpv6AddressesDataSourceConfig(rand, map[string]string{
+			"associated_instance_id": `"${alicloud_instance.default.id}"`,
+		}),
+		fakeConfig: testAccCheckAlicloudVpcIpv6AddressesDataSourceConfig(rand, map[string]string{
+			"associated_instance_id": `"${alicloud_instance.default.id}"`,
+			"status":                 `"Pending"`,
+		}),
+	}
+
+	vswitchIdConf := dataSourceTestAccConfig{
+		existConfig: testAccCheckAlicloudVpcIpv6AddressesDataSourceConfig(rand, map[string]string{
+			"vswitch_id": `"${alicloud_vswitch.default.id}"`,
+		}),
+		fakeConfig: testAccCheckAlicloudVpcIpv6AddressesDataSourceConfig(rand, map[string]string{
+			"vswitch_id": `"${alicloud_vswitch.default.id}"`,
+			"ids":        `["ipv6-fake"]`,
+		}),
+	}
+
+	allConf := dataSourceTestAccConfig{
+		existConfig: testAccCheckAlicloudVpcIpv6AddressesDataSourceConfig(rand, map[string]string{
+			"vpc_id":                 `"${alicloud_vpc.default.id}"`,
+			"vswitch_id":             `"${alicloud_vswitch.default.id}"`,
+			"associated_instance_id": `"${alicloud_instance.default.id}"`,
+			"status":                 `"Available"`,
+		}),
+		fakeConfig: testAccCheckAlicloudVpcIpv6AddressesDataSourceConfig(rand, map[string]string{
+			"vpc_id":                 `"${alicloud_vpc.default.id}"`,
+			"vswitch_id":             `"${alicloud_vswitch.default.id}"`,
+			"associated_instance_id": `"${alicloud_instance.default.id}"`,
+			"status":                 `"Pending"`,
+		}),
+	}
+
+	vpcIpv6AddressesCheckInfo.dataSourceTestCheck(t, rand, instanceIdConf, vswitchIdConf, allConf)
+}
+
+func testAccCheckAlicloudVpcIpv6AddressesDataSourceConfig(rand int, attrMap map[string]string) string {
+	var pairs []string
+	for k, v := range attrMap {
+		pairs = append(pairs, k+" = "+v)
+	}
+
+	config := fmt.Sprintf(`
+variable "name" {
+  default = "tf-testAccVpcIpv6Addresses%d"
+}
+
+data "alicloud_zones" "default" {
+  available_disk_category     = "cloud_efficiency"
+  available_resource_creation = "VSwitch"
+}
+
+data "alicloud_instance_types" "default" {
+  availability_zone = "${data.alicloud_zones.default.zones.0.id}"
+  cpu_core_count    = 2
+  memory_size       = 8
+}
+
+data "alicloud_images" "default" {
+  name_regex  = "^ubuntu_18.*64"
+  most_recent = true
+  owners      = "system"
+}
+
+resource "alicloud_vpc" "default" {
+  name        = "${var.name}"
+  cidr_block  = "172.16.0.0/16"
+  enable_ipv6 = true
+}
+
+resource "alicloud_vswitch" "default" {
+  vpc_id               = "${alicloud_vpc.default.id}"
+  cidr_block           = "172.16.0.0/24"
+  ipv6_cidr_block_mask = 1
+  availability_zone    = "${data.alicloud_zones.default.zones.0.id}"
+  name                 = "${var.name}"
+}
+
+resource "alicloud_security_group" "default" {
+  name   = "${var.name}"
+  vpc_id = "${alicloud_vpc.default.id}"
+}
+
+resource "alicloud_instance" "default" {
+  instance_name      = "${var.name}"
+  image_id           = "${data.alicloud_images.default.images.0.id}"
+  instance_type      = "${data.alicloud_instance_types.default.instance_types.0.id}"
+  security_groups    = ["${alicloud_security_group.default.id}"]
+  vswitch_id         = "${alicloud_vswitch.default.id}"
+  ipv6_address_count = 1
+}
+
+data "alicloud_vpc_ipv6_addresses" "default" {
+  %s
+}`, rand, strings.Join(pairs, "\n  "))
+	return config
+}
+
+var existVpcIpv6AddressesMapFunc = func(rand int) map[string]string {
+	return map[string]string{
+		"ids.#":                              "1",
+		"addresses.#":                        "1",
+		"addresses.0.id":                     CHECKSET,
+		"addresses.0.ipv6_address":           CHECKSET,
+		"addresses.0.vpc_id":                 CHECKSET,
+		"addresses.0.vswitch_id":             CHECKSET,
+		"addresses.0.associated_instance_id": CHECKSET,
+		"addresses.0.status":                 "Available",
+	}
+}
+
+var fakeVpcIpv6AddressesMapFunc = func(rand int) map[string]string {
+	return map[string]string{
+		"ids.#":       "0",
+		"addresses.#": "0",
+	}
+}
+
+var vpcIpv6AddressesCheckInfo = dataSourceAttr{
+	resourceId:   "data.alicloud_vpc_ipv6_addresses.default",
+	existMapFunc: existVpcIpv6AddressesMapFunc,
+	fakeMapFunc:  fakeVpcIpv6AddressesMapFunc,
+}
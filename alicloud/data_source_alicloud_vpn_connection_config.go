@@ -0,0 +1,475 @@
+package alicloud
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"strings"
+	"text/template"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func dataSourceAlicloudVpnConnectionConfig() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAlicloudVpnConnectionConfigRead,
+
+		Schema: map[string]*schema.Schema{
+			"vpn_connection_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"output_file": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			// Computed values
+			"local_ip": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"remote_ip": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"local_subnet": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"remote_subnet": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"ike_config": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"psk": {
+							Type:      schema.TypeString,
+							Computed:  true,
+							Sensitive: true,
+						},
+						"ike_version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ike_mode": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ike_enc_alg": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ike_auth_alg": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ike_pfs": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ike_lifetime": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"ike_local_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ike_remote_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"ipsec_config": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ipsec_enc_alg": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ipsec_auth_alg": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ipsec_pfs": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ipsec_lifetime": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"strongswan_config": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"libreswan_config": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"ipsec_secrets": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"cisco_ios_config": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func dataSourceAlicloudVpnConnectionConfigRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpnGatewayService := VpnGatewayService{client}
+
+	id := d.Get("vpn_connection_id").(string)
+	config, err := vpnGatewayService.DescribeVpnConnectionConfig(id)
+	if err != nil {
+		return WrapError(err)
+	}
+	rendered, err := renderVpnConnectionConfig(id, config)
+	if err != nil {
+		return WrapError(err)
+	}
+
+	d.SetId(id)
+	d.Set("local_ip", config.Local)
+	d.Set("remote_ip", config.Remote)
+	d.Set("local_subnet", config.LocalSubnet)
+	d.Set("remote_subnet", config.RemoteSubnet)
+	if err := d.Set("ike_config", vpnGatewayService.ParseIkeConfig(config.IkeConfig)); err != nil {
+		return WrapError(err)
+	}
+	if err := d.Set("ipsec_config", vpnGatewayService.ParseIpsecConfig(config.IpsecConfig)); err != nil {
+		return WrapError(err)
+	}
+	for key, value := range rendered {
+		d.Set(key, value)
+	}
+
+	// create a json file in current directory and write data source to it.
+	// The pre-shared key is left out, as the file is not protected like the state.
+	if output, ok := d.GetOk("output_file"); ok && output.(string) != "" {
+		s := map[string]interface{}{
+			"id":            id,
+			"local_ip":      config.Local,
+			"remote_ip":     config.Remote,
+			"local_subnet":  config.LocalSubnet,
+			"remote_subnet": config.RemoteSubnet,
+			"ipsec_config":  vpnGatewayService.ParseIpsecConfig(config.IpsecConfig),
+		}
+		writeToFile(output.(string), s)
+	}
+	return nil
+}
+
+// vpnConnectionConfigView is what the device templates are rendered from. The downloaded configuration is from
+// the point of view of the customer gateway, so "local" is the on-premises side and "remote" is the VPN gateway.
+type vpnConnectionConfigView struct {
+	Name          string
+	Local         string
+	Remote        string
+	LocalId       string
+	RemoteId      string
+	LocalSubnets  []string
+	RemoteSubnets []string
+	Psk           string
+	IkeV2         bool
+	Aggressive    bool
+	IkeEncAlg     string
+	IkeAuthAlg    string
+	IkePfs        string
+	IkeLifetime   int64
+	IpsecEncAlg   string
+	IpsecAuthAlg  string
+	IpsecPfs      string
+	IpsecLifetime int64
+}
+
+// renderVpnConnectionConfig renders the downloaded configuration into the configuration of the customer gateway devices,
+// keyed by the attributes of alicloud_vpn_connection_config.
+func renderVpnConnectionConfig(name string, config vpc.VpnConnectionConfig) (map[string]string, error) {
+	view := vpnConnectionConfigView{
+		Name:          name,
+		Local:         config.Local,
+		Remote:        config.Remote,
+		LocalId:       config.IkeConfig.LocalId,
+		RemoteId:      config.IkeConfig.RemoteId,
+		LocalSubnets:  splitVpnSubnets(config.LocalSubnet),
+		RemoteSubnets: splitVpnSubnets(config.RemoteSubnet),
+		Psk:           config.IkeConfig.Psk,
+		IkeV2:         config.IkeConfig.IkeVersion == IKE_VERSION_2,
+		Aggressive:    config.IkeConfig.IkeMode == IKE_MODE_AGGRESSIVE,
+		IkeEncAlg:     config.IkeConfig.IkeEncAlg,
+		IkeAuthAlg:    config.IkeConfig.IkeAuthAlg,
+		IkePfs:        config.IkeConfig.IkePfs,
+		IkeLifetime:   config.IkeConfig.IkeLifetime,
+		IpsecEncAlg:   config.IpsecConfig.IpsecEncAlg,
+		IpsecAuthAlg:  config.IpsecConfig.IpsecAuthAlg,
+		IpsecPfs:      config.IpsecConfig.IpsecPfs,
+		IpsecLifetime: config.IpsecConfig.IpsecLifetime,
+	}
+	if view.LocalId == "" {
+		view.LocalId = view.Local
+	}
+	if view.RemoteId == "" {
+		view.RemoteId = view.Remote
+	}
+
+	rendered := make(map[string]string)
+	for key, tmpl := range vpnConnectionConfigTemplates {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, view); err != nil {
+			return nil, WrapError(err)
+		}
+		rendered[key] = buf.String()
+	}
+	return rendered, nil
+}
+
+func splitVpnSubnets(subnets string) (list []string) {
+	for _, subnet := range strings.Split(subnets, COMMA_SEPARATED) {
+		if subnet = strings.TrimSpace(subnet); subnet != "" {
+			list = append(list, subnet)
+		}
+	}
+	return
+}
+
+// vpnPfsModp maps the DH groups to the names of strongSwan and Libreswan.
+var vpnPfsModp = map[string]string{
+	VPN_PFS_G1:  "modp768",
+	VPN_PFS_G2:  "modp1024",
+	VPN_PFS_G5:  "modp1536",
+	VPN_PFS_G14: "modp2048",
+	VPN_PFS_G24: "modp2048s256",
+}
+
+var vpnConnectionConfigFuncs = template.FuncMap{
+	"join": strings.Join,
+	"pfs": func(group string) bool {
+		_, ok := vpnPfsModp[group]
+		return ok
+	},
+	// swanEnc, swanAuth and swanDh name the algorithms for strongSwan and Libreswan
+	"swanEnc": func(alg string) string {
+		if alg == VPN_ENC_AES {
+			return "aes128"
+		}
+		return alg
+	},
+	"swanAuth": func(alg string) string {
+		if alg == VPN_AUTH_SHA386 {
+			return "sha384"
+		}
+		return alg
+	},
+	"swanDh": func(group string) string {
+		return vpnPfsModp[group]
+	},
+	// ciscoEnc, ciscoHash, ciscoGroup, ciscoEspEnc and ciscoEspAuth name the algorithms for Cisco IOS
+	"ciscoEnc": func(alg string, ikeV2 bool) string {
+		bits := map[string]string{VPN_ENC_AES: "128", VPN_ENC_AES_192: "192", VPN_ENC_AES_256: "256"}[alg]
+		if bits == "" {
+			return alg
+		}
+		if ikeV2 {
+			return "aes-cbc-" + bits
+		}
+		return "aes " + bits
+	},
+	"ciscoHash": func(alg string, ikeV2 bool) string {
+		switch alg {
+		case VPN_AUTH_SHA:
+			if ikeV2 {
+				return "sha1"
+			}
+			return "sha"
+		case VPN_AUTH_SHA386:
+			return "sha384"
+		}
+		return alg
+	},
+	"ciscoGroup": func(group string) string {
+		return strings.TrimPrefix(group, "group")
+	},
+	"ciscoEspEnc": func(alg string) string {
+		switch alg {
+		case VPN_ENC_AES:
+			return "esp-aes 128"
+		case VPN_ENC_AES_192:
+			return "esp-aes 192"
+		case VPN_ENC_AES_256:
+			return "esp-aes 256"
+		}
+		return "esp-" + alg
+	},
+	"ciscoEspAuth": func(alg string) string {
+		switch alg {
+		case VPN_AUTH_SHA:
+			return "esp-sha-hmac"
+		case VPN_AUTH_SHA386:
+			return "esp-sha384-hmac"
+		}
+		return "esp-" + alg + "-hmac"
+	},
+	// wildcard turns a CIDR block into the address and the wildcard mask of a Cisco access list entry
+	"wildcard": func(cidr string) string {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil || network.IP.To4() == nil {
+			return cidr
+		}
+		mask := make(net.IP, len(network.Mask))
+		for i, b := range network.Mask {
+			mask[i] = ^b
+		}
+		return fmt.Sprintf("%s %s", network.IP, mask)
+	},
+	"isIP": func(address string) bool {
+		return net.ParseIP(address) != nil
+	},
+}
+
+var vpnConnectionConfigTemplates = map[string]*template.Template{
+	"strongswan_config": template.Must(template.New("strongswan").Funcs(vpnConnectionConfigFuncs).Parse(
+		`# /etc/ipsec.conf
+conn {{.Name}}
+    type=tunnel
+    authby=secret
+    auto=start
+    keyexchange={{if .IkeV2}}ikev2{{else}}ikev1{{end}}
+{{- if and .Aggressive (not .IkeV2)}}
+    aggressive=yes
+{{- end}}
+    left=%defaultroute
+    leftid={{.LocalId}}
+    leftsubnet={{join .LocalSubnets ","}}
+    right={{.Remote}}
+    rightid={{.RemoteId}}
+    rightsubnet={{join .RemoteSubnets ","}}
+    ike={{swanEnc .IkeEncAlg}}-{{swanAuth .IkeAuthAlg}}-{{swanDh .IkePfs}}!
+    ikelifetime={{.IkeLifetime}}s
+    esp={{swanEnc .IpsecEncAlg}}-{{swanAuth .IpsecAuthAlg}}{{if pfs .IpsecPfs}}-{{swanDh .IpsecPfs}}{{end}}!
+    lifetime={{.IpsecLifetime}}s
+`)),
+
+	"libreswan_config": template.Must(template.New("libreswan").Funcs(vpnConnectionConfigFuncs).Parse(
+		`# /etc/ipsec.d/{{.Name}}.conf
+conn {{.Name}}
+    type=tunnel
+    authby=secret
+    auto=start
+    ikev2={{if .IkeV2}}insist{{else}}no{{end}}
+{{- if and .Aggressive (not .IkeV2)}}
+    aggrmode=yes
+{{- end}}
+    left=%defaultroute
+    leftid={{.LocalId}}
+    leftsubnets={ {{- join .LocalSubnets " " -}} }
+    right={{.Remote}}
+    rightid={{.RemoteId}}
+    rightsubnets={ {{- join .RemoteSubnets " " -}} }
+    ike={{swanEnc .IkeEncAlg}}-{{swanAuth .IkeAuthAlg}};{{swanDh .IkePfs}}
+    ikelifetime={{.IkeLifetime}}s
+    phase2=esp
+{{- if pfs .IpsecPfs}}
+    phase2alg={{swanEnc .IpsecEncAlg}}-{{swanAuth .IpsecAuthAlg}};{{swanDh .IpsecPfs}}
+    pfs=yes
+{{- else}}
+    phase2alg={{swanEnc .IpsecEncAlg}}-{{swanAuth .IpsecAuthAlg}}
+    pfs=no
+{{- end}}
+    salifetime={{.IpsecLifetime}}s
+`)),
+
+	"ipsec_secrets": template.Must(template.New("secrets").Funcs(vpnConnectionConfigFuncs).Parse(
+		`{{.LocalId}} {{.RemoteId}} : PSK "{{.Psk}}"
+`)),
+
+	"cisco_ios_config": template.Must(template.New("cisco").Funcs(vpnConnectionConfigFuncs).Parse(
+		`{{- if .IkeV2 -}}
+crypto ikev2 proposal {{.Name}}
+ encryption {{ciscoEnc .IkeEncAlg true}}
+ integrity {{ciscoHash .IkeAuthAlg true}}
+ group {{ciscoGroup .IkePfs}}
+!
+crypto ikev2 policy {{.Name}}
+ proposal {{.Name}}
+!
+crypto ikev2 keyring {{.Name}}
+ peer {{.Name}}
+  address {{.Remote}}
+  pre-shared-key {{.Psk}}
+!
+crypto ikev2 profile {{.Name}}
+{{- if isIP .RemoteId}}
+ match identity remote address {{.RemoteId}} 255.255.255.255
+{{- else}}
+ match identity remote fqdn {{.RemoteId}}
+{{- end}}
+{{- if isIP .LocalId}}
+ identity local address {{.LocalId}}
+{{- else}}
+ identity local fqdn {{.LocalId}}
+{{- end}}
+ authentication remote pre-share
+ authentication local pre-share
+ keyring local {{.Name}}
+ lifetime {{.IkeLifetime}}
+{{- else -}}
+crypto isakmp policy 10
+ encryption {{ciscoEnc .IkeEncAlg false}}
+ hash {{ciscoHash .IkeAuthAlg false}}
+ authentication pre-share
+ group {{ciscoGroup .IkePfs}}
+ lifetime {{.IkeLifetime}}
+!
+crypto isakmp key {{.Psk}} address {{.Remote}}
+{{- end}}
+!
+crypto ipsec transform-set {{.Name}} {{ciscoEspEnc .IpsecEncAlg}} {{ciscoEspAuth .IpsecAuthAlg}}
+ mode tunnel
+!
+ip access-list extended {{.Name}}
+{{- range $local := .LocalSubnets}}{{range $remote := $.RemoteSubnets}}
+ permit ip {{wildcard $local}} {{wildcard $remote}}
+{{- end}}{{end}}
+!
+crypto map {{.Name}} 10 ipsec-isakmp
+ set peer {{.Remote}}
+ set transform-set {{.Name}}
+{{- if .IkeV2}}
+ set ikev2-profile {{.Name}}
+{{- end}}
+{{- if pfs .IpsecPfs}}
+ set pfs group{{ciscoGroup .IpsecPfs}}
+{{- end}}
+ set security-association lifetime seconds {{.IpsecLifetime}}
+ match address {{.Name}}
+!
+! Apply the crypto map to the Internet-facing interface, e.g.
+! interface GigabitEthernet0/0
+!  crypto map {{.Name}}
+`)),
+}
@@ -0,0 +1,191 @@
+package alicloud
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func TestAccAlicloudVpnConnectionConfigDataSourceBasic(t *testing.T) {
+	resourceId := "data.alicloud_vpn_connection_config.default"
+	rand := acctest.RandIntRange(1000, 9999)
+	name := fmt.Sprintf("tf-testAccVpnConnConfigDataResource%d", rand)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckWithAccountSiteType(t, IntlSite)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+%s
+
+data "alicloud_vpn_connection_config" "default" {
+	vpn_connection_id = "${alicloud_vpn_connection.default.id}"
+}
+`, dataSourceVpnConnectionsConfigDependence(name)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceId, "id", "alicloud_vpn_connection.default", "id"),
+					resource.TestCheckResourceAttr(resourceId, "local_ip", "41.104.22.229"),
+					resource.TestCheckResourceAttrSet(resourceId, "remote_ip"),
+					resource.TestCheckResourceAttr(resourceId, "local_subnet", "10.4.0.0/24"),
+					resource.TestCheckResourceAttr(resourceId, "remote_subnet", "172.16.1.0/24"),
+					resource.TestCheckResourceAttr(resourceId, "ike_config.0.psk", "tf-testvpn1"),
+					resource.TestCheckResourceAttr(resourceId, "ike_config.0.ike_version", "ikev2"),
+					resource.TestCheckResourceAttr(resourceId, "ipsec_config.0.ipsec_lifetime", "86400"),
+					resource.TestCheckResourceAttrSet(resourceId, "strongswan_config"),
+					resource.TestCheckResourceAttrSet(resourceId, "libreswan_config"),
+					resource.TestCheckResourceAttrSet(resourceId, "ipsec_secrets"),
+					resource.TestCheckResourceAttrSet(resourceId, "cisco_ios_config"),
+				),
+			},
+		},
+	})
+}
+
+// TestUnitAlicloudVpnConnectionConfigDataSource reads the configuration downloaded from the fixture response
+// and checks the structured parameters and the rendered configuration of the devices.
+func TestUnitAlicloudVpnConnectionConfigDataSource(t *testing.T) {
+	fc := newFakeCloud(t, connectivity.VPCCode).LoadCassette("vpn_connection_config")
+	defer fc.Close()
+
+	resourceId := "data.alicloud_vpn_connection_config.default"
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+%s
+
+data "alicloud_vpn_connection_config" "default" {
+  vpn_connection_id = "vco-fake0001"
+}
+`, fc.ProviderConfig()),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "id", "vco-fake0001"),
+					resource.TestCheckResourceAttr(resourceId, "local_ip", "41.104.22.229"),
+					resource.TestCheckResourceAttr(resourceId, "remote_ip", "47.98.10.21"),
+					resource.TestCheckResourceAttr(resourceId, "local_subnet", "10.4.0.0/24,10.5.0.0/24"),
+					resource.TestCheckResourceAttr(resourceId, "remote_subnet", "172.16.1.0/24"),
+					resource.TestCheckResourceAttr(resourceId, "ike_config.#", "1"),
+					resource.TestCheckResourceAttr(resourceId, "ike_config.0.psk", "tf-testvpn1"),
+					resource.TestCheckResourceAttr(resourceId, "ike_config.0.ike_version", "ikev1"),
+					resource.TestCheckResourceAttr(resourceId, "ike_config.0.ike_enc_alg", "aes256"),
+					resource.TestCheckResourceAttr(resourceId, "ike_config.0.ike_pfs", "group14"),
+					resource.TestCheckResourceAttr(resourceId, "ike_config.0.ike_lifetime", "86400"),
+					resource.TestCheckResourceAttr(resourceId, "ipsec_config.#", "1"),
+					resource.TestCheckResourceAttr(resourceId, "ipsec_config.0.ipsec_auth_alg", "sha256"),
+					resource.TestCheckResourceAttr(resourceId, "ipsec_config.0.ipsec_lifetime", "3600"),
+					resource.TestCheckResourceAttr(resourceId, "ipsec_secrets", "41.104.22.229 47.98.10.21 : PSK \"tf-testvpn1\"\n"),
+					resource.TestMatchResourceAttr(resourceId, "strongswan_config", regexp.MustCompile(`(?m)^    ike=aes256-sha1-modp2048!$`)),
+					resource.TestMatchResourceAttr(resourceId, "strongswan_config", regexp.MustCompile(`(?m)^    leftsubnet=10.4.0.0/24,10.5.0.0/24$`)),
+					resource.TestMatchResourceAttr(resourceId, "libreswan_config", regexp.MustCompile(`(?m)^    phase2alg=aes128-sha256;modp1024$`)),
+					resource.TestMatchResourceAttr(resourceId, "cisco_ios_config", regexp.MustCompile(`(?m)^crypto isakmp key tf-testvpn1 address 47.98.10.21$`)),
+					resource.TestMatchResourceAttr(resourceId, "cisco_ios_config", regexp.MustCompile(`(?m)^ permit ip 10.5.0.0 0.0.0.255 172.16.1.0 0.0.0.255$`)),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitAlicloudVpnConnectionConfigRender(t *testing.T) {
+	config := vpc.VpnConnectionConfig{
+		LocalSubnet:  "192.168.0.0/16",
+		RemoteSubnet: "172.16.0.0/12, 10.0.0.0/8",
+		Local:        "41.104.22.229",
+		Remote:       "47.98.10.21",
+		IkeConfig: vpc.IkeConfig{
+			Psk:         "secret",
+			IkeVersion:  IKE_VERSION_2,
+			IkeMode:     IKE_MODE_AGGRESSIVE,
+			IkeEncAlg:   VPN_ENC_AES_256,
+			IkeAuthAlg:  VPN_AUTH_SHA386,
+			IkePfs:      VPN_PFS_G24,
+			IkeLifetime: 28800,
+			LocalId:     "alice",
+		},
+		IpsecConfig: vpc.IpsecConfig{
+			IpsecEncAlg:   VPN_ENC_AES_192,
+			IpsecAuthAlg:  VPN_AUTH_SHA,
+			IpsecPfs:      "disabled",
+			IpsecLifetime: 3600,
+		},
+	}
+
+	rendered, err := renderVpnConnectionConfig("vco-fake0001", config)
+	if err != nil {
+		t.Fatalf("rendering the configuration failed: %#v", err)
+	}
+
+	expected := map[string][]string{
+		"strongswan_config": {
+			"conn vco-fake0001",
+			"    keyexchange=ikev2",
+			"    leftid=alice",
+			"    rightid=47.98.10.21",
+			"    rightsubnet=172.16.0.0/12,10.0.0.0/8",
+			"    ike=aes256-sha384-modp2048s256!",
+			"    ikelifetime=28800s",
+			"    esp=aes192-sha1!",
+			"    lifetime=3600s",
+		},
+		"libreswan_config": {
+			"    ikev2=insist",
+			"    leftsubnets={192.168.0.0/16}",
+			"    rightsubnets={172.16.0.0/12 10.0.0.0/8}",
+			"    ike=aes256-sha384;modp2048s256",
+			"    phase2alg=aes192-sha1",
+			"    pfs=no",
+		},
+		"ipsec_secrets": {
+			`alice 47.98.10.21 : PSK "secret"`,
+		},
+		"cisco_ios_config": {
+			"crypto ikev2 proposal vco-fake0001",
+			" encryption aes-cbc-256",
+			" integrity sha384",
+			" group 24",
+			"  pre-shared-key secret",
+			" match identity remote address 47.98.10.21 255.255.255.255",
+			" identity local fqdn alice",
+			"crypto ipsec transform-set vco-fake0001 esp-aes 192 esp-sha-hmac",
+			" permit ip 192.168.0.0 0.0.255.255 172.16.0.0 0.15.255.255",
+			" permit ip 192.168.0.0 0.0.255.255 10.0.0.0 0.255.255.255",
+			" set ikev2-profile vco-fake0001",
+		},
+	}
+	for key, lines := range expected {
+		actual := strings.Split(rendered[key], "\n")
+		for _, line := range lines {
+			found := false
+			for _, l := range actual {
+				if l == line {
+					found = true
+					break
+				}
+			}
+			if !found {
+				t.Errorf("%s does not contain the line %q:\n%s", key, line, rendered[key])
+			}
+		}
+	}
+
+	// Aggressive mode only applies to IKEv1 and PFS is disabled in phase two
+	for key, line := range map[string]string{
+		"strongswan_config": "aggressive=yes",
+		"libreswan_config":  "aggrmode=yes",
+		"cisco_ios_config":  "set pfs",
+	} {
+		if strings.Contains(rendered[key], line) {
+			t.Errorf("%s should not contain %q:\n%s", key, line, rendered[key])
+		}
+	}
+}
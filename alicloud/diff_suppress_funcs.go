@@ -196,6 +196,11 @@ func ecsNotAutoRenewDiffSuppressFunc(k, old, new string, d *schema.ResourceData)
 	return true
 }
 
+// The auto snapshot of a disk is always enabled when an automatic snapshot policy is applied to it.
+func diskEnableAutoSnapshotDiffSuppressFunc(k, old, new string, d *schema.ResourceData) bool {
+	return d.Get("auto_snapshot_policy_id").(string) != ""
+}
+
 func csKubernetesMasterPostPaidDiffSuppressFunc(k, old, new string, d *schema.ResourceData) bool {
 	return common.InstanceChargeType(d.Get("master_instance_charge_type").(string)) == common.PostPaid || !(d.Id() == "") && !d.Get("force_update").(bool)
 }
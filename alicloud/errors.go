@@ -82,6 +82,7 @@ const (
 	Throttling           = "Throttling"
 	IncorrectVpcStatus   = "IncorrectVpcStatus"
 	IncorrectStatus      = "IncorrectStatus"
+	Ipv6GatewayNotFound  = "InvalidIpv6GatewayId.NotFound"
 
 	// NAS
 	InvalidFileSystemIDNotFound = "InvalidFileSystem.NotFound"
@@ -316,6 +317,19 @@ const (
 	// snapshot
 	SnapshotNotFound = "InvalidSnapshotId.NotFound"
 
+	// image
+	ImageNotFound = "InvalidImageId.NotFound"
+
+	// deployment set, dedicated host
+	DeploymentSetNotFound = "InvalidDeploymentSetId.NotFound"
+	DedicatedHostNotFound = "InvalidDedicatedHostId.NotFound"
+
+	// cloud assistant
+	CommandNotFound = "InvalidCommandId.NotFound"
+
+	// fleet
+	FleetNotFound = "InvalidFleetId.NotFound"
+
 	// kv-store
 	InvalidKVStoreInstanceIdNotFound = "InvalidInstanceId.NotFound"
 	// MNS
@@ -103,6 +103,34 @@ const (
 	DiskCloudSSD        = DiskCategory("cloud_ssd")
 )
 
+// diskCategoryUpgrades are the categories which a disk can be changed to in place, from the lowest performance to
+// the highest. ModifyDiskSpec only upgrades a disk, so the other changes of the category replace it.
+var diskCategoryUpgrades = []DiskCategory{DiskCloudEfficiency, DiskCloudSSD, DiskCloudESSD}
+
+// diskCategoryUpgraded reports whether the category of a disk can be changed in place from old to new.
+func diskCategoryUpgraded(old, new string) bool {
+	from, to := -1, -1
+	for i, category := range diskCategoryUpgrades {
+		if string(category) == old {
+			from = i
+		}
+		if string(category) == new {
+			to = i
+		}
+	}
+	return from >= 0 && to > from
+}
+
+// DiskPerformanceLevel is the performance level of the ESSD disks.
+type DiskPerformanceLevel string
+
+const (
+	DiskPL0 = DiskPerformanceLevel("PL0")
+	DiskPL1 = DiskPerformanceLevel("PL1")
+	DiskPL2 = DiskPerformanceLevel("PL2")
+	DiskPL3 = DiskPerformanceLevel("PL3")
+)
+
 type DiskResizeType string
 
 const (
@@ -6,6 +6,12 @@ const (
 	Ssl_Cert_Expired  = Status("expired")
 )
 
+const (
+	VpnRouteEntryPending   = Status("pending")
+	VpnRouteEntryNormal    = Status("normal")
+	VpnRouteEntryPublished = Status("published")
+)
+
 const (
 	IKE_VERSION_1       = string("ikev1")
 	IKE_VERSION_2       = string("ikev2")
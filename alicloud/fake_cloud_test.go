@@ -0,0 +1,505 @@
+package alicloud
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+/**
+	This file provides an in-process fake of the Alibaba Cloud OpenAPI endpoints. It lets the resources' CRUD and refresh
+logic be tested offline: every product gets a local HTTP server, and the provider is pointed at the servers through
+the nested `endpoints` block (see fakeCloud.ProviderConfig) or the <PRODUCT>_ENDPOINT environment variables which are
+read by loadEndpoint (see fakeCloud.Setenv).
+
+	Responses come from Go handlers registered by HandleRPC/HandleROA or from a cassette file under testdata/fakecloud.
+When ALICLOUD_FAKE_CLOUD_RECORD is set, the requests which can not be answered are forwarded to the real endpoints and
+the interactions are written back into the cassette when the fake is closed. Recording needs real credentials.
+*/
+
+// the real endpoints used to record cassettes
+var fakeCloudRecordEndpoints = map[connectivity.ServiceCode]string{
+	connectivity.ECSCode: "ecs.aliyuncs.com",
+	connectivity.VPCCode: "vpc.aliyuncs.com",
+	connectivity.SLBCode: "slb.aliyuncs.com",
+	connectivity.RDSCode: "rds.aliyuncs.com",
+}
+
+// the endpoints block field of each product
+var fakeCloudEndpointFields = map[connectivity.ServiceCode]string{
+	connectivity.ECSCode: "ecs",
+	connectivity.VPCCode: "vpc",
+	connectivity.SLBCode: "slb",
+	connectivity.RDSCode: "rds",
+	connectivity.LOGCode: "log",
+	connectivity.STSCode: "sts",
+}
+
+// the parameters which are different for every request and should not be recorded
+var fakeCloudIgnoredParams = []string{
+	"Action", "Version", "Format", "AccessKeyId", "SecurityToken", "Signature", "SignatureMethod", "SignatureVersion",
+	"SignatureNonce", "SignatureType", "Timestamp", "ClientToken",
+}
+
+type fakeCloudRequest struct {
+	Product connectivity.ServiceCode
+	// Action is set for RPC-style requests only
+	Action string
+	Method string
+	Path   string
+	Params map[string]string
+}
+
+// fakeCloudHandler returns the http status and the response object which will be encoded as JSON
+type fakeCloudHandler func(request *fakeCloudRequest) (int, interface{})
+
+type fakeCloudInteraction struct {
+	Product connectivity.ServiceCode `json:"product"`
+	Action  string                   `json:"action,omitempty"`
+	Method  string                   `json:"method,omitempty"`
+	Path    string                   `json:"path,omitempty"`
+	// The interaction only matches the requests which contain all of these parameters.
+	Params map[string]string `json:"params,omitempty"`
+	// The interaction is not replayed until the request with the action (or ROA "METHOD path") has been received.
+	After  string          `json:"after,omitempty"`
+	Status int             `json:"status"`
+	Body   json.RawMessage `json:"body"`
+}
+
+type fakeCloudCassette struct {
+	Interactions []*fakeCloudInteraction `json:"interactions"`
+}
+
+type fakeCloud struct {
+	t             *testing.T
+	servers       map[connectivity.ServiceCode]*httptest.Server
+	handlers      map[string]fakeCloudHandler
+	cassettePath  string
+	cassette      fakeCloudCassette
+	record        bool
+	requests      []*fakeCloudRequest
+	received      map[string]bool
+	lastWrite     string
+	env           map[string]*string
+	credentials   string
+	endpointsFile string
+	region        string
+	mutex         sync.Mutex
+}
+
+// newFakeCloud starts the fake endpoints of the given products, like connectivity.VPCCode.
+func newFakeCloud(t *testing.T, products ...connectivity.ServiceCode) *fakeCloud {
+	fc := &fakeCloud{
+		t:        t,
+		servers:  make(map[connectivity.ServiceCode]*httptest.Server),
+		handlers: make(map[string]fakeCloudHandler),
+		received: make(map[string]bool),
+		env:      make(map[string]*string),
+		region:   "cn-hangzhou",
+		record:   os.Getenv("ALICLOUD_FAKE_CLOUD_RECORD") != "",
+	}
+	for _, product := range products {
+		if _, ok := fakeCloudEndpointFields[product]; !ok {
+			t.Fatalf("The fake cloud does not support the product %s.", product)
+		}
+		product := product
+		fc.servers[product] = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fc.serve(product, w, r)
+		}))
+	}
+	if _, ok := fc.servers[connectivity.ECSCode]; ok && !fc.record {
+		// The ECS client calls DescribeRegions when it is initialized
+		fc.HandleRPC(connectivity.ECSCode, "DescribeRegions", func(request *fakeCloudRequest) (int, interface{}) {
+			return http.StatusOK, map[string]interface{}{
+				"RequestId": strings.ToUpper(uuid.New().String()),
+				"Regions": map[string]interface{}{
+					"Region": []map[string]string{{"RegionId": request.Params["RegionId"]}},
+				},
+			}
+		})
+	}
+	return fc
+}
+
+// LoadCassette replays the interactions stored in the file testdata/fakecloud/<name>.json.
+func (fc *fakeCloud) LoadCassette(name string) *fakeCloud {
+	fc.cassettePath = filepath.Join("testdata", "fakecloud", name+".json")
+	if fc.record {
+		return fc
+	}
+	data, err := ioutil.ReadFile(fc.cassettePath)
+	if err != nil {
+		fc.t.Fatalf("Loading the cassette %s got an error: %#v.", fc.cassettePath, err)
+	}
+	if err := json.Unmarshal(data, &fc.cassette); err != nil {
+		fc.t.Fatalf("Parsing the cassette %s got an error: %#v.", fc.cassettePath, err)
+	}
+	return fc
+}
+
+// HandleRPC registers a handler for the RPC-style action of the product. Handlers take precedence over the cassette.
+func (fc *fakeCloud) HandleRPC(product connectivity.ServiceCode, action string, handler fakeCloudHandler) {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+	fc.handlers[fmt.Sprintf("%s:%s", product, action)] = handler
+}
+
+// HandleROA registers a handler for the ROA-style method and path of the product.
+func (fc *fakeCloud) HandleROA(product connectivity.ServiceCode, method, path string, handler fakeCloudHandler) {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+	fc.handlers[fmt.Sprintf("%s:%s %s", product, strings.ToUpper(method), path)] = handler
+}
+
+// Endpoint returns the host of the product's fake endpoint.
+func (fc *fakeCloud) Endpoint(product connectivity.ServiceCode) string {
+	return strings.TrimPrefix(fc.servers[product].URL, "http://")
+}
+
+// URL returns the product's fake endpoint with its scheme, like http://127.0.0.1:8080, which is how the provider is
+// pointed to it.
+func (fc *fakeCloud) URL(product connectivity.ServiceCode) string {
+	return fc.servers[product].URL
+}
+
+// WithCredentials replaces the fake access_key and secret_key of the provider block by the given arguments,
+// like a credentials_uri. It has no effect when recording.
+func (fc *fakeCloud) WithCredentials(arguments string) *fakeCloud {
+	fc.credentials = arguments
+	return fc
+}
+
+// WithEndpointsFile replaces the endpoints block of the provider block by the endpoints_file, which
+// should point the products to the fake endpoints. It has no effect when recording.
+func (fc *fakeCloud) WithEndpointsFile(path string) *fakeCloud {
+	fc.endpointsFile = path
+	return fc
+}
+
+// WithRegion replaces the region of the provider block, which is cn-hangzhou by default.
+func (fc *fakeCloud) WithRegion(region string) *fakeCloud {
+	fc.region = region
+	return fc
+}
+
+// ProviderConfig returns the provider block which points all of the products to the fake endpoints. The extra
+// arguments, like a default_tags block, are appended to the block.
+func (fc *fakeCloud) ProviderConfig(extra ...string) string {
+	var endpoints []string
+	for product := range fc.servers {
+		endpoints = append(endpoints, fmt.Sprintf("    %s = \"%s\"", fakeCloudEndpointFields[product], fc.URL(product)))
+	}
+	sort.Strings(endpoints)
+	endpointsConfig := fmt.Sprintf("  endpoints {\n%s\n  }", strings.Join(endpoints, "\n"))
+	if fc.endpointsFile != "" && !fc.record {
+		endpointsConfig = fmt.Sprintf("  endpoints_file = \"%s\"", fc.endpointsFile)
+	}
+	credentials := fmt.Sprintf("  access_key = \"%s\"\n  secret_key = \"%s\"", "fake-access-key", "fake-secret-key")
+	if fc.record {
+		credentials = fmt.Sprintf("  access_key = \"%s\"\n  secret_key = \"%s\"", os.Getenv("ALICLOUD_ACCESS_KEY"), os.Getenv("ALICLOUD_SECRET_KEY"))
+	} else if fc.credentials != "" {
+		credentials = fc.credentials
+	}
+	return fmt.Sprintf(`
+provider "alicloud" {
+%s
+  region     = "%s"
+%s
+%s
+}
+`, credentials, fc.region, endpointsConfig, strings.Join(extra, "\n"))
+}
+
+// Setenv points loadEndpoint to the fake endpoints. The variables are restored when the fake is closed.
+func (fc *fakeCloud) Setenv() {
+	for product := range fc.servers {
+		key := fmt.Sprintf("%s_ENDPOINT", product)
+		if _, ok := fc.env[key]; !ok {
+			if v, ok := os.LookupEnv(key); ok {
+				fc.env[key] = &v
+			} else {
+				fc.env[key] = nil
+			}
+		}
+		os.Setenv(key, fc.URL(product))
+	}
+}
+
+// Requests returns the received requests of the product's action.
+func (fc *fakeCloud) Requests(product connectivity.ServiceCode, action string) []*fakeCloudRequest {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+	var result []*fakeCloudRequest
+	for _, request := range fc.requests {
+		if request.Product == product && request.Action == action {
+			result = append(result, request)
+		}
+	}
+	return result
+}
+
+// CheckRequests checks the product's action has been requested count times so far, and that the last request carries
+// the expected parameters. An empty expected value means the parameter is not sent.
+func (fc *fakeCloud) CheckRequests(product connectivity.ServiceCode, action string, count int, params map[string]string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		requests := fc.Requests(product, action)
+		if len(requests) != count {
+			return fmt.Errorf("expected %d %s requests, got %d", count, action, len(requests))
+		}
+		if count == 0 {
+			return nil
+		}
+		keys := make([]string, 0, len(params))
+		for key := range params {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if actual := requests[count-1].Param(key); actual != params[key] {
+				return fmt.Errorf("expected the %s of %s to be %q, got %q", key, action, params[key], actual)
+			}
+		}
+		return nil
+	}
+}
+
+// Close stops the fake endpoints and writes the cassette in the record mode.
+func (fc *fakeCloud) Close() {
+	for _, server := range fc.servers {
+		server.Close()
+	}
+	for key, value := range fc.env {
+		if value == nil {
+			os.Unsetenv(key)
+		} else {
+			os.Setenv(key, *value)
+		}
+	}
+	if fc.record && fc.cassettePath != "" {
+		data, err := json.MarshalIndent(fc.cassette, "", "  ")
+		if err != nil {
+			fc.t.Fatalf("Encoding the cassette %s got an error: %#v.", fc.cassettePath, err)
+		}
+		if err := os.MkdirAll(filepath.Dir(fc.cassettePath), 0755); err != nil {
+			fc.t.Fatalf("Creating the cassette directory got an error: %#v.", err)
+		}
+		if err := ioutil.WriteFile(fc.cassettePath, append(data, '\n'), 0644); err != nil {
+			fc.t.Fatalf("Writing the cassette %s got an error: %#v.", fc.cassettePath, err)
+		}
+	}
+}
+
+func (fc *fakeCloud) serve(product connectivity.ServiceCode, w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	request := &fakeCloudRequest{
+		Product: product,
+		Method:  r.Method,
+		Path:    r.URL.Path,
+		Params:  make(map[string]string),
+	}
+	for key, values := range r.URL.Query() {
+		request.Params[key] = values[0]
+	}
+	if form, err := url.ParseQuery(string(body)); err == nil && strings.Contains(r.Header.Get("Content-Type"), "form") {
+		for key, values := range form {
+			request.Params[key] = values[0]
+		}
+	}
+	request.Action = request.Params["Action"]
+
+	status, content := fc.respond(request, r, body)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(content)
+}
+
+func (fc *fakeCloud) respond(request *fakeCloudRequest, r *http.Request, body []byte) (int, []byte) {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+
+	fc.requests = append(fc.requests, request)
+	key := request.key()
+	defer func() {
+		fc.received[key] = true
+		if !request.isRead() {
+			fc.lastWrite = key
+		}
+	}()
+
+	if handler, ok := fc.handlers[fmt.Sprintf("%s:%s", request.Product, key)]; ok {
+		status, object := handler(request)
+		content, err := json.Marshal(object)
+		if err != nil {
+			return fakeCloudError(http.StatusInternalServerError, "FakeCloud.InvalidResponse", err.Error())
+		}
+		return status, content
+	}
+
+	if fc.record {
+		status, content, err := fc.forward(request, r, body)
+		if err != nil {
+			return fakeCloudError(http.StatusBadGateway, "FakeCloud.RecordFailed", err.Error())
+		}
+		interaction := &fakeCloudInteraction{
+			Product: request.Product,
+			Action:  request.Action,
+			Params:  request.recordedParams(),
+			After:   fc.lastWrite,
+			Status:  status,
+			Body:    json.RawMessage(content),
+		}
+		if request.Action == "" {
+			interaction.Method, interaction.Path = request.Method, request.Path
+		}
+		if !json.Valid(content) {
+			interaction.Body, _ = json.Marshal(string(content))
+		}
+		fc.cassette.Interactions = append(fc.cassette.Interactions, interaction)
+		return status, content
+	}
+
+	// The last eligible interaction wins, so the later recorded state overrides the earlier one.
+	var matched *fakeCloudInteraction
+	for _, interaction := range fc.cassette.Interactions {
+		if interaction.matches(request) && (interaction.After == "" || fc.received[interaction.After]) {
+			matched = interaction
+		}
+	}
+	if matched == nil {
+		fc.t.Errorf("The fake cloud has no response for the %s request %s with params %v.", request.Product, key, request.Params)
+		return fakeCloudError(http.StatusNotFound, "FakeCloud.NotRecorded", fmt.Sprintf("There is no recorded response for %s.", key))
+	}
+	content := []byte(matched.Body)
+	var text string
+	if json.Unmarshal(content, &text) == nil {
+		content = []byte(text)
+	}
+	return matched.Status, content
+}
+
+func (fc *fakeCloud) forward(request *fakeCloudRequest, r *http.Request, body []byte) (int, []byte, error) {
+	target := fmt.Sprintf("https://%s%s", fakeCloudRecordEndpoints[request.Product], r.URL.RequestURI())
+	forward, err := http.NewRequest(r.Method, target, bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, err
+	}
+	for key, values := range r.Header {
+		for _, value := range values {
+			forward.Header.Add(key, value)
+		}
+	}
+	response, err := http.DefaultClient.Do(forward)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer response.Body.Close()
+	content, err := ioutil.ReadAll(response.Body)
+	return response.StatusCode, content, err
+}
+
+func (request *fakeCloudRequest) key() string {
+	if request.Action != "" {
+		return request.Action
+	}
+	return fmt.Sprintf("%s %s", request.Method, request.Path)
+}
+
+func (request *fakeCloudRequest) isRead() bool {
+	if request.Action == "" {
+		return request.Method == http.MethodGet
+	}
+	for _, prefix := range []string{"Describe", "List", "Get", "Query", "Check"} {
+		if strings.HasPrefix(request.Action, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Param returns the request parameter which is not one of the common parameters.
+func (request *fakeCloudRequest) Param(key string) string {
+	for _, ignored := range fakeCloudIgnoredParams {
+		if ignored == key {
+			return ""
+		}
+	}
+	return request.Params[key]
+}
+
+// recordedParams returns the parameters of the request which are written to the cassette, so the replayed requests
+// are only answered if they send the same parameters.
+func (request *fakeCloudRequest) recordedParams() map[string]string {
+	params := make(map[string]string)
+	for key := range request.Params {
+		if value := request.Param(key); value != "" {
+			params[key] = value
+		}
+	}
+	if len(params) == 0 {
+		return nil
+	}
+	return params
+}
+
+func (interaction *fakeCloudInteraction) matches(request *fakeCloudRequest) bool {
+	if interaction.Product != request.Product {
+		return false
+	}
+	if request.Action != "" {
+		if interaction.Action != request.Action {
+			return false
+		}
+	} else if !strings.EqualFold(interaction.Method, request.Method) || interaction.Path != request.Path {
+		return false
+	}
+	for key, value := range interaction.Params {
+		if request.Params[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+func fakeCloudError(status int, code, message string) (int, []byte) {
+	content, _ := json.Marshal(map[string]string{
+		"RequestId": strings.ToUpper(uuid.New().String()),
+		"Code":      code,
+		"Message":   message,
+	})
+	return status, content
+}
+
+func TestUnitAlicloudFakeCloudRecordedParams(t *testing.T) {
+	request := &fakeCloudRequest{
+		Product: connectivity.VPCCode,
+		Action:  "CreateVpc",
+		Params:  map[string]string{"Action": "CreateVpc", "Signature": "abc", "RegionId": "cn-hangzhou", "CidrBlock": "172.16.0.0/12"},
+	}
+	interaction := &fakeCloudInteraction{Product: connectivity.VPCCode, Action: "CreateVpc", Params: request.recordedParams()}
+	if expected := map[string]string{"RegionId": "cn-hangzhou", "CidrBlock": "172.16.0.0/12"}; !reflect.DeepEqual(interaction.Params, expected) {
+		t.Fatalf("Expected the recorded params to be %v, got %v.", expected, interaction.Params)
+	}
+	if !interaction.matches(request) {
+		t.Fatalf("Expected the interaction to match the request which it was recorded from.")
+	}
+	request.Params["CidrBlock"] = "192.168.0.0/16"
+	if interaction.matches(request) {
+		t.Fatalf("Expected the interaction not to match the request which sends another CidrBlock.")
+	}
+}
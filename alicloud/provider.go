@@ -10,17 +10,20 @@ import (
 	"regexp"
 	"runtime"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/hashcode"
 	"github.com/hashicorp/terraform/helper/mutexkv"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
 )
 
 // Provider returns a schema.Provider for alicloud
 func Provider() terraform.ResourceProvider {
-	return &schema.Provider{
+	provider := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"access_key": {
 				Type:        schema.TypeString,
@@ -46,6 +49,18 @@ func Provider() terraform.ResourceProvider {
 				DefaultFunc: schema.EnvDefaultFunc("ALICLOUD_ECS_ROLE_NAME", os.Getenv("ALICLOUD_ECS_ROLE_NAME")),
 				Description: descriptions["ecs_role_name"],
 			},
+			"credentials_uri": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ALICLOUD_CREDENTIALS_URI", ""),
+				Description: descriptions["credentials_uri"],
+			},
+			"credential_process": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ALICLOUD_CREDENTIAL_PROCESS", ""),
+				Description: descriptions["credential_process"],
+			},
 			"region": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -80,6 +95,12 @@ func Provider() terraform.ResourceProvider {
 				Deprecated: "Field 'fc' has been deprecated from provider version 1.28.0. New field 'fc' which in nested endpoints instead.",
 			},
 			"endpoints": endpointsSchema(),
+			"endpoints_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ALICLOUD_ENDPOINTS_FILE", ""),
+				Description: descriptions["endpoints_file"],
+			},
 			"shared_credentials_file": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -96,6 +117,21 @@ func Provider() terraform.ResourceProvider {
 				Default:     false,
 				Description: descriptions["skip_region_validation"],
 			},
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  descriptions["max_concurrent_requests"],
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"trace_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ALICLOUD_TRACE_FILE", ""),
+				Description: descriptions["trace_file"],
+			},
+			"default_tags": defaultTagsSchema(),
+			"retry":        retrySchema(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 
@@ -110,6 +146,7 @@ func Provider() terraform.ResourceProvider {
 			"alicloud_snapshots":          dataSourceAlicloudSnapshots(),
 			"alicloud_vpcs":               dataSourceAlicloudVpcs(),
 			"alicloud_vswitches":          dataSourceAlicloudVSwitches(),
+			"alicloud_vpc_ipv6_addresses": dataSourceAlicloudVpcIpv6Addresses(),
 			"alicloud_eips":               dataSourceAlicloudEips(),
 			"alicloud_key_pairs":          dataSourceAlicloudKeyPairs(),
 			"alicloud_kms_keys":           dataSourceAlicloudKmsKeys(),
@@ -128,6 +165,7 @@ func Provider() terraform.ResourceProvider {
 			"alicloud_ram_policies":                   dataSourceAlicloudRamPolicies(),
 			"alicloud_security_groups":                dataSourceAlicloudSecurityGroups(),
 			"alicloud_security_group_rules":           dataSourceAlicloudSecurityGroupRules(),
+			"alicloud_launch_template_versions":       dataSourceAlicloudLaunchTemplateVersions(),
 			"alicloud_slbs":                           dataSourceAlicloudSlbs(),
 			"alicloud_slb_attachments":                dataSourceAlicloudSlbAttachments(),
 			"alicloud_slb_listeners":                  dataSourceAlicloudSlbListeners(),
@@ -152,6 +190,7 @@ func Provider() terraform.ResourceProvider {
 			"alicloud_vpn_gateways":                   dataSourceAlicloudVpnGateways(),
 			"alicloud_vpn_customer_gateways":          dataSourceAlicloudVpnCustomerGateways(),
 			"alicloud_vpn_connections":                dataSourceAlicloudVpnConnections(),
+			"alicloud_vpn_connection_config":          dataSourceAlicloudVpnConnectionConfig(),
 			"alicloud_ssl_vpn_servers":                dataSourceAlicloudSslVpnServers(),
 			"alicloud_ssl_vpn_client_certs":           dataSourceAlicloudSslVpnClientCerts(),
 			"alicloud_mongo_instances":                dataSourceAlicloudMongoDBInstances(),
@@ -207,9 +246,20 @@ func Provider() terraform.ResourceProvider {
 			"alicloud_network_interface_attachment":       resourceAliyunNetworkInterfaceAttachment(),
 			"alicloud_snapshot":                           resourceAliyunSnapshot(),
 			"alicloud_snapshot_policy":                    resourceAliyunSnapshotPolicy(),
+			"alicloud_snapshot_policy_attachment":         resourceAlicloudSnapshotPolicyAttachment(),
+			"alicloud_image":                              resourceAliyunImage(),
+			"alicloud_image_copy":                         resourceAliyunImageCopy(),
+			"alicloud_image_share_permission":             resourceAliyunImageSharePermission(),
+			"alicloud_ecs_deployment_set":                 resourceAlicloudEcsDeploymentSet(),
+			"alicloud_ecs_dedicated_host":                 resourceAlicloudEcsDedicatedHost(),
+			"alicloud_ecs_hpc_cluster":                    resourceAlicloudEcsHpcCluster(),
+			"alicloud_ecs_command":                        resourceAlicloudEcsCommand(),
+			"alicloud_ecs_invocation":                     resourceAlicloudEcsInvocation(),
+			"alicloud_ecs_fleet":                          resourceAlicloudEcsFleet(),
 			"alicloud_launch_template":                    resourceAliyunLaunchTemplate(),
 			"alicloud_security_group":                     resourceAliyunSecurityGroup(),
 			"alicloud_security_group_rule":                resourceAliyunSecurityGroupRule(),
+			"alicloud_security_group_rules":               resourceAlicloudSecurityGroupRules(),
 			"alicloud_db_database":                        resourceAlicloudDBDatabase(),
 			"alicloud_db_account":                         resourceAlicloudDBAccount(),
 			"alicloud_db_account_privilege":               resourceAlicloudDBAccountPrivilege(),
@@ -272,81 +322,116 @@ func Provider() terraform.ResourceProvider {
 			"alicloud_ram_role":                    resourceAlicloudRamRole(),
 			"alicloud_ram_policy":                  resourceAlicloudRamPolicy(),
 			// alicloud_ram_alias has been deprecated
-			"alicloud_ram_alias":                           resourceAlicloudRamAccountAlias(),
-			"alicloud_ram_account_alias":                   resourceAlicloudRamAccountAlias(),
-			"alicloud_ram_group_membership":                resourceAlicloudRamGroupMembership(),
-			"alicloud_ram_user_policy_attachment":          resourceAlicloudRamUserPolicyAtatchment(),
-			"alicloud_ram_role_policy_attachment":          resourceAlicloudRamRolePolicyAttachment(),
-			"alicloud_ram_group_policy_attachment":         resourceAlicloudRamGroupPolicyAtatchment(),
-			"alicloud_container_cluster":                   resourceAlicloudCSSwarm(),
-			"alicloud_cs_application":                      resourceAlicloudCSApplication(),
-			"alicloud_cs_swarm":                            resourceAlicloudCSSwarm(),
-			"alicloud_cs_kubernetes":                       resourceAlicloudCSKubernetes(),
-			"alicloud_cs_managed_kubernetes":               resourceAlicloudCSManagedKubernetes(),
-			"alicloud_cr_namespace":                        resourceAlicloudCRNamespace(),
-			"alicloud_cr_repo":                             resourceAlicloudCRRepo(),
-			"alicloud_cdn_domain":                          resourceAlicloudCdnDomain(),
-			"alicloud_cdn_domain_new":                      resourceAlicloudCdnDomainNew(),
-			"alicloud_cdn_domain_config":                   resourceAlicloudCdnDomainConfig(),
-			"alicloud_router_interface":                    resourceAlicloudRouterInterface(),
-			"alicloud_router_interface_connection":         resourceAlicloudRouterInterfaceConnection(),
-			"alicloud_ots_table":                           resourceAlicloudOtsTable(),
-			"alicloud_ots_instance":                        resourceAlicloudOtsInstance(),
-			"alicloud_ots_instance_attachment":             resourceAlicloudOtsInstanceAttachment(),
-			"alicloud_cms_alarm":                           resourceAlicloudCmsAlarm(),
-			"alicloud_pvtz_zone":                           resourceAlicloudPvtzZone(),
-			"alicloud_pvtz_zone_attachment":                resourceAlicloudPvtzZoneAttachment(),
-			"alicloud_pvtz_zone_record":                    resourceAlicloudPvtzZoneRecord(),
-			"alicloud_log_project":                         resourceAlicloudLogProject(),
-			"alicloud_log_store":                           resourceAlicloudLogStore(),
-			"alicloud_log_store_index":                     resourceAlicloudLogStoreIndex(),
-			"alicloud_log_machine_group":                   resourceAlicloudLogMachineGroup(),
-			"alicloud_logtail_config":                      resourceAlicloudLogtailConfig(),
-			"alicloud_logtail_attachment":                  resourceAlicloudLogtailAttachment(),
-			"alicloud_fc_service":                          resourceAlicloudFCService(),
-			"alicloud_fc_function":                         resourceAlicloudFCFunction(),
-			"alicloud_fc_trigger":                          resourceAlicloudFCTrigger(),
-			"alicloud_vpn_gateway":                         resourceAliyunVpnGateway(),
-			"alicloud_vpn_customer_gateway":                resourceAliyunVpnCustomerGateway(),
-			"alicloud_vpn_connection":                      resourceAliyunVpnConnection(),
-			"alicloud_ssl_vpn_server":                      resourceAliyunSslVpnServer(),
-			"alicloud_ssl_vpn_client_cert":                 resourceAliyunSslVpnClientCert(),
-			"alicloud_cen_instance":                        resourceAlicloudCenInstance(),
-			"alicloud_cen_instance_attachment":             resourceAlicloudCenInstanceAttachment(),
-			"alicloud_cen_bandwidth_package":               resourceAlicloudCenBandwidthPackage(),
-			"alicloud_cen_bandwidth_package_attachment":    resourceAlicloudCenBandwidthPackageAttachment(),
-			"alicloud_cen_bandwidth_limit":                 resourceAlicloudCenBandwidthLimit(),
-			"alicloud_cen_route_entry":                     resourceAlicloudCenRouteEntry(),
-			"alicloud_cen_instance_grant":                  resourceAlicloudCenInstanceGrant(),
-			"alicloud_kvstore_instance":                    resourceAlicloudKVStoreInstance(),
-			"alicloud_kvstore_backup_policy":               resourceAlicloudKVStoreBackupPolicy(),
-			"alicloud_datahub_project":                     resourceAlicloudDatahubProject(),
-			"alicloud_datahub_subscription":                resourceAlicloudDatahubSubscription(),
-			"alicloud_datahub_topic":                       resourceAlicloudDatahubTopic(),
-			"alicloud_mns_queue":                           resourceAlicloudMNSQueue(),
-			"alicloud_mns_topic":                           resourceAlicloudMNSTopic(),
-			"alicloud_havip":                               resourceAliyunHaVip(),
-			"alicloud_mns_topic_subscription":              resourceAlicloudMNSSubscription(),
-			"alicloud_havip_attachment":                    resourceAliyunHaVipAttachment(),
-			"alicloud_api_gateway_api":                     resourceAliyunApigatewayApi(),
-			"alicloud_api_gateway_group":                   resourceAliyunApigatewayGroup(),
-			"alicloud_api_gateway_app":                     resourceAliyunApigatewayApp(),
-			"alicloud_api_gateway_app_attachment":          resourceAliyunApigatewayAppAttachment(),
-			"alicloud_api_gateway_vpc_access":              resourceAliyunApigatewayVpc(),
-			"alicloud_common_bandwidth_package":            resourceAliyunCommonBandwidthPackage(),
-			"alicloud_common_bandwidth_package_attachment": resourceAliyunCommonBandwidthPackageAttachment(),
-			"alicloud_drds_instance":                       resourceAlicloudDRDSInstance(),
-			"alicloud_elasticsearch_instance":              resourceAlicloudElasticsearch(),
-			"alicloud_actiontrail":                         resourceAlicloudActiontrail(),
-			"alicloud_cas_certificate":                     resourceAlicloudCasCertificate(),
-			"alicloud_ddoscoo_instance":                    resourceAlicloudDdoscooInstance(),
-			"alicloud_network_acl":                         resourceAliyunNetworkAcl(),
-			"alicloud_network_acl_attachment":              resourceAliyunNetworkAclAttachment(),
-			"alicloud_network_acl_entries":                 resourceAliyunNetworkAclEntries(),
+			"alicloud_ram_alias":                             resourceAlicloudRamAccountAlias(),
+			"alicloud_ram_account_alias":                     resourceAlicloudRamAccountAlias(),
+			"alicloud_ram_group_membership":                  resourceAlicloudRamGroupMembership(),
+			"alicloud_ram_user_policy_attachment":            resourceAlicloudRamUserPolicyAtatchment(),
+			"alicloud_ram_role_policy_attachment":            resourceAlicloudRamRolePolicyAttachment(),
+			"alicloud_ram_group_policy_attachment":           resourceAlicloudRamGroupPolicyAtatchment(),
+			"alicloud_container_cluster":                     resourceAlicloudCSSwarm(),
+			"alicloud_cs_application":                        resourceAlicloudCSApplication(),
+			"alicloud_cs_swarm":                              resourceAlicloudCSSwarm(),
+			"alicloud_cs_kubernetes":                         resourceAlicloudCSKubernetes(),
+			"alicloud_cs_managed_kubernetes":                 resourceAlicloudCSManagedKubernetes(),
+			"alicloud_cr_namespace":                          resourceAlicloudCRNamespace(),
+			"alicloud_cr_repo":                               resourceAlicloudCRRepo(),
+			"alicloud_cdn_domain":                            resourceAlicloudCdnDomain(),
+			"alicloud_cdn_domain_new":                        resourceAlicloudCdnDomainNew(),
+			"alicloud_cdn_domain_config":                     resourceAlicloudCdnDomainConfig(),
+			"alicloud_router_interface":                      resourceAlicloudRouterInterface(),
+			"alicloud_router_interface_connection":           resourceAlicloudRouterInterfaceConnection(),
+			"alicloud_ots_table":                             resourceAlicloudOtsTable(),
+			"alicloud_ots_instance":                          resourceAlicloudOtsInstance(),
+			"alicloud_ots_instance_attachment":               resourceAlicloudOtsInstanceAttachment(),
+			"alicloud_cms_alarm":                             resourceAlicloudCmsAlarm(),
+			"alicloud_pvtz_zone":                             resourceAlicloudPvtzZone(),
+			"alicloud_pvtz_zone_attachment":                  resourceAlicloudPvtzZoneAttachment(),
+			"alicloud_pvtz_zone_record":                      resourceAlicloudPvtzZoneRecord(),
+			"alicloud_log_project":                           resourceAlicloudLogProject(),
+			"alicloud_log_store":                             resourceAlicloudLogStore(),
+			"alicloud_log_store_index":                       resourceAlicloudLogStoreIndex(),
+			"alicloud_log_machine_group":                     resourceAlicloudLogMachineGroup(),
+			"alicloud_logtail_config":                        resourceAlicloudLogtailConfig(),
+			"alicloud_logtail_attachment":                    resourceAlicloudLogtailAttachment(),
+			"alicloud_fc_service":                            resourceAlicloudFCService(),
+			"alicloud_fc_function":                           resourceAlicloudFCFunction(),
+			"alicloud_fc_trigger":                            resourceAlicloudFCTrigger(),
+			"alicloud_vpn_gateway":                           resourceAliyunVpnGateway(),
+			"alicloud_vpn_customer_gateway":                  resourceAliyunVpnCustomerGateway(),
+			"alicloud_vpn_connection":                        resourceAliyunVpnConnection(),
+			"alicloud_ssl_vpn_server":                        resourceAliyunSslVpnServer(),
+			"alicloud_ssl_vpn_client_cert":                   resourceAliyunSslVpnClientCert(),
+			"alicloud_vpn_route_entry":                       resourceAliyunVpnRouteEntry(),
+			"alicloud_vpn_pbr_route_entry":                   resourceAliyunVpnPbrRouteEntry(),
+			"alicloud_cen_instance":                          resourceAlicloudCenInstance(),
+			"alicloud_cen_instance_attachment":               resourceAlicloudCenInstanceAttachment(),
+			"alicloud_cen_bandwidth_package":                 resourceAlicloudCenBandwidthPackage(),
+			"alicloud_cen_bandwidth_package_attachment":      resourceAlicloudCenBandwidthPackageAttachment(),
+			"alicloud_cen_bandwidth_limit":                   resourceAlicloudCenBandwidthLimit(),
+			"alicloud_cen_route_entry":                       resourceAlicloudCenRouteEntry(),
+			"alicloud_cen_instance_grant":                    resourceAlicloudCenInstanceGrant(),
+			"alicloud_kvstore_instance":                      resourceAlicloudKVStoreInstance(),
+			"alicloud_kvstore_backup_policy":                 resourceAlicloudKVStoreBackupPolicy(),
+			"alicloud_datahub_project":                       resourceAlicloudDatahubProject(),
+			"alicloud_datahub_subscription":                  resourceAlicloudDatahubSubscription(),
+			"alicloud_datahub_topic":                         resourceAlicloudDatahubTopic(),
+			"alicloud_mns_queue":                             resourceAlicloudMNSQueue(),
+			"alicloud_mns_topic":                             resourceAlicloudMNSTopic(),
+			"alicloud_havip":                                 resourceAliyunHaVip(),
+			"alicloud_mns_topic_subscription":                resourceAlicloudMNSSubscription(),
+			"alicloud_havip_attachment":                      resourceAliyunHaVipAttachment(),
+			"alicloud_api_gateway_api":                       resourceAliyunApigatewayApi(),
+			"alicloud_api_gateway_group":                     resourceAliyunApigatewayGroup(),
+			"alicloud_api_gateway_app":                       resourceAliyunApigatewayApp(),
+			"alicloud_api_gateway_app_attachment":            resourceAliyunApigatewayAppAttachment(),
+			"alicloud_api_gateway_vpc_access":                resourceAliyunApigatewayVpc(),
+			"alicloud_common_bandwidth_package":              resourceAliyunCommonBandwidthPackage(),
+			"alicloud_common_bandwidth_package_attachment":   resourceAliyunCommonBandwidthPackageAttachment(),
+			"alicloud_drds_instance":                         resourceAlicloudDRDSInstance(),
+			"alicloud_elasticsearch_instance":                resourceAlicloudElasticsearch(),
+			"alicloud_actiontrail":                           resourceAlicloudActiontrail(),
+			"alicloud_cas_certificate":                       resourceAlicloudCasCertificate(),
+			"alicloud_ddoscoo_instance":                      resourceAlicloudDdoscooInstance(),
+			"alicloud_network_acl":                           resourceAliyunNetworkAcl(),
+			"alicloud_network_acl_attachment":                resourceAliyunNetworkAclAttachment(),
+			"alicloud_network_acl_entries":                   resourceAliyunNetworkAclEntries(),
+			"alicloud_vpc_flow_log":                          resourceAlicloudVpcFlowLog(),
+			"alicloud_vpc_ipv6_gateway":                      resourceAlicloudVpcIpv6Gateway(),
+			"alicloud_vpc_ipv6_egress_rule":                  resourceAlicloudVpcIpv6EgressRule(),
+			"alicloud_vpc_ipv6_internet_bandwidth":           resourceAlicloudVpcIpv6InternetBandwidth(),
+			"alicloud_express_connect_virtual_border_router": resourceAlicloudExpressConnectVirtualBorderRouter(),
+			"alicloud_vpc_bgp_group":                         resourceAlicloudVpcBgpGroup(),
+			"alicloud_vpc_bgp_peer":                          resourceAlicloudVpcBgpPeer(),
+			"alicloud_vpc_bgp_network":                       resourceAlicloudVpcBgpNetwork(),
 		},
 
 		ConfigureFunc: providerConfigure,
 	}
+	// The client of the previous test is closed before the provider is configured again
+	provider.MetaReset = func() error {
+		if client, ok := provider.Meta().(*connectivity.AliyunClient); ok {
+			return client.Close()
+		}
+		return nil
+	}
+	return provider
+}
+
+// configuredClients are the clients of the configured providers, which are closed by Shutdown.
+var configuredClients []*connectivity.AliyunClient
+var configuredClientsMutex sync.Mutex
+
+// Shutdown closes the clients of the configured providers, like their trace files. The plugin calls it once
+// Terraform shuts it down.
+func Shutdown() {
+	configuredClientsMutex.Lock()
+	defer configuredClientsMutex.Unlock()
+	for _, client := range configuredClients {
+		if err := client.Close(); err != nil {
+			log.Printf("[WARN] Closing the client got an error: %#v", err)
+		}
+	}
+	configuredClients = nil
 }
 
 var providerConfig map[string]interface{}
@@ -388,13 +473,34 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		}
 	}
 
+	credentialsURI := d.Get("credentials_uri").(string)
+	if credentialsURI == "" {
+		uri, err := getConfigFromProfile(d, "credentials_uri")
+		if err == nil && uri != nil {
+			credentialsURI = uri.(string)
+		}
+	}
+
+	credentialProcess := d.Get("credential_process").(string)
+	if credentialProcess == "" {
+		command, err := getConfigFromProfile(d, "process_command")
+		if err == nil && command != nil {
+			credentialProcess = command.(string)
+		}
+	}
+
 	config := connectivity.Config{
-		AccessKey:            strings.TrimSpace(accessKey),
-		SecretKey:            strings.TrimSpace(secretKey),
-		EcsRoleName:          strings.TrimSpace(ecsRoleName),
-		Region:               connectivity.Region(strings.TrimSpace(region)),
-		RegionId:             strings.TrimSpace(region),
-		SkipRegionValidation: d.Get("skip_region_validation").(bool),
+		AccessKey:             strings.TrimSpace(accessKey),
+		SecretKey:             strings.TrimSpace(secretKey),
+		EcsRoleName:           strings.TrimSpace(ecsRoleName),
+		CredentialsURI:        strings.TrimSpace(credentialsURI),
+		CredentialProcess:     strings.TrimSpace(credentialProcess),
+		Region:                connectivity.Region(strings.TrimSpace(region)),
+		RegionId:              strings.TrimSpace(region),
+		SkipRegionValidation:  d.Get("skip_region_validation").(bool),
+		MaxConcurrentRequests: d.Get("max_concurrent_requests").(int),
+		TraceFile:             d.Get("trace_file").(string),
+		EndpointsFile:         strings.TrimSpace(d.Get("endpoints_file").(string)),
 	}
 
 	token := d.Get("security_token").(string)
@@ -488,10 +594,35 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		config.FcEndpoint = strings.TrimSpace(fcEndpoint.(string))
 	}
 
+	defaultTagsList := d.Get("default_tags").(*schema.Set).List()
+	if len(defaultTagsList) == 1 {
+		defaultTags := defaultTagsList[0].(map[string]interface{})
+		config.DefaultTags = make(map[string]string)
+		for key, value := range defaultTags["tags"].(map[string]interface{}) {
+			config.DefaultTags[key] = value.(string)
+		}
+		log.Printf("[INFO] default_tags configuration set: %v", config.DefaultTags)
+	}
+
+	config.RetryPolicy = connectivity.NewRetryPolicy()
+	retryList := d.Get("retry").(*schema.Set).List()
+	if len(retryList) == 1 {
+		retry := retryList[0].(map[string]interface{})
+		config.RetryPolicy.MaxAttempts = retry["max_attempts"].(int)
+		config.RetryPolicy.BaseDelay, _ = time.ParseDuration(retry["base_delay"].(string))
+		config.RetryPolicy.MaxDelay, _ = time.ParseDuration(retry["max_delay"].(string))
+		config.RetryPolicy.Jitter = retry["jitter"].(float64)
+		config.RetryPolicy.RetryableErrors = expandStringList(retry["retryable_error_codes"].(*schema.Set).List())
+		log.Printf("[INFO] retry configuration set: %#v", config.RetryPolicy)
+	}
+
 	client, err := config.Client()
 	if err != nil {
 		return nil, err
 	}
+	configuredClientsMutex.Lock()
+	configuredClients = append(configuredClients, client)
+	configuredClientsMutex.Unlock()
 
 	return client, nil
 }
@@ -509,6 +640,10 @@ func init() {
 
 		"ecs_role_name": "The RAM Role Name attached on a ECS instance for API operations. You can retrieve this from the 'Access Control' section of the Alibaba Cloud console.",
 
+		"credentials_uri": "The URL of an HTTP endpoint which returns a JSON credential document. It is used when the `access_key` and `secret_key` are not set, and the credentials are fetched again before they expire.",
+
+		"credential_process": "A command which prints a JSON credential document, like the External mode of the aliyun CLI. It is used when the `access_key`, `secret_key` and `credentials_uri` are not set, and it is run again before the credentials expire.",
+
 		"region": "The region where Alibaba Cloud operations will take place. Examples are cn-beijing, cn-hangzhou, eu-central-1, etc.",
 
 		"security_token": "security token. A security token is only required if you are using Security Token Service.",
@@ -527,7 +662,25 @@ func init() {
 
 		"assume_role_session_expiration": "The time after which the established session for assuming role expires. Valid value range: [900-3600] seconds. Default to 0 (in this case Alicloud use own default value).",
 
-		"skip_region_validation": "Skip static validation of region ID. Used by users of alternative AlibabaCloud-like APIs or users w/ access to regions that are not public (yet).",
+		"skip_region_validation": "Skip the validation of region ID by the known regions and the DescribeRegions API. Used by users of alternative AlibabaCloud-like APIs or users w/ access to regions that are not public (yet).",
+
+		"max_concurrent_requests": "The maximum number of API requests which can be sent to Alibaba Cloud at the same time. Default to 0, which means no limit.",
+
+		"endpoints_file": "The path of a JSON, YAML or XML file which maps the services of the regions to their endpoints, like the ones of Apsara Stack. Several files are separated by `:` (`;` on Windows), and the later ones override the earlier ones. The `endpoints` block and the <SERVICE>_ENDPOINT environment variables have precedence over it.",
+
+		"trace_file": "The path of a file which every API call is appended to as a line of JSON, with its product, action, request ID, latency and error code. The credentials and passwords in the parameters are redacted.",
+
+		"default_tags_tags": "A mapping of tags to assign to every resource which supports tags. The tags set in a resource override the same keys.",
+
+		"retry_max_attempts": "The maximum number of times an API call is sent when it fails with a throttling, service unavailable or retryable error. Default to 10.",
+
+		"retry_base_delay": "The delay before the first retry, like `500ms` or `2s`. It doubles on each following retry. Default to `1s`.",
+
+		"retry_max_delay": "The maximum delay between two retries. Default to `30s`.",
+
+		"retry_jitter": "The fraction of every delay which is randomized, from 0 to 1. Default to 0.5.",
+
+		"retry_retryable_error_codes": "The error codes which are retried in addition to the `Throttling*` and `ServiceUnavailable` ones.",
 
 		"ecs_endpoint": "Use this to override the default endpoint URL constructed from the `region`. It's typically used to connect to custom ECS endpoints.",
 
@@ -636,6 +789,70 @@ func assumeRoleSchema() *schema.Schema {
 	}
 }
 
+func defaultTagsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"tags": {
+					Type:        schema.TypeMap,
+					Required:    true,
+					Description: descriptions["default_tags_tags"],
+				},
+			},
+		},
+	}
+}
+
+func retrySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"max_attempts": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      connectivity.DefaultRetryMaxAttempts,
+					Description:  descriptions["retry_max_attempts"],
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+				"base_delay": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      connectivity.DefaultRetryBaseDelay.String(),
+					Description:  descriptions["retry_base_delay"],
+					ValidateFunc: validateDuration,
+				},
+				"max_delay": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      connectivity.DefaultRetryMaxDelay.String(),
+					Description:  descriptions["retry_max_delay"],
+					ValidateFunc: validateDuration,
+				},
+				"jitter": {
+					Type:         schema.TypeFloat,
+					Optional:     true,
+					Default:      connectivity.DefaultRetryJitter,
+					Description:  descriptions["retry_jitter"],
+					ValidateFunc: validation.FloatBetween(0, 1),
+				},
+				"retryable_error_codes": {
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Set:         schema.HashString,
+					Description: descriptions["retry_retryable_error_codes"],
+				},
+			},
+		},
+	}
+}
+
 func endpointsSchema() *schema.Schema {
 	return &schema.Schema{
 		Type:     schema.TypeSet,
@@ -958,6 +1175,14 @@ func getConfigFromProfile(d *schema.ResourceData, ProfileKey string) (interface{
 		if mode != "RamRoleArn" {
 			return float64(0), nil
 		}
+	case "credentials_uri":
+		if mode != "CredentialsURI" {
+			return "", nil
+		}
+	case "process_command":
+		if mode != "External" {
+			return "", nil
+		}
 	}
 
 	return providerConfig[ProfileKey], nil
@@ -1,10 +1,20 @@
 package alicloud
 
 import (
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"strings"
 
@@ -136,3 +146,427 @@ func testAccPreCheckWithCmsContactGroupSetting(t *testing.T) {
 		t.Skipped()
 	}
 }
+
+func testAccPreCheckWithPhysicalConnectionSetting(t *testing.T) {
+	if v := strings.TrimSpace(os.Getenv("ALICLOUD_PHYSICAL_CONNECTION_ID")); v == "" {
+		t.Skipf("Skipping the test case with no physical connection setting")
+		t.Skipped()
+	}
+}
+
+func TestUnitAlicloudProviderCredentialsURI(t *testing.T) {
+	defer testUnsetenv("ALICLOUD_ACCESS_KEY", "ALICLOUD_SECRET_KEY")()
+
+	// The credentials expire within the refresh window, so they are fetched again before every API call
+	var fetched int32
+	broker := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&fetched, 1)
+		json.NewEncoder(w).Encode(map[string]string{
+			"Code":            "Success",
+			"AccessKeyId":     fmt.Sprintf("uri-key-%d", n),
+			"AccessKeySecret": "uri-secret",
+			"SecurityToken":   "uri-token",
+			"Expiration":      time.Now().Add(time.Minute).UTC().Format(time.RFC3339),
+		})
+	}))
+	defer broker.Close()
+
+	fc := newFakeCloud(t, connectivity.VPCCode).LoadCassette("vpc_basic").WithCredentials(fmt.Sprintf(`  credentials_uri = "%s"`, broker.URL))
+	defer fc.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpcDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckVpcConfigFake(fc, ""),
+				Check: func(s *terraform.State) error {
+					requests := fc.Requests(connectivity.VPCCode, "CreateVpc")
+					if len(requests) != 1 {
+						return fmt.Errorf("expected one CreateVpc request, got %d", len(requests))
+					}
+					if key := requests[0].Params["AccessKeyId"]; !strings.HasPrefix(key, "uri-key-") || key == "uri-key-1" {
+						return fmt.Errorf("expected CreateVpc to be signed by refreshed credentials, got %s", key)
+					}
+					if token := requests[0].Params["SecurityToken"]; token != "uri-token" {
+						return fmt.Errorf("expected the SecurityToken of CreateVpc to be uri-token, got %s", token)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestUnitAlicloudProviderCredentialsURIAssumeRole(t *testing.T) {
+	defer testUnsetenv("ALICLOUD_ACCESS_KEY", "ALICLOUD_SECRET_KEY")()
+
+	broker := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"Code":            "Success",
+			"AccessKeyId":     "uri-key",
+			"AccessKeySecret": "uri-secret",
+			"SecurityToken":   "uri-token",
+			"Expiration":      time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+		})
+	}))
+	defer broker.Close()
+
+	fc := newFakeCloud(t, connectivity.VPCCode, connectivity.STSCode).LoadCassette("vpc_basic").WithCredentials(fmt.Sprintf(`  credentials_uri = "%s"
+  assume_role {
+    role_arn = "acs:ram::123456789:role/tf-role"
+  }`, broker.URL))
+	defer fc.Close()
+	fc.HandleRPC(connectivity.STSCode, "AssumeRole", func(request *fakeCloudRequest) (int, interface{}) {
+		return http.StatusOK, map[string]interface{}{
+			"RequestId": "fake-request",
+			"Credentials": map[string]string{
+				"AccessKeyId":     "role-key",
+				"AccessKeySecret": "role-secret",
+				"SecurityToken":   "role-token",
+				"Expiration":      time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+			},
+		}
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpcDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckVpcConfigFake(fc, ""),
+				Check: func(s *terraform.State) error {
+					// The role is assumed whenever the provider is configured
+					assumed := fc.Requests(connectivity.STSCode, "AssumeRole")
+					if len(assumed) == 0 {
+						return fmt.Errorf("expected the role to be assumed")
+					}
+					for _, request := range assumed {
+						if request.Param("RoleArn") != "acs:ram::123456789:role/tf-role" || request.Params["AccessKeyId"] != "uri-key" || request.Params["SecurityToken"] != "uri-token" {
+							return fmt.Errorf("expected AssumeRole to be signed by the credentials of the credentials_uri, got %s", request.Params["AccessKeyId"])
+						}
+					}
+					requests := fc.Requests(connectivity.VPCCode, "CreateVpc")
+					if len(requests) != 1 {
+						return fmt.Errorf("expected one CreateVpc request, got %d", len(requests))
+					}
+					if requests[0].Params["AccessKeyId"] != "role-key" || requests[0].Params["SecurityToken"] != "role-token" {
+						return fmt.Errorf("expected CreateVpc to be signed by the credentials of the role, got %s", requests[0].Params["AccessKeyId"])
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestUnitAlicloudProviderCredentialProcess(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("The credential process of the test is a shell script")
+	}
+	defer testUnsetenv("ALICLOUD_ACCESS_KEY", "ALICLOUD_SECRET_KEY", "ALICLOUD_CREDENTIALS_URI")()
+
+	dir, err := ioutil.TempDir("", "tf-credential-process")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	command := filepath.Join(dir, "credentials.sh")
+	script := `#!/bin/sh
+echo '{"mode": "StsToken", "access_key_id": "process-key", "access_key_secret": "process-secret", "sts_token": "process-token"}'
+`
+	if err := ioutil.WriteFile(command, []byte(script), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	fc := newFakeCloud(t, connectivity.VPCCode).LoadCassette("vpc_basic").WithCredentials(fmt.Sprintf(`  credential_process = "%s"`, command))
+	defer fc.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpcDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckVpcConfigFake(fc, ""),
+				Check: func(s *terraform.State) error {
+					requests := fc.Requests(connectivity.VPCCode, "CreateVpc")
+					if len(requests) != 1 {
+						return fmt.Errorf("expected one CreateVpc request, got %d", len(requests))
+					}
+					if requests[0].Params["AccessKeyId"] != "process-key" || requests[0].Params["SecurityToken"] != "process-token" {
+						return fmt.Errorf("expected CreateVpc to be signed by the credentials of the process, got %s", requests[0].Params["AccessKeyId"])
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+// testUnsetenv unsets the environment variables and returns a function to restore them.
+func testUnsetenv(keys ...string) func() {
+	values := make(map[string]string)
+	for _, key := range keys {
+		if value, ok := os.LookupEnv(key); ok {
+			values[key] = value
+			os.Unsetenv(key)
+		}
+	}
+	return func() {
+		for key, value := range values {
+			os.Setenv(key, value)
+		}
+	}
+}
+
+func TestUnitAlicloudProviderEndpointsFile(t *testing.T) {
+	fc := newFakeCloud(t, connectivity.VPCCode).LoadCassette("vpc_basic")
+	defer fc.Close()
+
+	dir, err := ioutil.TempDir("", "tf-endpoints-file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	host, port, err := net.SplitHostPort(fc.Endpoint(connectivity.VPCCode))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The endpoint of every region is overridden by the one of the region, and the later file overrides the earlier one
+	yamlFile := filepath.Join(dir, "endpoints.yaml")
+	yamlContent := fmt.Sprintf(`
+endpoints:
+  - services:
+      vpc: {domain: "vpc.invalid"}
+  - regions: ["cn-hangzhou", "cn-shanghai"]
+    services:
+      vpc: {domain: "%s", port: 1, scheme: "http"}
+`, host)
+	jsonFile := filepath.Join(dir, "endpoints.json")
+	jsonContent := fmt.Sprintf(`{"endpoints": [{"regions": ["cn-hangzhou"], "services": {"VPC": {"domain": "%s", "port": %s, "scheme": "http"}}}]}`, host, port)
+	if err := ioutil.WriteFile(yamlFile, []byte(yamlContent), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(jsonFile, []byte(jsonContent), 0600); err != nil {
+		t.Fatal(err)
+	}
+	fc.WithEndpointsFile(strings.Join([]string{yamlFile, jsonFile}, string(os.PathListSeparator)))
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpcDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckVpcConfigFake(fc, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("alicloud_vpc.default", "id"),
+					func(s *terraform.State) error {
+						if requests := fc.Requests(connectivity.VPCCode, "CreateVpc"); len(requests) != 1 {
+							return fmt.Errorf("expected one CreateVpc request sent to the endpoint of the endpoints file, got %d", len(requests))
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestUnitAlicloudProviderRegionValidation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tf-regions-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cacheFile := filepath.Join(dir, "regions.json")
+	defer testUnsetenv("ALICLOUD_REGIONS_CACHE_FILE")()
+	os.Setenv("ALICLOUD_REGIONS_CACHE_FILE", cacheFile)
+
+	// ap-northeast-2 is not one of the ValidRegions, so it is validated by the regions of ECS
+	fc := newFakeCloud(t, connectivity.ECSCode).WithRegion("ap-northeast-2")
+	defer fc.Close()
+	fc.HandleRPC(connectivity.ECSCode, "DescribeRegions", func(request *fakeCloudRequest) (int, interface{}) {
+		return http.StatusOK, map[string]interface{}{
+			"RequestId": "D2C41D0E-5B52-4F1A-9B1C-0C0D2E9A3B6F",
+			"Regions": map[string]interface{}{
+				"Region": []map[string]string{{"RegionId": "cn-hangzhou"}, {"RegionId": "ap-northeast-2"}},
+			},
+		}
+	})
+	invalid := newFakeCloud(t, connectivity.ECSCode).WithRegion("cn-nowhere-1")
+	defer invalid.Close()
+	invalid.HandleRPC(connectivity.ECSCode, "DescribeRegions", func(request *fakeCloudRequest) (int, interface{}) {
+		return http.StatusOK, map[string]interface{}{
+			"RequestId": "5E3B7C9A-1D2F-4A6B-8C0E-7F9A1B3C5D7E",
+			"Regions": map[string]interface{}{
+				"Region": []map[string]string{{"RegionId": "cn-hangzhou"}},
+			},
+		}
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fc.ProviderConfig() + `
+data "alicloud_regions" "current" {
+  current = true
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.alicloud_regions.current", "ids.#", "1"),
+					resource.TestCheckResourceAttr("data.alicloud_regions.current", "ids.0", "ap-northeast-2"),
+					func(s *terraform.State) error {
+						data, err := ioutil.ReadFile(cacheFile)
+						if err != nil {
+							return fmt.Errorf("reading the regions cache file got an error: %s", err)
+						}
+						var cache map[string]struct{ Regions []string }
+						if err := json.Unmarshal(data, &cache); err != nil {
+							return err
+						}
+						// The regions described by a custom endpoint are cached apart from the ones of the public endpoint
+						key := "ECS@" + fc.URL(connectivity.ECSCode)
+						if regions := cache[key].Regions; len(regions) != 2 || regions[1] != "ap-northeast-2" {
+							return fmt.Errorf("expected the regions of ECS to be cached by %s, got %v", key, regions)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: invalid.ProviderConfig() + `
+data "alicloud_regions" "current" {
+  current = true
+}
+`,
+				ExpectError: regexp.MustCompile("Invalid Alibaba Cloud region: cn-nowhere-1"),
+			},
+		},
+	})
+}
+
+func TestUnitAlicloudProviderRetryPolicy(t *testing.T) {
+	fc := newFakeCloud(t, connectivity.VPCCode).LoadCassette("vpc_basic")
+	defer fc.Close()
+
+	// The first CreateVpc fails with an error code which is only retried because of the retry block
+	conflicted := false
+	fc.HandleRPC(connectivity.VPCCode, "CreateVpc", func(request *fakeCloudRequest) (int, interface{}) {
+		if !conflicted {
+			conflicted = true
+			return 400, map[string]string{"RequestId": "5E6B5A8C-0F4A-4F5B-9E53-1B2F0E3C7B01", "Code": "OperationConflict", "Message": "The operation conflicts with another one."}
+		}
+		return 200, map[string]string{"RequestId": "5E6B5A8C-0F4A-4F5B-9E53-1B2F0E3C7B02", "VpcId": "vpc-fake0001", "VRouterId": "vrt-fake0001", "RouteTableId": "vtb-fake0001"}
+	})
+
+	resourceId := "alicloud_vpc.default"
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckVpcDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+%s
+
+resource "alicloud_vpc" "default" {
+  name       = "tf-testAccVpcFake"
+  cidr_block = "172.16.0.0/12"
+}
+`, fc.ProviderConfig(`  retry {
+    base_delay            = "1ms"
+    jitter                = 0
+    retryable_error_codes = ["OperationConflict"]
+  }`)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "id", "vpc-fake0001"),
+					func(s *terraform.State) error {
+						if requests := fc.Requests(connectivity.VPCCode, "CreateVpc"); len(requests) != 2 {
+							return fmt.Errorf("expected CreateVpc to be sent twice, got %d", len(requests))
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestUnitAlicloudProviderTraceFile(t *testing.T) {
+	fc := newFakeCloud(t, connectivity.VPCCode).LoadCassette("vpc_basic")
+	defer fc.Close()
+
+	dir, err := ioutil.TempDir("", "tf-trace")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	traceFile := filepath.Join(dir, "trace.jsonl")
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpcDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+%s
+
+resource "alicloud_vpc" "default" {
+  name       = "tf-testAccVpcFake"
+  cidr_block = "172.16.0.0/12"
+}
+`, fc.ProviderConfig(fmt.Sprintf(`  trace_file = "%s"`, traceFile))),
+				Check: resource.TestCheckResourceAttr("alicloud_vpc.default", "id", "vpc-fake0001"),
+			},
+		},
+	})
+
+	content, err := ioutil.ReadFile(traceFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	traces := make(map[string]map[string]interface{})
+	for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+		var trace map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &trace); err != nil {
+			t.Fatalf("the trace line %s is not JSON: %s", line, err)
+		}
+		traces[fmt.Sprint(trace["action"])] = trace
+	}
+
+	create := traces["CreateVpc"]
+	if create == nil {
+		t.Fatalf("CreateVpc is not traced in %s", content)
+	}
+	params := create["params"].(map[string]interface{})
+	for key, value := range map[string]interface{}{
+		"product":    "vpc",
+		"region":     "cn-hangzhou",
+		"request_id": "5E6B5A8C-0F4A-4F5B-9E53-1B2F0E3C7A01",
+		"attempt":    float64(1),
+		"caller":     "resourceAliyunVpcCreate.func1",
+	} {
+		if create[key] != value {
+			t.Errorf("expected the %s of CreateVpc to be %v, got %v", key, value, create[key])
+		}
+	}
+	for key, value := range map[string]interface{}{
+		"CidrBlock":   "172.16.0.0/12",
+		"AccessKeyId": "REDACTED",
+		"Signature":   "REDACTED",
+	} {
+		if params[key] != value {
+			t.Errorf("expected the parameter %s of CreateVpc to be %v, got %v", key, value, params[key])
+		}
+	}
+	if strings.Contains(string(content), "fake-access-key") {
+		t.Errorf("the access key is not redacted in %s", content)
+	}
+
+	// The last DescribeVpcAttribute is the one of the destroy check, which fails as the VPC is gone
+	if describe := traces["DescribeVpcAttribute"]; describe == nil || describe["error_code"] != "InvalidVpcID.NotFound" {
+		t.Errorf("expected DescribeVpcAttribute to be traced with error code InvalidVpcID.NotFound, got %v", describe)
+	}
+}
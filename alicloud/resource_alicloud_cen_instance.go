@@ -21,6 +21,7 @@ func resourceAlicloudCenInstance() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: defaultTagsCustomizeDiff,
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(5 * time.Minute),
@@ -60,6 +61,8 @@ func resourceAlicloudCenInstance() *schema.Resource {
 					return
 				},
 			},
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 		},
 	}
 }
@@ -99,11 +102,12 @@ func resourceAlicloudCenInstanceCreate(d *schema.ResourceData, meta interface{})
 		return WrapErrorf(err, IdMsg, d.Id())
 	}
 
-	return resourceAlicloudCenInstanceRead(d, meta)
+	return resourceAlicloudCenInstanceUpdate(d, meta)
 }
 
 func resourceAlicloudCenInstanceRead(d *schema.ResourceData, meta interface{}) error {
-	cenService := CenService{meta.(*connectivity.AliyunClient)}
+	client := meta.(*connectivity.AliyunClient)
+	cenService := CenService{client}
 	object, err := cenService.DescribeCenInstance(d.Id())
 	if err != nil {
 		if NotFoundError(err) {
@@ -116,10 +120,31 @@ func resourceAlicloudCenInstanceRead(d *schema.ResourceData, meta interface{}) e
 	d.Set("name", object.Name)
 	d.Set("description", object.Description)
 
+	tagService := TagService{client}
+	tags, err := tagService.ListTags(d.Id(), TagResourceCenInstance)
+	if err != nil {
+		return WrapError(err)
+	}
+	setTagsWithDefaults(client, d, tags)
+
 	return nil
 }
 
 func resourceAlicloudCenInstanceUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	tagService := TagService{client}
+	d.Partial(true)
+
+	if err := tagService.SetTags(d, TagResourceCenInstance); err != nil {
+		return WrapError(err)
+	}
+	d.SetPartial("tags")
+
+	if d.IsNewResource() {
+		d.Partial(false)
+		return resourceAlicloudCenInstanceRead(d, meta)
+	}
+
 	update := false
 	request := cbn.CreateModifyCenAttributeRequest()
 	request.CenId = d.Id()
@@ -135,15 +160,17 @@ func resourceAlicloudCenInstanceUpdate(d *schema.ResourceData, meta interface{})
 	}
 
 	if update {
-		client := meta.(*connectivity.AliyunClient)
 		_, err := client.WithCenClient(func(cbnClient *cbn.Client) (interface{}, error) {
 			return cbnClient.ModifyCenAttribute(request)
 		})
 		if err != nil {
 			return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
 		}
+		d.SetPartial("name")
+		d.SetPartial("description")
 	}
 
+	d.Partial(false)
 	return resourceAlicloudCenInstanceRead(d, meta)
 }
 
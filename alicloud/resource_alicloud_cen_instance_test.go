@@ -174,12 +174,25 @@ func TestAccAlicloudCenInstance_basic(t *testing.T) {
 					testAccCheck(map[string]string{"description": "tf-testAccCenConfigDescription-N"}),
 				),
 			},
+			{
+				Config: testAccCenInstanceTagsConfig(rand),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"tags.%":       "2",
+						"tags.Created": "TF",
+						"tags.For":     "acceptance test",
+					}),
+				),
+			},
 			{
 				Config: testAccCenInstanceConfig(rand),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheck(map[string]string{
-						"name":        fmt.Sprintf("tf-testAcc%sCenConfig-%d", defaultRegionToTest, rand),
-						"description": "tf-testAccCenConfigDescription",
+						"name":         fmt.Sprintf("tf-testAcc%sCenConfig-%d", defaultRegionToTest, rand),
+						"description":  "tf-testAccCenConfigDescription",
+						"tags.%":       REMOVEKEY,
+						"tags.Created": REMOVEKEY,
+						"tags.For":     REMOVEKEY,
 					}),
 				),
 			},
@@ -248,6 +261,18 @@ func testAccCenInstanceDescriptionConfig(rand int) string {
 }
 `, defaultRegionToTest, rand)
 }
+func testAccCenInstanceTagsConfig(rand int) string {
+	return fmt.Sprintf(`
+	resource "alicloud_cen_instance" "default" {
+		name = "tf-testAcc%sCenConfig-%d-N"
+		description = "tf-testAccCenConfigDescription-N"
+		tags = {
+			Created = "TF"
+			For     = "acceptance test"
+		}
+}
+`, defaultRegionToTest, rand)
+}
 func testAccCenInstanceMultiConfig(rand int) string {
 	return fmt.Sprintf(`
 	resource "alicloud_cen_instance" "default" {
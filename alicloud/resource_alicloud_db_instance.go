@@ -26,6 +26,7 @@ func resourceAlicloudDBInstance() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: defaultTagsCustomizeDiff,
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
@@ -268,7 +269,8 @@ func resourceAlicloudDBInstance() *schema.Resource {
 				Computed: true,
 			},
 
-			"tags": tagsSchema(),
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 		},
 	}
 }
@@ -319,9 +321,11 @@ func resourceAlicloudDBInstanceUpdate(d *schema.ResourceData, meta interface{})
 		}
 	}
 
-	if err := rdsService.setInstanceTags(d); err != nil {
+	tagService := TagService{client}
+	if err := tagService.SetTags(d, TagResourceDBInstance); err != nil {
 		return WrapError(err)
 	}
+	d.SetPartial("tags")
 
 	if !d.IsNewResource() && (d.HasChange("instance_charge_type") || d.HasChange("period")) {
 		prePaidRequest := rds.CreateModifyDBInstancePayTypeRequest()
@@ -504,13 +508,12 @@ func resourceAlicloudDBInstanceRead(d *schema.ResourceData, meta interface{}) er
 		return WrapError(err)
 	}
 
-	tags, err := rdsService.describeTags(d)
+	tagService := TagService{client}
+	tags, err := tagService.ListTags(d.Id(), TagResourceDBInstance)
 	if err != nil {
 		return WrapError(err)
 	}
-	if len(tags) > 0 {
-		d.Set("tags", rdsService.tagsToMap(tags))
-	}
+	setTagsWithDefaults(client, d, tags)
 
 	monitoringPeriod, err := rdsService.DescribeDbInstanceMonitor(d.Id())
 	if err != nil {
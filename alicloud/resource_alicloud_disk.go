@@ -19,6 +19,7 @@ func resourceAliyunDisk() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: resourceAliyunDiskCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"availability_zone": {
@@ -42,11 +43,17 @@ func resourceAliyunDisk() *schema.Resource {
 			"category": {
 				Type:         schema.TypeString,
 				Optional:     true,
-				ForceNew:     true,
 				ValidateFunc: validateAllowedStringValue([]string{string(DiskCloud), string(DiskCloudESSD), string(DiskCloudSSD), string(DiskCloudEfficiency)}),
 				Default:      DiskCloudEfficiency,
 			},
 
+			"performance_level": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateAllowedStringValue([]string{string(DiskPL0), string(DiskPL1), string(DiskPL2), string(DiskPL3)}),
+			},
+
 			"size": {
 				Type:     schema.TypeInt,
 				Required: true,
@@ -78,9 +85,15 @@ func resourceAliyunDisk() *schema.Resource {
 			},
 
 			"enable_auto_snapshot": {
-				Type:     schema.TypeBool,
+				Type:             schema.TypeBool,
+				Optional:         true,
+				Default:          false,
+				DiffSuppressFunc: diskEnableAutoSnapshotDiffSuppressFunc,
+			},
+
+			"auto_snapshot_policy_id": {
+				Type:     schema.TypeString,
 				Optional: true,
-				Default:  false,
 			},
 
 			"status": {
@@ -88,11 +101,26 @@ func resourceAliyunDisk() *schema.Resource {
 				Computed: true,
 			},
 
-			"tags": tagsSchema(),
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 		},
 	}
 }
 
+// resourceAliyunDiskCustomizeDiff replaces the disk when its category is changed in a way which cannot be done in
+// place, like a downgrade or a change from cloud, and plans its tags_all by the provider default_tags.
+func resourceAliyunDiskCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && d.HasChange("category") {
+		o, n := d.GetChange("category")
+		if !diskCategoryUpgraded(o.(string), n.(string)) {
+			if err := d.ForceNew("category"); err != nil {
+				return err
+			}
+		}
+	}
+	return defaultTagsCustomizeDiff(d, meta)
+}
+
 func resourceAliyunDiskCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*connectivity.AliyunClient)
 	ecsService := EcsService{client}
@@ -130,6 +158,10 @@ func resourceAliyunDiskCreate(d *schema.ResourceData, meta interface{}) error {
 	if v, ok := d.GetOk("encrypted"); ok {
 		request.Encrypted = requests.NewBoolean(v.(bool))
 	}
+	// The performance level of ESSD disks is not supported by the CreateDisk request of the ECS SDK yet
+	if v, ok := d.GetOk("performance_level"); ok {
+		request.QueryParams["PerformanceLevel"] = v.(string)
+	}
 	request.ClientToken = buildClientToken(request.GetActionName())
 	raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
 		return ecsClient.CreateDisk(request)
@@ -171,20 +203,30 @@ func resourceAliyunDiskRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("delete_auto_snapshot", object.DeleteAutoSnapshot)
 	d.Set("delete_with_instance", object.DeleteWithInstance)
 	d.Set("enable_auto_snapshot", object.EnableAutoSnapshot)
+	performanceLevel := ""
+	if object.Category == string(DiskCloudESSD) {
+		if performanceLevel, err = ecsService.DescribeDiskPerformanceLevel(d.Id()); err != nil {
+			return WrapError(err)
+		}
+	}
+	d.Set("performance_level", performanceLevel)
+	// The policy is only read back when it is managed here, so that it can be attached by alicloud_snapshot_policy_attachment as well
+	if d.Get("auto_snapshot_policy_id").(string) != "" {
+		d.Set("auto_snapshot_policy_id", object.AutoSnapshotPolicyId)
+	}
 
 	tags, err := ecsService.DescribeTags(d.Id(), TagResourceDisk)
 	if err != nil && !NotFoundError(err) {
 		return WrapError(err)
 	}
-	if len(tags) > 0 {
-		d.Set("tags", tagsToMap(tags))
-	}
+	setTagsWithDefaults(client, d, tagsToMap(tags))
 
 	return nil
 }
 
 func resourceAliyunDiskUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
 
 	d.Partial(true)
 
@@ -240,30 +282,38 @@ func resourceAliyunDiskUpdate(d *schema.ResourceData, meta interface{}) error {
 		addDebug(request.GetActionName(), raw)
 	}
 
+	if d.HasChange("auto_snapshot_policy_id") {
+		if v, ok := d.GetOk("auto_snapshot_policy_id"); ok {
+			if err := ecsService.ApplyAutoSnapshotPolicy(v.(string), []string{d.Id()}); err != nil {
+				return WrapError(err)
+			}
+		} else if err := ecsService.CancelAutoSnapshotPolicy([]string{d.Id()}); err != nil {
+			return WrapError(err)
+		}
+		d.SetPartial("auto_snapshot_policy_id")
+	}
+
 	if d.IsNewResource() {
 		d.Partial(false)
 		return resourceAliyunDiskRead(d, meta)
 	}
 
-	if d.HasChange("size") {
-		size := d.Get("size").(int)
-		request := ecs.CreateResizeDiskRequest()
-		request.DiskId = d.Id()
-		request.NewSize = requests.NewInteger(size)
-		request.Type = string(DiskResizeTypeOnline)
-		raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
-			return ecsClient.ResizeDisk(request)
-		})
-		if IsExceptedErrors(err, DiskNotSupportOnlineChangeErrors) {
-			request.Type = string(DiskResizeTypeOffline)
-			raw, err = client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
-				return ecsClient.ResizeDisk(request)
-			})
+	if d.HasChange("category") || d.HasChange("performance_level") {
+		performanceLevel := ""
+		if d.Get("category").(string) == string(DiskCloudESSD) {
+			performanceLevel = d.Get("performance_level").(string)
 		}
-		if err != nil {
-			return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
+		if err := ecsService.ModifyDiskSpec(d.Id(), d.Get("category").(string), performanceLevel); err != nil {
+			return WrapError(err)
+		}
+		d.SetPartial("category")
+		d.SetPartial("performance_level")
+	}
+
+	if d.HasChange("size") {
+		if err := ecsService.ResizeDisk(d.Id(), d.Get("size").(int)); err != nil {
+			return WrapError(err)
 		}
-		addDebug(request.GetActionName(), raw)
 		d.SetPartial("size")
 	}
 
@@ -256,6 +256,171 @@ func TestAccAlicloudDisk_multi(t *testing.T) {
 
 }
 
+// TestUnitAlicloudDiskSpec checks the category, the performance level and the size of a disk are changed in place,
+// against the local fake cloud.
+func TestUnitAlicloudDiskSpec(t *testing.T) {
+	fc := newFakeCloud(t, connectivity.ECSCode)
+	defer fc.Close()
+
+	// The policy is applied by an alicloud_snapshot_policy_attachment, which the disk must leave alone
+	disk := map[string]interface{}{
+		"DiskId":               "d-fake0001",
+		"ZoneId":               "cn-hangzhou-b",
+		"Status":               string(Available),
+		"ExpiredTime":          "2999-09-08T16:00Z",
+		"AutoSnapshotPolicyId": "sp-fake0001",
+	}
+	fc.HandleRPC(connectivity.ECSCode, "DescribeZones", func(request *fakeCloudRequest) (int, interface{}) {
+		return 200, map[string]interface{}{
+			"RequestId": "fake-request",
+			"Zones": map[string]interface{}{
+				"Zone": []map[string]interface{}{
+					{
+						"ZoneId":                    "cn-hangzhou-b",
+						"AvailableResourceCreation": map[string]interface{}{"ResourceTypes": []string{"Disk"}},
+						"AvailableDiskCategories":   map[string]interface{}{"DiskCategories": []string{"cloud_efficiency", "cloud_essd"}},
+					},
+				},
+			},
+		}
+	})
+	fc.HandleRPC(connectivity.ECSCode, "CreateDisk", func(request *fakeCloudRequest) (int, interface{}) {
+		disk["Status"] = string(Available)
+		disk["Category"] = request.Param("DiskCategory")
+		disk["PerformanceLevel"] = request.Param("PerformanceLevel")
+		disk["Size"] = request.Param("Size")
+		return 200, map[string]interface{}{"RequestId": "fake-request", "DiskId": disk["DiskId"]}
+	})
+	// Like ECS, the spec is changed after ModifyDiskSpec has returned, so the first DescribeDisks still has the old one
+	modifying := make(map[string]interface{})
+	fc.HandleRPC(connectivity.ECSCode, "DescribeDisks", func(request *fakeCloudRequest) (int, interface{}) {
+		response := map[string]interface{}{
+			"RequestId":  "fake-request",
+			"TotalCount": 1,
+			"Disks":      map[string]interface{}{"Disk": []map[string]interface{}{copyFakeDisk(disk)}},
+		}
+		for k, v := range modifying {
+			disk[k] = v
+			delete(modifying, k)
+		}
+		return 200, response
+	})
+	fc.HandleRPC(connectivity.ECSCode, "DescribeTags", func(request *fakeCloudRequest) (int, interface{}) {
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	})
+	fc.HandleRPC(connectivity.ECSCode, "ModifyDiskAttribute", func(request *fakeCloudRequest) (int, interface{}) {
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	})
+	fc.HandleRPC(connectivity.ECSCode, "ModifyDiskSpec", func(request *fakeCloudRequest) (int, interface{}) {
+		if v := request.Param("DiskCategory"); v != "" {
+			modifying["Category"] = v
+		}
+		modifying["PerformanceLevel"] = request.Param("PerformanceLevel")
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	})
+	fc.HandleRPC(connectivity.ECSCode, "ResizeDisk", func(request *fakeCloudRequest) (int, interface{}) {
+		disk["Size"] = request.Param("NewSize")
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	})
+	fc.HandleRPC(connectivity.ECSCode, "DeleteDisk", func(request *fakeCloudRequest) (int, interface{}) {
+		disk["Status"] = "Deleted"
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	})
+
+	resourceId := "alicloud_disk.default"
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDiskConfigFake(fc, "cloud_efficiency", "", 50),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "category", "cloud_efficiency"),
+					resource.TestCheckResourceAttr(resourceId, "performance_level", ""),
+					resource.TestCheckNoResourceAttr(resourceId, "auto_snapshot_policy_id"),
+					fc.CheckRequests(connectivity.ECSCode, "ModifyDiskSpec", 0, nil),
+					fc.CheckRequests(connectivity.ECSCode, "CancelAutoSnapshotPolicy", 0, nil),
+				),
+			},
+			{
+				Config: testAccDiskConfigFake(fc, "cloud_essd", "PL2", 100),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "id", "d-fake0001"),
+					resource.TestCheckResourceAttr(resourceId, "category", "cloud_essd"),
+					resource.TestCheckResourceAttr(resourceId, "performance_level", "PL2"),
+					resource.TestCheckResourceAttr(resourceId, "size", "100"),
+					fc.CheckRequests(connectivity.ECSCode, "CreateDisk", 1, nil),
+					fc.CheckRequests(connectivity.ECSCode, "ModifyDiskSpec", 1, map[string]string{"DiskCategory": "cloud_essd", "PerformanceLevel": "PL2"}),
+					fc.CheckRequests(connectivity.ECSCode, "ResizeDisk", 1, map[string]string{"NewSize": "100", "Type": string(DiskResizeTypeOnline)}),
+				),
+			},
+			{
+				Config: testAccDiskConfigFake(fc, "cloud_essd", "PL3", 100),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "performance_level", "PL3"),
+					fc.CheckRequests(connectivity.ECSCode, "ModifyDiskSpec", 2, map[string]string{"DiskCategory": "", "PerformanceLevel": "PL3"}),
+				),
+			},
+			{
+				// A downgrade cannot be done in place, so the disk is replaced
+				Config: testAccDiskConfigFake(fc, "cloud_efficiency", "", 100),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "category", "cloud_efficiency"),
+					resource.TestCheckResourceAttr(resourceId, "performance_level", ""),
+					fc.CheckRequests(connectivity.ECSCode, "ModifyDiskSpec", 2, nil),
+					fc.CheckRequests(connectivity.ECSCode, "DeleteDisk", 1, nil),
+					fc.CheckRequests(connectivity.ECSCode, "CreateDisk", 2, map[string]string{"DiskCategory": "cloud_efficiency"}),
+				),
+			},
+		},
+	})
+}
+
+func copyFakeDisk(disk map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{})
+	for k, v := range disk {
+		copied[k] = v
+	}
+	return copied
+}
+
+func TestUnitAlicloudDiskCategoryUpgraded(t *testing.T) {
+	cases := []struct {
+		old, new string
+		upgraded bool
+	}{
+		{"cloud_efficiency", "cloud_ssd", true},
+		{"cloud_efficiency", "cloud_essd", true},
+		{"cloud_ssd", "cloud_essd", true},
+		{"cloud_essd", "cloud_efficiency", false},
+		{"cloud_ssd", "cloud_efficiency", false},
+		{"cloud", "cloud_essd", false},
+		{"cloud_efficiency", "cloud", false},
+		{"ephemeral_ssd", "cloud_essd", false},
+	}
+	for _, c := range cases {
+		if actual := diskCategoryUpgraded(c.old, c.new); actual != c.upgraded {
+			t.Errorf("Expected the change of the category from %s to %s to be upgraded %t, got %t.", c.old, c.new, c.upgraded, actual)
+		}
+	}
+}
+
+func testAccDiskConfigFake(fc *fakeCloud, category, performanceLevel string, size int) string {
+	if performanceLevel != "" {
+		performanceLevel = fmt.Sprintf("performance_level = \"%s\"", performanceLevel)
+	}
+	return fmt.Sprintf(`
+%s
+
+resource "alicloud_disk" "default" {
+  availability_zone = "cn-hangzhou-b"
+  category          = "%s"
+  size              = %d
+  %s
+}
+`, fc.ProviderConfig(), category, size, performanceLevel)
+}
+
 const testAccDiskConfig_basic = `
 data "alicloud_zones" "default" {
 	available_resource_creation= "VSwitch"
@@ -0,0 +1,181 @@
+package alicloud
+
+import (
+	"encoding/base64"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+// resourceAlicloudEcsCommand is a Cloud Assistant command, a script which can be run on the instances by the
+// alicloud_ecs_invocation.
+func resourceAlicloudEcsCommand() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAlicloudEcsCommandCreate,
+		Read:   resourceAlicloudEcsCommandRead,
+		Update: resourceAlicloudEcsCommandUpdate,
+		Delete: resourceAlicloudEcsCommandDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateStringLengthInRange(1, 128),
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateAllowedStringValue([]string{"RunShellScript", "RunBatScript", "RunPowerShellScript"}),
+			},
+			"command_content": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateStringLengthInRange(0, 512),
+			},
+			"working_dir": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      60,
+				ValidateFunc: validateIntegerInRange(10, 86400),
+			},
+			"enable_parameter": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+func resourceAlicloudEcsCommandCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+
+	request := ecs.CreateCreateCommandRequest()
+	request.RegionId = client.RegionId
+	request.Name = d.Get("name").(string)
+	request.Type = d.Get("type").(string)
+	request.CommandContent = encodeCommandContent(d.Get("command_content").(string))
+	request.Description = d.Get("description").(string)
+	request.WorkingDir = d.Get("working_dir").(string)
+	request.Timeout = requests.NewInteger(d.Get("timeout").(int))
+	request.EnableParameter = requests.NewBoolean(d.Get("enable_parameter").(bool))
+
+	raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.CreateCommand(request)
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, "alicloud_ecs_command", request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+	response := raw.(*ecs.CreateCommandResponse)
+	d.SetId(response.CommandId)
+
+	return resourceAlicloudEcsCommandRead(d, meta)
+}
+
+func resourceAlicloudEcsCommandRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+	object, err := ecsService.DescribeEcsCommand(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+	d.Set("name", object.Name)
+	d.Set("type", object.Type)
+	d.Set("description", object.Description)
+	d.Set("working_dir", object.WorkingDir)
+	d.Set("timeout", object.Timeout)
+	d.Set("enable_parameter", object.EnableParameter)
+
+	// The content is always encoded by encodeCommandContent, so it is decoded back to the plain text
+	content, err := base64.StdEncoding.DecodeString(object.CommandContent)
+	if err != nil {
+		return WrapError(err)
+	}
+	d.Set("command_content", string(content))
+	return nil
+}
+
+func resourceAlicloudEcsCommandUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+
+	request := ecs.CreateModifyCommandRequest()
+	request.RegionId = client.RegionId
+	request.CommandId = d.Id()
+	update := false
+	if d.HasChange("name") {
+		request.Name = d.Get("name").(string)
+		update = true
+	}
+	if d.HasChange("command_content") {
+		request.CommandContent = encodeCommandContent(d.Get("command_content").(string))
+		update = true
+	}
+	if d.HasChange("description") {
+		request.Description = d.Get("description").(string)
+		update = true
+	}
+	if d.HasChange("working_dir") {
+		request.WorkingDir = d.Get("working_dir").(string)
+		update = true
+	}
+	if d.HasChange("timeout") {
+		request.Timeout = requests.NewInteger(d.Get("timeout").(int))
+		update = true
+	}
+	if update {
+		raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.ModifyCommand(request)
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw)
+	}
+	return resourceAlicloudEcsCommandRead(d, meta)
+}
+
+func resourceAlicloudEcsCommandDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	request := ecs.CreateDeleteCommandRequest()
+	request.RegionId = client.RegionId
+	request.CommandId = d.Id()
+	raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.DeleteCommand(request)
+	})
+	if err != nil {
+		if IsExceptedError(err, CommandNotFound) {
+			return nil
+		}
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+	return nil
+}
+
+// encodeCommandContent returns the content in base64, which is required by the API. Like the user_data of
+// alicloud_instance, the content is always plain text, since a script may happen to be valid base64 as well.
+func encodeCommandContent(content string) string {
+	return base64.StdEncoding.EncodeToString([]byte(content))
+}
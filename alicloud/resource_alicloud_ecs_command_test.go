@@ -0,0 +1,90 @@
+package alicloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func TestAccAlicloudEcsCommandBasic(t *testing.T) {
+	var v *ecs.Command
+	resourceId := "alicloud_ecs_command.default"
+	rand := acctest.RandInt()
+	name := fmt.Sprintf("tf-testAccEcsCommand%d", rand)
+	ra := resourceAttrInit(resourceId, map[string]string{
+		"name":             name,
+		"type":             "RunShellScript",
+		"command_content":  "echo hello",
+		"description":      name,
+		"working_dir":      "/root",
+		"timeout":          "60",
+		"enable_parameter": "false",
+	})
+
+	serviceFunc := func() interface{} {
+		return &EcsService{testAccProvider.Meta().(*connectivity.AliyunClient)}
+	}
+	rc := resourceCheckInit(resourceId, &v, serviceFunc)
+
+	rac := resourceAttrCheckInit(rc, ra)
+
+	testAccCheck := rac.resourceAttrMapUpdateSet()
+	testAccConfig := resourceTestAccConfigFunc(resourceId, name, resourceEcsCommandConfigDependence)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		// module name
+		IDRefreshName: resourceId,
+
+		Providers:    testAccProviders,
+		CheckDestroy: rac.checkResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"name":            "${var.name}",
+					"type":            "RunShellScript",
+					"command_content": "echo hello",
+					"description":     "${var.name}",
+					"working_dir":     "/root",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(nil),
+				),
+			},
+			{
+				ResourceName:      resourceId,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"name":            "${var.name}_update",
+					"command_content": "echo world",
+					"timeout":         "120",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"name":            name + "_update",
+						"command_content": "echo world",
+						"timeout":         "120",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func resourceEcsCommandConfigDependence(name string) string {
+	return fmt.Sprintf(`
+variable "name" {
+  default = "%s"
+}
+`, name)
+}
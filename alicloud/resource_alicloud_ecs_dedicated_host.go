@@ -0,0 +1,246 @@
+package alicloud
+
+import (
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+// resourceAlicloudEcsDedicatedHost allocates a PostPaid dedicated host. The PrePaid hosts cannot be released by API.
+func resourceAlicloudEcsDedicatedHost() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAlicloudEcsDedicatedHostCreate,
+		Read:   resourceAlicloudEcsDedicatedHostRead,
+		Update: resourceAlicloudEcsDedicatedHostUpdate,
+		Delete: resourceAlicloudEcsDedicatedHostDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		CustomizeDiff: defaultTagsCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(DefaultTimeout * time.Second),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"dedicated_host_type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"availability_zone": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateInstanceName,
+			},
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateInstanceDescription,
+			},
+			"action_on_maintenance": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateAllowedStringValue([]string{"Migrate", "Stop"}),
+			},
+			"auto_placement": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateAllowedStringValue([]string{"on", "off"}),
+			},
+			"auto_release_time": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"resource_group_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
+		},
+	}
+}
+
+func resourceAlicloudEcsDedicatedHostCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	request := ecs.CreateAllocateDedicatedHostsRequest()
+	request.RegionId = client.RegionId
+	request.ClientToken = buildClientToken(request.GetActionName())
+	request.DedicatedHostType = d.Get("dedicated_host_type").(string)
+	request.ZoneId = d.Get("availability_zone").(string)
+	request.DedicatedHostName = d.Get("name").(string)
+	request.Description = d.Get("description").(string)
+	request.ActionOnMaintenance = d.Get("action_on_maintenance").(string)
+	request.AutoPlacement = d.Get("auto_placement").(string)
+	request.AutoReleaseTime = d.Get("auto_release_time").(string)
+	request.ResourceGroupId = d.Get("resource_group_id").(string)
+	request.ChargeType = string(PostPaid)
+	request.Quantity = requests.NewInteger(1)
+
+	raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.AllocateDedicatedHosts(request)
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, "alicloud_ecs_dedicated_host", request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+	response := raw.(*ecs.AllocateDedicatedHostsResponse)
+	if len(response.DedicatedHostIdSets.DedicatedHostId) != 1 {
+		return WrapError(Error("AllocateDedicatedHosts returned %d dedicated hosts.", len(response.DedicatedHostIdSets.DedicatedHostId)))
+	}
+	d.SetId(response.DedicatedHostIdSets.DedicatedHostId[0])
+
+	stateConf := BuildStateConf([]string{"", string(DedicatedHostUnderAssessment)}, []string{string(Available)}, d.Timeout(schema.TimeoutCreate), 5*time.Second,
+		ecsService.EcsDedicatedHostStateRefreshFunc(d.Id(), []string{string(DedicatedHostPermanentFailure)}))
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+
+	return resourceAlicloudEcsDedicatedHostUpdate(d, meta)
+}
+
+func resourceAlicloudEcsDedicatedHostRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+	object, err := ecsService.DescribeEcsDedicatedHost(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+	d.Set("dedicated_host_type", object.DedicatedHostType)
+	d.Set("availability_zone", object.ZoneId)
+	d.Set("name", object.DedicatedHostName)
+	d.Set("description", object.Description)
+	d.Set("action_on_maintenance", object.ActionOnMaintenance)
+	d.Set("auto_placement", object.AutoPlacement)
+	d.Set("auto_release_time", object.AutoReleaseTime)
+	d.Set("resource_group_id", object.ResourceGroupId)
+	d.Set("status", object.Status)
+
+	tags, err := ecsService.DescribeTags(d.Id(), TagResourceDedicatedHost)
+	if err != nil && !NotFoundError(err) {
+		return WrapError(err)
+	}
+	setTagsWithDefaults(client, d, tagsToMap(tags))
+	return nil
+}
+
+func resourceAlicloudEcsDedicatedHostUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+
+	d.Partial(true)
+	if err := setTags(client, TagResourceDedicatedHost, d); err != nil {
+		return WrapError(err)
+	}
+	d.SetPartial("tags")
+
+	if d.IsNewResource() {
+		d.Partial(false)
+		return resourceAlicloudEcsDedicatedHostRead(d, meta)
+	}
+
+	if d.HasChange("name") || d.HasChange("description") || d.HasChange("action_on_maintenance") || d.HasChange("auto_placement") {
+		request := ecs.CreateModifyDedicatedHostAttributeRequest()
+		request.RegionId = client.RegionId
+		request.DedicatedHostId = d.Id()
+		request.DedicatedHostName = d.Get("name").(string)
+		request.Description = d.Get("description").(string)
+		request.ActionOnMaintenance = d.Get("action_on_maintenance").(string)
+		request.AutoPlacement = d.Get("auto_placement").(string)
+		raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.ModifyDedicatedHostAttribute(request)
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw)
+		d.SetPartial("name")
+		d.SetPartial("description")
+		d.SetPartial("action_on_maintenance")
+		d.SetPartial("auto_placement")
+	}
+
+	if d.HasChange("auto_release_time") {
+		request := ecs.CreateModifyDedicatedHostAutoReleaseTimeRequest()
+		request.RegionId = client.RegionId
+		request.DedicatedHostId = d.Id()
+		request.AutoReleaseTime = d.Get("auto_release_time").(string)
+		raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.ModifyDedicatedHostAutoReleaseTime(request)
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw)
+		d.SetPartial("auto_release_time")
+	}
+	d.Partial(false)
+
+	return resourceAlicloudEcsDedicatedHostRead(d, meta)
+}
+
+func resourceAlicloudEcsDedicatedHostDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	request := ecs.CreateReleaseDedicatedHostRequest()
+	request.RegionId = client.RegionId
+	request.DedicatedHostId = d.Id()
+
+	var raw interface{}
+	var err error
+	// The host can be released only after the instances on it have been released
+	err = resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		raw, err = client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.ReleaseDedicatedHost(request)
+		})
+		if err != nil {
+			if IsExceptedErrors(err, []string{"OperationConflict", "ServiceUnavailable", "InternalError", "DependencyViolation.Instance"}) {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		if IsExceptedError(err, DedicatedHostNotFound) {
+			return nil
+		}
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+
+	stateConf := BuildStateConf([]string{string(Available), string(DedicatedHostUnderAssessment)}, []string{}, d.Timeout(schema.TimeoutDelete), 5*time.Second,
+		ecsService.EcsDedicatedHostStateRefreshFunc(d.Id(), []string{}))
+	if _, err = stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+	return nil
+}
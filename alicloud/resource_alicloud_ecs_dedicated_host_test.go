@@ -0,0 +1,95 @@
+package alicloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func TestAccAlicloudEcsDedicatedHostBasic(t *testing.T) {
+	var v *ecs.DedicatedHost
+	resourceId := "alicloud_ecs_dedicated_host.default"
+	rand := acctest.RandInt()
+	name := fmt.Sprintf("tf-testAccEcsDedicatedHost%d", rand)
+	ra := resourceAttrInit(resourceId, map[string]string{
+		"dedicated_host_type":   "ddh.g5",
+		"availability_zone":     CHECKSET,
+		"name":                  name,
+		"description":           name,
+		"action_on_maintenance": CHECKSET,
+		"auto_placement":        CHECKSET,
+		"status":                "Available",
+		"tags.%":                "1",
+		"tags.version":          "1.0",
+	})
+
+	serviceFunc := func() interface{} {
+		return &EcsService{testAccProvider.Meta().(*connectivity.AliyunClient)}
+	}
+	rc := resourceCheckInit(resourceId, &v, serviceFunc)
+
+	rac := resourceAttrCheckInit(rc, ra)
+
+	testAccCheck := rac.resourceAttrMapUpdateSet()
+	testAccConfig := resourceTestAccConfigFunc(resourceId, name, resourceEcsDedicatedHostConfigDependence)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		// module name
+		IDRefreshName: resourceId,
+
+		Providers:    testAccProviders,
+		CheckDestroy: rac.checkResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"dedicated_host_type": "ddh.g5",
+					"name":                "${var.name}",
+					"description":         "${var.name}",
+					"tags": map[string]string{
+						"version": "1.0",
+					},
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(nil),
+				),
+			},
+			{
+				ResourceName:      resourceId,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"name":                  "${var.name}_update",
+					"description":           "${var.name}_update",
+					"action_on_maintenance": "Stop",
+					"auto_placement":        "off",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"name":                  name + "_update",
+						"description":           name + "_update",
+						"action_on_maintenance": "Stop",
+						"auto_placement":        "off",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func resourceEcsDedicatedHostConfigDependence(name string) string {
+	return fmt.Sprintf(`
+variable "name" {
+  default = "%s"
+}
+`, name)
+}
@@ -0,0 +1,399 @@
+package alicloud
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+// resourceAlicloudEcsFleet is an auto provisioning group which launches a mix of spot and pay-as-you-go instances
+// from a launch template, across the instance types and vswitches of its launch template configs.
+func resourceAlicloudEcsFleet() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAlicloudEcsFleetCreate,
+		Read:   resourceAlicloudEcsFleetRead,
+		Update: resourceAlicloudEcsFleetUpdate,
+		Delete: resourceAlicloudEcsFleetDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateStringLengthInRange(2, 128),
+			},
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateStringLengthInRange(2, 256),
+			},
+			"fleet_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "maintain",
+				ValidateFunc: validateAllowedStringValue([]string{"maintain", "request"}),
+			},
+			"launch_template_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"launch_template_version": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+			"launch_template_config": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 20,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"instance_type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"vswitch_id": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"max_price": {
+							Type:     schema.TypeFloat,
+							Optional: true,
+							ForceNew: true,
+							Computed: true,
+						},
+						"weighted_capacity": {
+							Type:     schema.TypeFloat,
+							Optional: true,
+							ForceNew: true,
+							Computed: true,
+						},
+						"priority": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							ForceNew: true,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"total_target_capacity": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validateIntegerInRange(0, 1000),
+			},
+			"spot_target_capacity": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"pay_as_you_go_target_capacity": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"default_target_capacity_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "Spot",
+				ValidateFunc: validateAllowedStringValue([]string{"Spot", "PayAsYouGo"}),
+			},
+			"spot_allocation_strategy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "lowest-price",
+				ValidateFunc: validateAllowedStringValue([]string{"lowest-price", "diversified"}),
+			},
+			"pay_as_you_go_allocation_strategy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "lowest-price",
+				ValidateFunc: validateAllowedStringValue([]string{"lowest-price", "prioritized"}),
+			},
+			"spot_instance_pools_to_use_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				Computed:     true,
+				ValidateFunc: validateIntegerInRange(1, 10),
+			},
+			"spot_instance_interruption_behavior": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "stop",
+				ValidateFunc: validateAllowedStringValue([]string{"stop", "terminate"}),
+			},
+			"excess_capacity_termination_policy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "no-termination",
+				ValidateFunc: validateAllowedStringValue([]string{"no-termination", "termination"}),
+			},
+			"max_spot_price": {
+				Type:     schema.TypeFloat,
+				Optional: true,
+				Computed: true,
+			},
+			"valid_from": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+			"valid_until": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+			"terminate_instances_with_expiration": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"terminate_instances": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"instance_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAlicloudEcsFleetCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	request := ecs.CreateCreateFleetRequest()
+	request.RegionId = client.RegionId
+	request.FleetName = d.Get("name").(string)
+	request.Description = d.Get("description").(string)
+	request.FleetType = d.Get("fleet_type").(string)
+	request.LaunchTemplateId = d.Get("launch_template_id").(string)
+	request.LaunchTemplateVersion = d.Get("launch_template_version").(string)
+	request.TotalTargetCapacity = strconv.Itoa(d.Get("total_target_capacity").(int))
+	if v, ok := d.GetOkExists("spot_target_capacity"); ok {
+		request.SpotTargetCapacity = strconv.Itoa(v.(int))
+	}
+	if v, ok := d.GetOkExists("pay_as_you_go_target_capacity"); ok {
+		request.OnDemandTargetCapacity = strconv.Itoa(v.(int))
+	}
+	request.DefaultTargetCapacityType = d.Get("default_target_capacity_type").(string)
+	request.SpotAllocationStrategy = d.Get("spot_allocation_strategy").(string)
+	request.OnDemandAllocationStrategy = d.Get("pay_as_you_go_allocation_strategy").(string)
+	if v, ok := d.GetOk("spot_instance_pools_to_use_count"); ok {
+		request.SpotInstancePoolsToUseCount = requests.NewInteger(v.(int))
+	}
+	request.SpotInstanceInterruptionBehavior = d.Get("spot_instance_interruption_behavior").(string)
+	request.ExcessCapacityTerminationPolicy = d.Get("excess_capacity_termination_policy").(string)
+	if v, ok := d.GetOk("max_spot_price"); ok {
+		request.MaxSpotPrice = requests.NewFloat(v.(float64))
+	}
+	request.ValidFrom = d.Get("valid_from").(string)
+	request.ValidUntil = d.Get("valid_until").(string)
+	request.TerminateInstancesWithExpiration = requests.NewBoolean(d.Get("terminate_instances_with_expiration").(bool))
+
+	var configs []ecs.CreateFleetLaunchTemplateConfig
+	for _, raw := range d.Get("launch_template_config").([]interface{}) {
+		item := raw.(map[string]interface{})
+		config := ecs.CreateFleetLaunchTemplateConfig{
+			InstanceType: item["instance_type"].(string),
+			VSwitchId:    item["vswitch_id"].(string),
+		}
+		if v := item["max_price"].(float64); v > 0 {
+			config.MaxPrice = strconv.FormatFloat(v, 'f', -1, 64)
+		}
+		if v := item["weighted_capacity"].(float64); v > 0 {
+			config.WeightedCapacity = strconv.FormatFloat(v, 'f', -1, 64)
+		}
+		if v := item["priority"].(int); v > 0 {
+			config.Priority = strconv.Itoa(v)
+		}
+		configs = append(configs, config)
+	}
+	if len(configs) > 0 {
+		request.LaunchTemplateConfig = &configs
+	}
+
+	raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.CreateFleet(request)
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, "alicloud_ecs_fleet", request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+	response := raw.(*ecs.CreateFleetResponse)
+	d.SetId(response.FleetId)
+
+	stateConf := BuildStateConf([]string{"", string(FleetSubmitted)}, []string{string(FleetActive)}, d.Timeout(schema.TimeoutCreate), 5*time.Second,
+		ecsService.EcsFleetStateRefreshFunc(d.Id(), []string{string(FleetError)}))
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+
+	return resourceAlicloudEcsFleetRead(d, meta)
+}
+
+func resourceAlicloudEcsFleetRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+	object, err := ecsService.DescribeEcsFleet(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	d.Set("name", object.FleetName)
+	d.Set("fleet_type", object.FleetType)
+	d.Set("launch_template_id", object.LaunchTemplateId)
+	d.Set("launch_template_version", object.LaunchTemplateVersion)
+	d.Set("total_target_capacity", int(object.TargetCapacitySpecification.TotalTargetCapacity))
+	d.Set("spot_target_capacity", int(object.TargetCapacitySpecification.SpotTargetCapacity))
+	d.Set("pay_as_you_go_target_capacity", int(object.TargetCapacitySpecification.OnDemandTargetCapacity))
+	d.Set("default_target_capacity_type", object.TargetCapacitySpecification.DefaultTargetCapacityType)
+	d.Set("spot_allocation_strategy", object.SpotOptions.AllocationStrategy)
+	d.Set("pay_as_you_go_allocation_strategy", object.OnDemandOptions.AllocationStrategy)
+	d.Set("spot_instance_pools_to_use_count", object.SpotOptions.InstancePoolsToUseCount)
+	d.Set("spot_instance_interruption_behavior", object.SpotOptions.InstanceInterruptionBehavior)
+	d.Set("excess_capacity_termination_policy", object.ExcessCapacityTerminationPolicy)
+	d.Set("max_spot_price", object.MaxSpotPrice)
+	d.Set("valid_from", object.ValidFrom)
+	d.Set("valid_until", object.ValidUntil)
+	d.Set("terminate_instances_with_expiration", object.TerminateInstancesWithExpiration)
+	d.Set("status", object.Status)
+
+	var configs []map[string]interface{}
+	for _, config := range object.LaunchTemplateConfigs.LaunchTemplateConfig {
+		configs = append(configs, map[string]interface{}{
+			"instance_type":     config.InstanceType,
+			"vswitch_id":        config.VSWitchId,
+			"max_price":         config.MaxPrice,
+			"weighted_capacity": config.WeightedCapacity,
+			"priority":          int(config.Priority),
+		})
+	}
+	if err := d.Set("launch_template_config", configs); err != nil {
+		return WrapError(err)
+	}
+
+	instances, err := ecsService.DescribeEcsFleetInstances(d.Id())
+	if err != nil {
+		return WrapError(err)
+	}
+	var instanceIds []string
+	for _, instance := range instances {
+		instanceIds = append(instanceIds, instance.InstanceId)
+	}
+	d.Set("instance_ids", instanceIds)
+
+	return nil
+}
+
+func resourceAlicloudEcsFleetUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+
+	request := ecs.CreateModifyFleetRequest()
+	request.RegionId = client.RegionId
+	request.FleetId = d.Id()
+	update := false
+	if d.HasChange("total_target_capacity") {
+		request.TotalTargetCapacity = strconv.Itoa(d.Get("total_target_capacity").(int))
+		update = true
+	}
+	if d.HasChange("spot_target_capacity") {
+		request.SpotTargetCapacity = strconv.Itoa(d.Get("spot_target_capacity").(int))
+		update = true
+	}
+	if d.HasChange("pay_as_you_go_target_capacity") {
+		request.OnDemandTargetCapacity = strconv.Itoa(d.Get("pay_as_you_go_target_capacity").(int))
+		update = true
+	}
+	if d.HasChange("default_target_capacity_type") {
+		request.DefaultTargetCapacityType = d.Get("default_target_capacity_type").(string)
+		update = true
+	}
+	if d.HasChange("excess_capacity_termination_policy") {
+		request.ExcessCapacityTerminationPolicy = d.Get("excess_capacity_termination_policy").(string)
+		update = true
+	}
+	if d.HasChange("max_spot_price") {
+		request.MaxSpotPrice = requests.NewFloat(d.Get("max_spot_price").(float64))
+		update = true
+	}
+	if d.HasChange("terminate_instances_with_expiration") {
+		request.TerminateInstancesWithExpiration = requests.NewBoolean(d.Get("terminate_instances_with_expiration").(bool))
+		update = true
+	}
+	if update {
+		raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.ModifyFleet(request)
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw)
+	}
+	return resourceAlicloudEcsFleetRead(d, meta)
+}
+
+func resourceAlicloudEcsFleetDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	request := ecs.CreateDeleteFleetRequest()
+	request.RegionId = client.RegionId
+	request.FleetId = d.Id()
+	request.TerminateInstances = requests.NewBoolean(d.Get("terminate_instances").(bool))
+	raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.DeleteFleet(request)
+	})
+	if err != nil {
+		if IsExceptedError(err, FleetNotFound) {
+			return nil
+		}
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+
+	stateConf := BuildStateConf([]string{string(FleetSubmitted), string(FleetActive), string(FleetError)}, []string{}, d.Timeout(schema.TimeoutDelete), 5*time.Second,
+		ecsService.EcsFleetStateRefreshFunc(d.Id(), []string{}))
+	if _, err = stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+	return nil
+}
@@ -0,0 +1,308 @@
+package alicloud
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func TestAccAlicloudEcsFleetBasic(t *testing.T) {
+	var v *ecs.Fleet
+	resourceId := "alicloud_ecs_fleet.default"
+	rand := acctest.RandIntRange(10000, 99999)
+	name := fmt.Sprintf("tf-testAccEcsFleet%d", rand)
+	ra := resourceAttrInit(resourceId, map[string]string{
+		"name":                               name,
+		"fleet_type":                         "maintain",
+		"launch_template_id":                 CHECKSET,
+		"launch_template_config.#":           "2",
+		"total_target_capacity":              "2",
+		"spot_target_capacity":               "1",
+		"pay_as_you_go_target_capacity":      "1",
+		"default_target_capacity_type":       "Spot",
+		"spot_allocation_strategy":           "diversified",
+		"excess_capacity_termination_policy": "no-termination",
+		"status":                             string(FleetActive),
+	})
+
+	serviceFunc := func() interface{} {
+		return &EcsService{testAccProvider.Meta().(*connectivity.AliyunClient)}
+	}
+	rc := resourceCheckInit(resourceId, &v, serviceFunc)
+
+	rac := resourceAttrCheckInit(rc, ra)
+
+	testAccCheck := rac.resourceAttrMapUpdateSet()
+	testAccConfig := resourceTestAccConfigFunc(resourceId, name, resourceEcsFleetConfigDependence)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		// module name
+		IDRefreshName: resourceId,
+
+		Providers:    testAccProviders,
+		CheckDestroy: rac.checkResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"name":               "${var.name}",
+					"launch_template_id": "${alicloud_launch_template.default.id}",
+					"launch_template_config": []map[string]interface{}{
+						{
+							"instance_type": "${data.alicloud_instance_types.default.instance_types.0.id}",
+							"vswitch_id":    "${alicloud_vswitch.default.id}",
+						},
+						{
+							"instance_type": "${data.alicloud_instance_types.default.instance_types.1.id}",
+							"vswitch_id":    "${alicloud_vswitch.default.id}",
+						},
+					},
+					"total_target_capacity":         "2",
+					"spot_target_capacity":          "1",
+					"pay_as_you_go_target_capacity": "1",
+					"spot_allocation_strategy":      "diversified",
+					"terminate_instances":           "true",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(nil),
+				),
+			},
+			{
+				ResourceName:            resourceId,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"terminate_instances", "instance_ids"},
+			},
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"total_target_capacity": "3",
+					"spot_target_capacity":  "2",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"total_target_capacity": "3",
+						"spot_target_capacity":  "2",
+					}),
+				),
+			},
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"excess_capacity_termination_policy": "termination",
+					"default_target_capacity_type":       "PayAsYouGo",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"excess_capacity_termination_policy": "termination",
+						"default_target_capacity_type":       "PayAsYouGo",
+					}),
+				),
+			},
+		},
+	})
+}
+
+// TestUnitAlicloudEcsFleet checks the launch template configs and the capacity split are sent to the API and
+// the capacity is modified in place, against the local fake cloud.
+func TestUnitAlicloudEcsFleet(t *testing.T) {
+	fc := newFakeCloud(t, connectivity.ECSCode)
+	defer fc.Close()
+
+	fleet := map[string]interface{}{}
+	capacity := func(request *fakeCloudRequest, key string) int {
+		value, _ := strconv.Atoi(request.Param(key))
+		return value
+	}
+	fc.HandleRPC(connectivity.ECSCode, "CreateFleet", func(request *fakeCloudRequest) (int, interface{}) {
+		var configs []map[string]interface{}
+		for i := 1; request.Param(fmt.Sprintf("LaunchTemplateConfig.%d.InstanceType", i)) != ""; i++ {
+			weight, _ := strconv.ParseFloat(request.Param(fmt.Sprintf("LaunchTemplateConfig.%d.WeightedCapacity", i)), 64)
+			if weight == 0 {
+				weight = 1
+			}
+			configs = append(configs, map[string]interface{}{
+				"InstanceType":     request.Param(fmt.Sprintf("LaunchTemplateConfig.%d.InstanceType", i)),
+				"VSWitchId":        request.Param(fmt.Sprintf("LaunchTemplateConfig.%d.VSwitchId", i)),
+				"WeightedCapacity": weight,
+			})
+		}
+		fleet = map[string]interface{}{
+			"FleetId":                         "fleet-fake0001",
+			"FleetName":                       request.Param("FleetName"),
+			"FleetType":                       request.Param("FleetType"),
+			"Status":                          string(FleetActive),
+			"LaunchTemplateId":                request.Param("LaunchTemplateId"),
+			"LaunchTemplateVersion":           "1",
+			"ExcessCapacityTerminationPolicy": request.Param("ExcessCapacityTerminationPolicy"),
+			"SpotOptions": map[string]interface{}{
+				"AllocationStrategy":           request.Param("SpotAllocationStrategy"),
+				"InstanceInterruptionBehavior": request.Param("SpotInstanceInterruptionBehavior"),
+				"InstancePoolsToUseCount":      2,
+			},
+			"OnDemandOptions": map[string]interface{}{"AllocationStrategy": request.Param("OnDemandAllocationStrategy")},
+			"TargetCapacitySpecification": map[string]interface{}{
+				"TotalTargetCapacity":       capacity(request, "TotalTargetCapacity"),
+				"SpotTargetCapacity":        capacity(request, "SpotTargetCapacity"),
+				"OnDemandTargetCapacity":    capacity(request, "OnDemandTargetCapacity"),
+				"DefaultTargetCapacityType": request.Param("DefaultTargetCapacityType"),
+			},
+			"launchTemplateConfigs": map[string]interface{}{"LaunchTemplateConfig": configs},
+		}
+		return 200, map[string]interface{}{"RequestId": "fake-request", "FleetId": "fleet-fake0001"}
+	})
+	fc.HandleRPC(connectivity.ECSCode, "DescribeFleets", func(request *fakeCloudRequest) (int, interface{}) {
+		return 200, map[string]interface{}{
+			"RequestId": "fake-request",
+			"Fleets":    map[string]interface{}{"Fleet": []map[string]interface{}{fleet}},
+		}
+	})
+	fc.HandleRPC(connectivity.ECSCode, "DescribeFleetInstances", func(request *fakeCloudRequest) (int, interface{}) {
+		return 200, map[string]interface{}{
+			"RequestId": "fake-request",
+			"Instances": map[string]interface{}{
+				"Instance": []map[string]interface{}{{"InstanceId": "i-fake0001"}, {"InstanceId": "i-fake0002"}},
+			},
+		}
+	})
+	fc.HandleRPC(connectivity.ECSCode, "ModifyFleet", func(request *fakeCloudRequest) (int, interface{}) {
+		specification := fleet["TargetCapacitySpecification"].(map[string]interface{})
+		for _, key := range []string{"TotalTargetCapacity", "SpotTargetCapacity", "OnDemandTargetCapacity"} {
+			if request.Param(key) != "" {
+				specification[key] = capacity(request, key)
+			}
+		}
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	})
+	fc.HandleRPC(connectivity.ECSCode, "DeleteFleet", func(request *fakeCloudRequest) (int, interface{}) {
+		fleet["Status"] = "deleted"
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	})
+
+	resourceId := "alicloud_ecs_fleet.default"
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		CheckDestroy: func(*terraform.State) error {
+			if fleet["Status"] != "deleted" {
+				return fmt.Errorf("the fleet is not deleted")
+			}
+			return fc.CheckRequests(connectivity.ECSCode, "DeleteFleet", 1, map[string]string{"TerminateInstances": "true"})(nil)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEcsFleetConfigFake(fc, 4, 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "launch_template_config.#", "2"),
+					resource.TestCheckResourceAttr(resourceId, "launch_template_config.1.vswitch_id", "vsw-fake0002"),
+					resource.TestCheckResourceAttr(resourceId, "launch_template_config.1.weighted_capacity", "2"),
+					resource.TestCheckResourceAttr(resourceId, "pay_as_you_go_target_capacity", "2"),
+					resource.TestCheckResourceAttr(resourceId, "instance_ids.#", "2"),
+					fc.CheckRequests(connectivity.ECSCode, "CreateFleet", 1, map[string]string{
+						"LaunchTemplateConfig.2.InstanceType":     "ecs.c5.large",
+						"LaunchTemplateConfig.2.WeightedCapacity": "2",
+						"SpotAllocationStrategy":                  "diversified",
+						"ExcessCapacityTerminationPolicy":         "termination",
+					}),
+				),
+			},
+			{
+				Config: testAccEcsFleetConfigFake(fc, 6, 4),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "total_target_capacity", "6"),
+					resource.TestCheckResourceAttr(resourceId, "spot_target_capacity", "4"),
+					fc.CheckRequests(connectivity.ECSCode, "ModifyFleet", 1, map[string]string{
+						"TotalTargetCapacity":    "6",
+						"SpotTargetCapacity":     "4",
+						"OnDemandTargetCapacity": "",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccEcsFleetConfigFake(fc *fakeCloud, total, spot int) string {
+	return fmt.Sprintf(`
+%s
+
+resource "alicloud_ecs_fleet" "default" {
+  name                 = "tf-testAccEcsFleet"
+  launch_template_id   = "lt-fake0001"
+  launch_template_config {
+    instance_type = "ecs.g5.large"
+    vswitch_id    = "vsw-fake0001"
+  }
+  launch_template_config {
+    instance_type     = "ecs.c5.large"
+    vswitch_id        = "vsw-fake0002"
+    weighted_capacity = 2
+  }
+  total_target_capacity              = %d
+  spot_target_capacity               = %d
+  pay_as_you_go_target_capacity      = 2
+  spot_allocation_strategy           = "diversified"
+  excess_capacity_termination_policy = "termination"
+  terminate_instances                = true
+}
+`, fc.ProviderConfig(), total, spot)
+}
+
+func resourceEcsFleetConfigDependence(name string) string {
+	return fmt.Sprintf(`
+variable "name" {
+  default = "%s"
+}
+
+data "alicloud_zones" "default" {
+  available_disk_category     = "cloud_efficiency"
+  available_resource_creation = "VSwitch"
+}
+
+data "alicloud_instance_types" "default" {
+  availability_zone = "${data.alicloud_zones.default.zones.0.id}"
+  cpu_core_count    = 2
+  memory_size       = 4
+}
+
+data "alicloud_images" "default" {
+  name_regex  = "^ubuntu_18.*64"
+  most_recent = true
+  owners      = "system"
+}
+
+resource "alicloud_vpc" "default" {
+  name       = "${var.name}"
+  cidr_block = "172.16.0.0/16"
+}
+
+resource "alicloud_vswitch" "default" {
+  vpc_id            = "${alicloud_vpc.default.id}"
+  cidr_block        = "172.16.0.0/24"
+  availability_zone = "${data.alicloud_zones.default.zones.0.id}"
+  name              = "${var.name}"
+}
+
+resource "alicloud_security_group" "default" {
+  name   = "${var.name}"
+  vpc_id = "${alicloud_vpc.default.id}"
+}
+
+resource "alicloud_launch_template" "default" {
+  name                 = "${var.name}"
+  image_id             = "${data.alicloud_images.default.images.0.id}"
+  instance_type        = "${data.alicloud_instance_types.default.instance_types.0.id}"
+  security_group_id    = "${alicloud_security_group.default.id}"
+  vswitch_id           = "${alicloud_vswitch.default.id}"
+  instance_charge_type = "PostPaid"
+  spot_strategy        = "SpotAsPriceGo"
+}
+`, name)
+}
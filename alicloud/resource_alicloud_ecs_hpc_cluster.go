@@ -0,0 +1,110 @@
+package alicloud
+
+import (
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func resourceAlicloudEcsHpcCluster() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAlicloudEcsHpcClusterCreate,
+		Read:   resourceAlicloudEcsHpcClusterRead,
+		Update: resourceAlicloudEcsHpcClusterUpdate,
+		Delete: resourceAlicloudEcsHpcClusterDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateInstanceName,
+			},
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateInstanceDescription,
+			},
+		},
+	}
+}
+
+func resourceAlicloudEcsHpcClusterCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+
+	request := ecs.CreateCreateHpcClusterRequest()
+	request.RegionId = client.RegionId
+	request.ClientToken = buildClientToken(request.GetActionName())
+	request.Name = d.Get("name").(string)
+	request.Description = d.Get("description").(string)
+
+	raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.CreateHpcCluster(request)
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, "alicloud_ecs_hpc_cluster", request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+	response := raw.(*ecs.CreateHpcClusterResponse)
+	d.SetId(response.HpcClusterId)
+
+	return resourceAlicloudEcsHpcClusterRead(d, meta)
+}
+
+func resourceAlicloudEcsHpcClusterRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+	object, err := ecsService.DescribeEcsHpcCluster(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+	d.Set("name", object.Name)
+	d.Set("description", object.Description)
+	return nil
+}
+
+func resourceAlicloudEcsHpcClusterUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	if d.HasChange("name") || d.HasChange("description") {
+		request := ecs.CreateModifyHpcClusterAttributeRequest()
+		request.RegionId = client.RegionId
+		request.HpcClusterId = d.Id()
+		request.Name = d.Get("name").(string)
+		request.Description = d.Get("description").(string)
+		raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.ModifyHpcClusterAttribute(request)
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw)
+	}
+	return resourceAlicloudEcsHpcClusterRead(d, meta)
+}
+
+func resourceAlicloudEcsHpcClusterDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+	request := ecs.CreateDeleteHpcClusterRequest()
+	request.RegionId = client.RegionId
+	request.HpcClusterId = d.Id()
+	raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.DeleteHpcCluster(request)
+	})
+	if err != nil {
+		// The error code of a missing cluster is not documented, so it is looked up again
+		if _, e := ecsService.DescribeEcsHpcCluster(d.Id()); NotFoundError(e) {
+			return nil
+		}
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+	return nil
+}
@@ -0,0 +1,201 @@
+package alicloud
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+// resourceAlicloudEcsInvocation runs a Cloud Assistant command on the instances once. The command is run again when
+// the resource is replaced, like by changing the instances or by a taint.
+func resourceAlicloudEcsInvocation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAlicloudEcsInvocationCreate,
+		Read:   resourceAlicloudEcsInvocationRead,
+		Delete: resourceAlicloudEcsInvocationDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"command_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"instance_ids": {
+				Type:     schema.TypeSet,
+				Required: true,
+				ForceNew: true,
+				MinItems: 1,
+				MaxItems: 50,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"parameters": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"results": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"instance_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"exit_code": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"output": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"finished_time": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceAlicloudEcsInvocationCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	request := ecs.CreateInvokeCommandRequest()
+	request.RegionId = client.RegionId
+	request.CommandId = d.Get("command_id").(string)
+	instanceIds := expandStringList(d.Get("instance_ids").(*schema.Set).List())
+	request.InstanceId = &instanceIds
+	request.Parameters = d.Get("parameters").(map[string]interface{})
+
+	raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.InvokeCommand(request)
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, "alicloud_ecs_invocation", request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+	response := raw.(*ecs.InvokeCommandResponse)
+	d.SetId(response.InvokeId)
+
+	// The invocation fails when it does not finish on any of the instances, which are described by its results
+	stateConf := BuildStateConf([]string{"", string(InvocationRunning)},
+		[]string{string(InvocationFinished), string(InvocationFailed), string(InvocationPartialFailed), string(InvocationStopped)},
+		d.Timeout(schema.TimeoutCreate), 5*time.Second, ecsService.EcsInvocationStateRefreshFunc(d.Id(), []string{}))
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+
+	if err := resourceAlicloudEcsInvocationRead(d, meta); err != nil {
+		return WrapError(err)
+	}
+
+	var failures []string
+	for _, result := range d.Get("results").([]interface{}) {
+		r := result.(map[string]interface{})
+		if r["status"].(string) != string(InvocationFinished) || r["exit_code"].(int) != 0 {
+			failures = append(failures, fmt.Sprintf("%s (status: %s, exit code: %d): %s", r["instance_id"], r["status"], r["exit_code"], r["output"]))
+		}
+	}
+	if len(failures) > 0 {
+		return WrapError(Error("The command %s failed on the instances:\n%s", d.Get("command_id").(string), strings.Join(failures, "\n")))
+	}
+	return nil
+}
+
+func resourceAlicloudEcsInvocationRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+	object, err := ecsService.DescribeEcsInvocation(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+	d.Set("command_id", object.CommandId)
+	d.Set("status", object.InvokeStatus)
+
+	results, err := ecsService.DescribeEcsInvocationResults(d.Id())
+	if err != nil {
+		return WrapError(err)
+	}
+	var instanceIds []string
+	var mappings []map[string]interface{}
+	for _, result := range results {
+		output, err := base64.StdEncoding.DecodeString(result.Output)
+		if err != nil {
+			output = []byte(result.Output)
+		}
+		instanceIds = append(instanceIds, result.InstanceId)
+		mappings = append(mappings, map[string]interface{}{
+			"instance_id":   result.InstanceId,
+			"status":        result.InvokeRecordStatus,
+			"exit_code":     int(result.ExitCode),
+			"output":        string(output),
+			"finished_time": result.FinishedTime,
+		})
+	}
+	if err := d.Set("instance_ids", instanceIds); err != nil {
+		return WrapError(err)
+	}
+	if err := d.Set("results", mappings); err != nil {
+		return WrapError(err)
+	}
+	return nil
+}
+
+// resourceAlicloudEcsInvocationDelete stops the invocation if it is still running. The finished invocations cannot be
+// deleted, so they are only removed from the state.
+func resourceAlicloudEcsInvocationDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+	object, err := ecsService.DescribeEcsInvocation(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			return nil
+		}
+		return WrapError(err)
+	}
+	if object.InvokeStatus != string(InvocationRunning) {
+		return nil
+	}
+
+	request := ecs.CreateStopInvocationRequest()
+	request.RegionId = client.RegionId
+	request.InvokeId = d.Id()
+	raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.StopInvocation(request)
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+	return nil
+}
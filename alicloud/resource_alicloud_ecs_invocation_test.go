@@ -0,0 +1,212 @@
+package alicloud
+
+import (
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func TestAccAlicloudEcsInvocationBasic(t *testing.T) {
+	var v *ecs.Invocation
+	resourceId := "alicloud_ecs_invocation.default"
+	rand := acctest.RandIntRange(1000, 9999)
+	name := fmt.Sprintf("tf-testAccEcsInvocation%d", rand)
+	ra := resourceAttrInit(resourceId, map[string]string{
+		"command_id":            CHECKSET,
+		"instance_ids.#":        "1",
+		"status":                "Finished",
+		"results.#":             "1",
+		"results.0.exit_code":   "0",
+		"results.0.output":      "hello\n",
+		"results.0.instance_id": CHECKSET,
+	})
+
+	serviceFunc := func() interface{} {
+		return &EcsService{testAccProvider.Meta().(*connectivity.AliyunClient)}
+	}
+	rc := resourceCheckInit(resourceId, &v, serviceFunc)
+
+	rac := resourceAttrCheckInit(rc, ra)
+
+	testAccCheck := rac.resourceAttrMapUpdateSet()
+	testAccConfig := resourceTestAccConfigFunc(resourceId, name, resourceEcsInvocationConfigDependence)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		// module name
+		IDRefreshName: resourceId,
+
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"command_id":   "${alicloud_ecs_command.default.id}",
+					"instance_ids": []string{"${alicloud_instance.default.id}"},
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(nil),
+				),
+			},
+		},
+	})
+}
+
+// TestUnitAlicloudEcsInvocationExitCode checks the invocation waits for the results of its instances and fails on a
+// non-zero exit code, against the local fake cloud.
+func TestUnitAlicloudEcsInvocationExitCode(t *testing.T) {
+	fc := newFakeCloud(t, connectivity.ECSCode)
+	defer fc.Close()
+
+	invocations := make(map[string][]string)
+	polls := 0
+	fc.HandleRPC(connectivity.ECSCode, "InvokeCommand", func(request *fakeCloudRequest) (int, interface{}) {
+		id := fmt.Sprintf("t-fake%04d", len(invocations)+1)
+		var instanceIds []string
+		for i := 1; request.Param(fmt.Sprintf("InstanceId.%d", i)) != ""; i++ {
+			instanceIds = append(instanceIds, request.Param(fmt.Sprintf("InstanceId.%d", i)))
+		}
+		invocations[id] = instanceIds
+		return 200, map[string]interface{}{"RequestId": "fake-request", "InvokeId": id}
+	})
+	fc.HandleRPC(connectivity.ECSCode, "DescribeInvocations", func(request *fakeCloudRequest) (int, interface{}) {
+		id := request.Param("InvokeId")
+		status := string(InvocationFinished)
+		// The invocation runs for a while
+		if polls++; polls == 1 {
+			status = string(InvocationRunning)
+		}
+		return 200, map[string]interface{}{
+			"RequestId": "fake-request",
+			"Invocations": map[string]interface{}{"Invocation": []map[string]interface{}{{
+				"InvokeId":     id,
+				"CommandId":    "c-fake0001",
+				"InvokeStatus": status,
+			}}},
+		}
+	})
+	fc.HandleRPC(connectivity.ECSCode, "DescribeInvocationResults", func(request *fakeCloudRequest) (int, interface{}) {
+		var results []map[string]interface{}
+		for _, instanceId := range invocations[request.Param("InvokeId")] {
+			exitCode := 0
+			output := "hello " + instanceId
+			if instanceId == "i-broken" {
+				exitCode = 1
+				output = "no such file"
+			}
+			results = append(results, map[string]interface{}{
+				"InvokeId":           request.Param("InvokeId"),
+				"InstanceId":         instanceId,
+				"InvokeRecordStatus": string(InvocationFinished),
+				"ExitCode":           exitCode,
+				"Output":             base64.StdEncoding.EncodeToString([]byte(output)),
+			})
+		}
+		return 200, map[string]interface{}{
+			"RequestId":  "fake-request",
+			"Invocation": map[string]interface{}{"InvocationResults": map[string]interface{}{"InvocationResult": results}},
+		}
+	})
+
+	resourceId := "alicloud_ecs_invocation.default"
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEcsInvocationConfigFake(fc, "i-fake0001"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "status", string(InvocationFinished)),
+					resource.TestCheckResourceAttr(resourceId, "results.#", "1"),
+					resource.TestCheckResourceAttr(resourceId, "results.0.instance_id", "i-fake0001"),
+					resource.TestCheckResourceAttr(resourceId, "results.0.exit_code", "0"),
+					resource.TestCheckResourceAttr(resourceId, "results.0.output", "hello i-fake0001"),
+					func(*terraform.State) error {
+						requests := fc.Requests(connectivity.ECSCode, "InvokeCommand")
+						if len(requests) != 1 || requests[0].Param("Parameters") != `{"name":"world"}` {
+							return fmt.Errorf("expected the parameters of InvokeCommand to be encoded as JSON, got %q", requests[0].Param("Parameters"))
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config:      testAccEcsInvocationConfigFake(fc, "i-broken"),
+				ExpectError: regexp.MustCompile(`i-broken \(status: Finished, exit code: 1\): no such file`),
+			},
+		},
+	})
+}
+
+func testAccEcsInvocationConfigFake(fc *fakeCloud, instanceId string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "alicloud_ecs_invocation" "default" {
+  command_id   = "c-fake0001"
+  instance_ids = ["%s"]
+  parameters = {
+    name = "world"
+  }
+}
+`, fc.ProviderConfig(), instanceId)
+}
+
+func resourceEcsInvocationConfigDependence(name string) string {
+	return fmt.Sprintf(`
+variable "name" {
+  default = "%s"
+}
+
+data "alicloud_instance_types" "default" {
+  cpu_core_count    = 1
+  memory_size       = 2
+}
+
+resource "alicloud_vpc" "default" {
+  name = "${var.name}"
+  cidr_block = "192.168.0.0/16"
+}
+
+resource "alicloud_vswitch" "default" {
+  name = "${var.name}"
+  cidr_block = "192.168.0.0/24"
+  availability_zone = "${data.alicloud_instance_types.default.instance_types.0.availability_zones.0}"
+  vpc_id = "${alicloud_vpc.default.id}"
+}
+
+resource "alicloud_security_group" "default" {
+  name = "${var.name}"
+  vpc_id = "${alicloud_vpc.default.id}"
+}
+
+data "alicloud_images" "default" {
+  name_regex = "^centos_7"
+  owners = "system"
+}
+
+resource "alicloud_instance" "default" {
+  availability_zone = "${data.alicloud_instance_types.default.instance_types.0.availability_zones.0}"
+  instance_name   = "${var.name}"
+  image_id        = "${data.alicloud_images.default.images.0.id}"
+  instance_type   = "${data.alicloud_instance_types.default.instance_types.0.id}"
+  security_groups = ["${alicloud_security_group.default.id}"]
+  vswitch_id      = "${alicloud_vswitch.default.id}"
+}
+
+resource "alicloud_ecs_command" "default" {
+  name            = "${var.name}"
+  type            = "RunShellScript"
+  command_content = "echo hello"
+}
+`, name)
+}
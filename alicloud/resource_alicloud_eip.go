@@ -20,6 +20,7 @@ func resourceAliyunEip() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: defaultTagsCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -73,6 +74,8 @@ func resourceAliyunEip() *schema.Resource {
 				ForceNew: true,
 				Computed: true,
 			},
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 		},
 	}
 }
@@ -141,11 +144,26 @@ func resourceAliyunEipRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("ip_address", object.IpAddress)
 	d.Set("status", object.Status)
 
+	tagService := TagService{client}
+	tags, err := tagService.ListTags(d.Id(), TagResourceEip)
+	if err != nil {
+		return WrapError(err)
+	}
+	setTagsWithDefaults(client, d, tags)
+
 	return nil
 }
 
 func resourceAliyunEipUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*connectivity.AliyunClient)
+	tagService := TagService{client}
+	d.Partial(true)
+
+	if err := tagService.SetTags(d, TagResourceEip); err != nil {
+		return WrapError(err)
+	}
+	d.SetPartial("tags")
+
 	update := false
 	request := vpc.CreateModifyEipAddressAttributeRequest()
 	request.AllocationId = d.Id()
@@ -170,7 +188,12 @@ func resourceAliyunEipUpdate(d *schema.ResourceData, meta interface{}) error {
 			return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
 		}
 		addDebug(request.GetActionName(), raw)
+		d.SetPartial("bandwidth")
+		d.SetPartial("name")
+		d.SetPartial("description")
 	}
+
+	d.Partial(false)
 	return resourceAliyunEipRead(d, meta)
 }
 
@@ -0,0 +1,229 @@
+package alicloud
+
+import (
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+// resourceAlicloudExpressConnectVirtualBorderRouter routes the traffic between a physical connection of Express Connect
+// and the VPCs which are connected to it through router interfaces.
+func resourceAlicloudExpressConnectVirtualBorderRouter() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAlicloudExpressConnectVirtualBorderRouterCreate,
+		Read:   resourceAlicloudExpressConnectVirtualBorderRouterRead,
+		Update: resourceAlicloudExpressConnectVirtualBorderRouterUpdate,
+		Delete: resourceAlicloudExpressConnectVirtualBorderRouterDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"physical_connection_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"vlan_id": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validateIntegerInRange(1, 2999),
+			},
+			"local_gateway_ip": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"peer_gateway_ip": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"peering_subnet_mask": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"circuit_code": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateStringLengthInRange(2, 128),
+			},
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateStringLengthInRange(2, 256),
+			},
+			"route_table_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"vlan_interface_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"access_point_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAlicloudExpressConnectVirtualBorderRouterCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+
+	request := vpc.CreateCreateVirtualBorderRouterRequest()
+	request.RegionId = client.RegionId
+	request.PhysicalConnectionId = d.Get("physical_connection_id").(string)
+	request.VlanId = requests.NewInteger(d.Get("vlan_id").(int))
+	request.LocalGatewayIp = d.Get("local_gateway_ip").(string)
+	request.PeerGatewayIp = d.Get("peer_gateway_ip").(string)
+	request.PeeringSubnetMask = d.Get("peering_subnet_mask").(string)
+	request.CircuitCode = d.Get("circuit_code").(string)
+	request.Name = d.Get("name").(string)
+	request.Description = d.Get("description").(string)
+	request.ClientToken = buildClientToken(request.GetActionName())
+
+	raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+		return vpcClient.CreateVirtualBorderRouter(request)
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, "alicloud_express_connect_virtual_border_router", request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+	response := raw.(*vpc.CreateVirtualBorderRouterResponse)
+	d.SetId(response.VbrId)
+
+	stateConf := BuildStateConf([]string{"", string(VbrPending)}, []string{string(VbrActive)}, d.Timeout(schema.TimeoutCreate), 3*time.Second,
+		vpcService.ExpressConnectVirtualBorderRouterStateRefreshFunc(d.Id(), []string{string(VbrTerminated)}))
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+
+	return resourceAlicloudExpressConnectVirtualBorderRouterRead(d, meta)
+}
+
+func resourceAlicloudExpressConnectVirtualBorderRouterRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+	object, err := vpcService.DescribeExpressConnectVirtualBorderRouter(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	d.Set("physical_connection_id", object.PhysicalConnectionId)
+	d.Set("vlan_id", object.VlanId)
+	d.Set("local_gateway_ip", object.LocalGatewayIp)
+	d.Set("peer_gateway_ip", object.PeerGatewayIp)
+	d.Set("peering_subnet_mask", object.PeeringSubnetMask)
+	d.Set("circuit_code", object.CircuitCode)
+	d.Set("name", object.Name)
+	d.Set("description", object.Description)
+	d.Set("route_table_id", object.RouteTableId)
+	d.Set("vlan_interface_id", object.VlanInterfaceId)
+	d.Set("access_point_id", object.AccessPointId)
+	d.Set("status", object.Status)
+	return nil
+}
+
+func resourceAlicloudExpressConnectVirtualBorderRouterUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+
+	update := false
+	request := vpc.CreateModifyVirtualBorderRouterAttributeRequest()
+	request.RegionId = client.RegionId
+	request.VbrId = d.Id()
+	request.ClientToken = buildClientToken(request.GetActionName())
+
+	if d.HasChange("vlan_id") {
+		request.VlanId = requests.NewInteger(d.Get("vlan_id").(int))
+		update = true
+	}
+	// The gateway IPs and the subnet mask are validated against each other, so they are sent together
+	if d.HasChange("local_gateway_ip") || d.HasChange("peer_gateway_ip") || d.HasChange("peering_subnet_mask") {
+		request.LocalGatewayIp = d.Get("local_gateway_ip").(string)
+		request.PeerGatewayIp = d.Get("peer_gateway_ip").(string)
+		request.PeeringSubnetMask = d.Get("peering_subnet_mask").(string)
+		update = true
+	}
+	if d.HasChange("circuit_code") {
+		request.CircuitCode = d.Get("circuit_code").(string)
+		update = true
+	}
+	if d.HasChange("name") {
+		request.Name = d.Get("name").(string)
+		update = true
+	}
+	if d.HasChange("description") {
+		request.Description = d.Get("description").(string)
+		update = true
+	}
+
+	if update {
+		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.ModifyVirtualBorderRouterAttribute(request)
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw)
+	}
+
+	return resourceAlicloudExpressConnectVirtualBorderRouterRead(d, meta)
+}
+
+func resourceAlicloudExpressConnectVirtualBorderRouterDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+
+	request := vpc.CreateDeleteVirtualBorderRouterRequest()
+	request.RegionId = client.RegionId
+	request.VbrId = d.Id()
+	request.ClientToken = buildClientToken(request.GetActionName())
+	err := resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.DeleteVirtualBorderRouter(request)
+		})
+		if err != nil {
+			// The BGP groups and the router interfaces of the router are released asynchronously
+			if IsExceptedErrors(err, []string{IncorrectStatus, TaskConflict, Throttling}) {
+				time.Sleep(5 * time.Second)
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw)
+		return nil
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+
+	stateConf := BuildStateConf([]string{string(VbrActive), string(VbrDeleting), string(VbrTerminated)}, []string{}, d.Timeout(schema.TimeoutDelete), 3*time.Second,
+		vpcService.ExpressConnectVirtualBorderRouterStateRefreshFunc(d.Id(), []string{}))
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+	return nil
+}
@@ -0,0 +1,348 @@
+package alicloud
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func TestAccAlicloudExpressConnectVirtualBorderRouterBasic(t *testing.T) {
+	var v *vpc.VirtualBorderRouterType
+	resourceId := "alicloud_express_connect_virtual_border_router.default"
+	rand := acctest.RandIntRange(10000, 99999)
+	name := fmt.Sprintf("tf-testacc-vbr-%d", rand)
+	ra := resourceAttrInit(resourceId, map[string]string{
+		"physical_connection_id": os.Getenv("ALICLOUD_PHYSICAL_CONNECTION_ID"),
+		"vlan_id":                "1001",
+		"local_gateway_ip":       "10.0.0.1",
+		"peer_gateway_ip":        "10.0.0.2",
+		"peering_subnet_mask":    "255.255.255.252",
+		"name":                   name,
+		"route_table_id":         CHECKSET,
+		"vlan_interface_id":      CHECKSET,
+		"access_point_id":        CHECKSET,
+		"status":                 string(VbrActive),
+	})
+
+	serviceFunc := func() interface{} {
+		return &VpcService{testAccProvider.Meta().(*connectivity.AliyunClient)}
+	}
+	rc := resourceCheckInit(resourceId, &v, serviceFunc)
+
+	rac := resourceAttrCheckInit(rc, ra)
+
+	testAccCheck := rac.resourceAttrMapUpdateSet()
+	testAccConfig := resourceTestAccConfigFunc(resourceId, name, resourceExpressConnectVirtualBorderRouterConfigDependence)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckWithPhysicalConnectionSetting(t)
+		},
+
+		// module name
+		IDRefreshName: resourceId,
+
+		Providers:    testAccProviders,
+		CheckDestroy: rac.checkResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"physical_connection_id": os.Getenv("ALICLOUD_PHYSICAL_CONNECTION_ID"),
+					"vlan_id":                "1001",
+					"local_gateway_ip":       "10.0.0.1",
+					"peer_gateway_ip":        "10.0.0.2",
+					"peering_subnet_mask":    "255.255.255.252",
+					"name":                   "${var.name}",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(nil),
+				),
+			},
+			{
+				ResourceName:      resourceId,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"description": "${var.name}",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"description": name,
+					}),
+				),
+			},
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"vlan_id": "1002",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"vlan_id": "1002",
+					}),
+				),
+			},
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"local_gateway_ip": "10.0.1.1",
+					"peer_gateway_ip":  "10.0.1.2",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"local_gateway_ip": "10.0.1.1",
+						"peer_gateway_ip":  "10.0.1.2",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func resourceExpressConnectVirtualBorderRouterConfigDependence(name string) string {
+	return fmt.Sprintf(`
+variable "name" {
+  default = "%s"
+}
+`, name)
+}
+
+// TestUnitAlicloudExpressConnectVirtualBorderRouter builds a virtual border router with a BGP group, peer and network
+// against the local fake cloud, and checks they are changed and released in the order of their dependencies.
+func TestUnitAlicloudExpressConnectVirtualBorderRouter(t *testing.T) {
+	fc := newFakeCloud(t, connectivity.VPCCode)
+	defer fc.Close()
+
+	router := map[string]interface{}{}
+	group := map[string]interface{}{}
+	peer := map[string]interface{}{}
+	var networks []map[string]interface{}
+
+	fc.HandleRPC(connectivity.VPCCode, "CreateVirtualBorderRouter", func(request *fakeCloudRequest) (int, interface{}) {
+		router = map[string]interface{}{
+			"VbrId":                "vbr-fake0001",
+			"PhysicalConnectionId": request.Param("PhysicalConnectionId"),
+			"VlanId":               request.Param("VlanId"),
+			"LocalGatewayIp":       request.Param("LocalGatewayIp"),
+			"PeerGatewayIp":        request.Param("PeerGatewayIp"),
+			"PeeringSubnetMask":    request.Param("PeeringSubnetMask"),
+			"Name":                 request.Param("Name"),
+			"RouteTableId":         "vtb-fake0001",
+			"VlanInterfaceId":      "ri-fake0001",
+			"AccessPointId":        "ap-cn-hangzhou-fake",
+			"Status":               string(VbrActive),
+		}
+		return 200, map[string]interface{}{"RequestId": "fake-request", "VbrId": "vbr-fake0001"}
+	})
+	fc.HandleRPC(connectivity.VPCCode, "DescribeVirtualBorderRouters", func(request *fakeCloudRequest) (int, interface{}) {
+		var found []map[string]interface{}
+		if len(router) > 0 && request.Param("Filter.1.Key") == "VbrId" && request.Param("Filter.1.Value.1") == router["VbrId"] {
+			found = append(found, router)
+		}
+		return 200, map[string]interface{}{
+			"RequestId":              "fake-request",
+			"TotalCount":             len(found),
+			"VirtualBorderRouterSet": map[string]interface{}{"VirtualBorderRouterType": found},
+		}
+	})
+	fc.HandleRPC(connectivity.VPCCode, "ModifyVirtualBorderRouterAttribute", func(request *fakeCloudRequest) (int, interface{}) {
+		for _, key := range []string{"VlanId", "LocalGatewayIp", "PeerGatewayIp", "PeeringSubnetMask", "Name", "Description"} {
+			if value := request.Param(key); value != "" {
+				router[key] = value
+			}
+		}
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	})
+	fc.HandleRPC(connectivity.VPCCode, "DeleteVirtualBorderRouter", func(request *fakeCloudRequest) (int, interface{}) {
+		if len(group) > 0 {
+			return 400, map[string]interface{}{"RequestId": "fake-request", "Code": "DependencyViolation.BgpGroup", "Message": "The router still has BGP groups."}
+		}
+		router = map[string]interface{}{}
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	})
+
+	fc.HandleRPC(connectivity.VPCCode, "CreateBgpGroup", func(request *fakeCloudRequest) (int, interface{}) {
+		group = map[string]interface{}{
+			"BgpGroupId": "bgpg-fake0001",
+			"RouterId":   request.Param("RouterId"),
+			"PeerAsn":    request.Param("PeerAsn"),
+			"AuthKey":    request.Param("AuthKey"),
+			"IsFake":     request.Param("IsFakeAsn"),
+			"Name":       request.Param("Name"),
+			"LocalAsn":   "45104",
+			"Status":     string(Available),
+		}
+		return 200, map[string]interface{}{"RequestId": "fake-request", "BgpGroupId": "bgpg-fake0001"}
+	})
+	fc.HandleRPC(connectivity.VPCCode, "DescribeBgpGroups", func(request *fakeCloudRequest) (int, interface{}) {
+		var found []map[string]interface{}
+		if len(group) > 0 && request.Param("BgpGroupId") == group["BgpGroupId"] {
+			found = append(found, group)
+		}
+		return 200, map[string]interface{}{
+			"RequestId":  "fake-request",
+			"TotalCount": len(found),
+			"BgpGroups":  map[string]interface{}{"BgpGroup": found},
+		}
+	})
+	fc.HandleRPC(connectivity.VPCCode, "ModifyBgpGroupAttribute", func(request *fakeCloudRequest) (int, interface{}) {
+		if value := request.Param("PeerAsn"); value != "" {
+			group["PeerAsn"] = value
+		}
+		if value := request.Param("AuthKey"); value != "" {
+			group["AuthKey"] = value
+		}
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	})
+	fc.HandleRPC(connectivity.VPCCode, "DeleteBgpGroup", func(request *fakeCloudRequest) (int, interface{}) {
+		if len(peer) > 0 {
+			return 400, map[string]interface{}{"RequestId": "fake-request", "Code": "DependencyViolation.BgpPeer", "Message": "The group still has BGP peers."}
+		}
+		group = map[string]interface{}{}
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	})
+
+	fc.HandleRPC(connectivity.VPCCode, "CreateBgpPeer", func(request *fakeCloudRequest) (int, interface{}) {
+		peer = map[string]interface{}{
+			"BgpPeerId":     "bgp-fake0001",
+			"BgpGroupId":    request.Param("BgpGroupId"),
+			"PeerIpAddress": request.Param("PeerIpAddress"),
+			"RouterId":      "vbr-fake0001",
+			"BgpStatus":     "Established",
+			"Status":        string(Available),
+		}
+		return 200, map[string]interface{}{"RequestId": "fake-request", "BgpPeerId": "bgp-fake0001"}
+	})
+	fc.HandleRPC(connectivity.VPCCode, "DescribeBgpPeers", func(request *fakeCloudRequest) (int, interface{}) {
+		var found []map[string]interface{}
+		if len(peer) > 0 && request.Param("BgpPeerId") == peer["BgpPeerId"] {
+			found = append(found, peer)
+		}
+		return 200, map[string]interface{}{
+			"RequestId":  "fake-request",
+			"TotalCount": len(found),
+			"BgpPeers":   map[string]interface{}{"BgpPeer": found},
+		}
+	})
+	fc.HandleRPC(connectivity.VPCCode, "DeleteBgpPeer", func(request *fakeCloudRequest) (int, interface{}) {
+		peer = map[string]interface{}{}
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	})
+
+	fc.HandleRPC(connectivity.VPCCode, "AddBgpNetwork", func(request *fakeCloudRequest) (int, interface{}) {
+		networks = append(networks, map[string]interface{}{
+			"RouterId":     request.Param("RouterId"),
+			"DstCidrBlock": request.Param("DstCidrBlock"),
+			"VpcId":        request.Param("VpcId"),
+		})
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	})
+	fc.HandleRPC(connectivity.VPCCode, "DescribeBgpNetworks", func(request *fakeCloudRequest) (int, interface{}) {
+		var found []map[string]interface{}
+		for _, network := range networks {
+			if network["RouterId"] == request.Param("RouterId") {
+				found = append(found, network)
+			}
+		}
+		return 200, map[string]interface{}{
+			"RequestId":   "fake-request",
+			"TotalCount":  len(found),
+			"BgpNetworks": map[string]interface{}{"BgpNetwork": found},
+		}
+	})
+	fc.HandleRPC(connectivity.VPCCode, "DeleteBgpNetwork", func(request *fakeCloudRequest) (int, interface{}) {
+		var kept []map[string]interface{}
+		for _, network := range networks {
+			if network["RouterId"] != request.Param("RouterId") || network["DstCidrBlock"] != request.Param("DstCidrBlock") {
+				kept = append(kept, network)
+			}
+		}
+		networks = kept
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	})
+
+	resourceId := "alicloud_express_connect_virtual_border_router.default"
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		CheckDestroy: func(*terraform.State) error {
+			if len(router) > 0 || len(group) > 0 || len(peer) > 0 || len(networks) > 0 {
+				return fmt.Errorf("the virtual border router and its BGP group, peer and network are not all deleted")
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExpressConnectVirtualBorderRouterConfigFake(fc, "10.0.0.1", "10.0.0.2", 65001),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "id", "vbr-fake0001"),
+					resource.TestCheckResourceAttr(resourceId, "physical_connection_id", "pc-fake0001"),
+					resource.TestCheckResourceAttr(resourceId, "vlan_id", "1001"),
+					resource.TestCheckResourceAttr(resourceId, "route_table_id", "vtb-fake0001"),
+					resource.TestCheckResourceAttr(resourceId, "status", string(VbrActive)),
+					resource.TestCheckResourceAttr("alicloud_vpc_bgp_group.default", "id", "bgpg-fake0001"),
+					resource.TestCheckResourceAttr("alicloud_vpc_bgp_group.default", "router_id", "vbr-fake0001"),
+					resource.TestCheckResourceAttr("alicloud_vpc_bgp_group.default", "peer_asn", "65001"),
+					resource.TestCheckResourceAttr("alicloud_vpc_bgp_group.default", "local_asn", "45104"),
+					resource.TestCheckResourceAttr("alicloud_vpc_bgp_peer.default", "id", "bgp-fake0001"),
+					resource.TestCheckResourceAttr("alicloud_vpc_bgp_peer.default", "router_id", "vbr-fake0001"),
+					resource.TestCheckResourceAttr("alicloud_vpc_bgp_peer.default", "bgp_status", "Established"),
+					resource.TestCheckResourceAttr("alicloud_vpc_bgp_network.default", "id", "vbr-fake0001:192.168.0.0/24"),
+					fc.CheckRequests(connectivity.VPCCode, "CreateBgpPeer", 1, map[string]string{"PeerIpAddress": "10.0.0.2"}),
+					fc.CheckRequests(connectivity.VPCCode, "AddBgpNetwork", 1, map[string]string{"DstCidrBlock": "192.168.0.0/24"}),
+				),
+			},
+			{
+				Config: testAccExpressConnectVirtualBorderRouterConfigFake(fc, "10.0.1.1", "10.0.1.2", 65002),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "id", "vbr-fake0001"),
+					resource.TestCheckResourceAttr(resourceId, "local_gateway_ip", "10.0.1.1"),
+					resource.TestCheckResourceAttr(resourceId, "peer_gateway_ip", "10.0.1.2"),
+					resource.TestCheckResourceAttr("alicloud_vpc_bgp_group.default", "peer_asn", "65002"),
+					resource.TestCheckResourceAttr("alicloud_vpc_bgp_peer.default", "peer_ip_address", "10.0.1.2"),
+					// The subnet mask is sent with the gateway IPs even though it does not change
+					fc.CheckRequests(connectivity.VPCCode, "ModifyVirtualBorderRouterAttribute", 1, map[string]string{"PeeringSubnetMask": "255.255.255.252"}),
+					fc.CheckRequests(connectivity.VPCCode, "ModifyBgpGroupAttribute", 1, map[string]string{"PeerAsn": "65002"}),
+				),
+			},
+		},
+	})
+}
+
+func testAccExpressConnectVirtualBorderRouterConfigFake(fc *fakeCloud, localGatewayIp, peerGatewayIp string, peerAsn int) string {
+	return fmt.Sprintf(`
+%s
+
+resource "alicloud_express_connect_virtual_border_router" "default" {
+  physical_connection_id = "pc-fake0001"
+  vlan_id                = 1001
+  local_gateway_ip       = "%s"
+  peer_gateway_ip        = "%s"
+  peering_subnet_mask    = "255.255.255.252"
+  name                   = "tf-vbr"
+}
+
+resource "alicloud_vpc_bgp_group" "default" {
+  router_id = "${alicloud_express_connect_virtual_border_router.default.id}"
+  peer_asn  = %d
+  auth_key  = "fake-key"
+}
+
+resource "alicloud_vpc_bgp_peer" "default" {
+  bgp_group_id    = "${alicloud_vpc_bgp_group.default.id}"
+  peer_ip_address = "${alicloud_express_connect_virtual_border_router.default.peer_gateway_ip}"
+}
+
+resource "alicloud_vpc_bgp_network" "default" {
+  router_id      = "${alicloud_express_connect_virtual_border_router.default.id}"
+  dst_cidr_block = "192.168.0.0/24"
+}
+`, fc.ProviderConfig(), localGatewayIp, peerGatewayIp, peerAsn)
+}
@@ -0,0 +1,279 @@
+package alicloud
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func resourceAliyunImage() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAliyunImageCreate,
+		Read:   resourceAliyunImageRead,
+		Update: resourceAliyunImageUpdate,
+		Delete: resourceAliyunImageDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		CustomizeDiff: defaultTagsCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(DefaultTimeout * time.Second),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"instance_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"snapshot_id", "disk_device_mapping"},
+			},
+			"snapshot_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"instance_id", "disk_device_mapping"},
+			},
+			"disk_device_mapping": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"instance_id", "snapshot_id"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"snapshot_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"size": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							ForceNew: true,
+						},
+						"disk_type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.StringInSlice([]string{"system", "data"}, false),
+						},
+						"device": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateInstanceName,
+			},
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateInstanceDescription,
+			},
+			"platform": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"architecture": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"i386", "x86_64"}, false),
+			},
+			"resource_group_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"force": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
+		},
+	}
+}
+
+func resourceAliyunImageCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	request := ecs.CreateCreateImageRequest()
+	request.RegionId = client.RegionId
+	request.ClientToken = buildClientToken(request.GetActionName())
+	request.InstanceId = d.Get("instance_id").(string)
+	request.SnapshotId = d.Get("snapshot_id").(string)
+	request.ImageName = d.Get("name").(string)
+	request.Description = d.Get("description").(string)
+	request.Platform = d.Get("platform").(string)
+	request.Architecture = d.Get("architecture").(string)
+	request.ResourceGroupId = d.Get("resource_group_id").(string)
+	if request.InstanceId == "" && request.SnapshotId == "" && len(d.Get("disk_device_mapping").([]interface{})) == 0 {
+		return WrapError(Error("One of instance_id, snapshot_id and disk_device_mapping must be set."))
+	}
+
+	if v, ok := d.GetOk("disk_device_mapping"); ok {
+		var mappings []ecs.CreateImageDiskDeviceMapping
+		for _, raw := range v.([]interface{}) {
+			mapping := raw.(map[string]interface{})
+			m := ecs.CreateImageDiskDeviceMapping{
+				SnapshotId: mapping["snapshot_id"].(string),
+				DiskType:   mapping["disk_type"].(string),
+				Device:     mapping["device"].(string),
+			}
+			if size := mapping["size"].(int); size > 0 {
+				m.Size = strconv.Itoa(size)
+			}
+			mappings = append(mappings, m)
+		}
+		request.DiskDeviceMapping = &mappings
+	}
+
+	raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.CreateImage(request)
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, "alicloud_image", request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+	response := raw.(*ecs.CreateImageResponse)
+	d.SetId(response.ImageId)
+
+	stateConf := BuildStateConf([]string{string(ImageWaiting), string(ImageCreating)}, []string{string(ImageAvailable)}, d.Timeout(schema.TimeoutCreate), 0,
+		ecsService.ImageStateRefreshFunc(d.Id(), []string{string(ImageCreateFailed), string(ImageUnAvailable)}))
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+
+	return resourceAliyunImageUpdate(d, meta)
+}
+
+func resourceAliyunImageRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+	image, err := ecsService.DescribeImage(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+	d.Set("name", image.ImageName)
+	d.Set("description", image.Description)
+	d.Set("platform", image.Platform)
+	d.Set("architecture", image.Architecture)
+	d.Set("resource_group_id", image.ResourceGroupId)
+
+	tags, err := ecsService.DescribeTags(d.Id(), TagResourceImage)
+	if err != nil && !NotFoundError(err) {
+		return WrapError(err)
+	}
+	setTagsWithDefaults(client, d, tagsToMap(tags))
+
+	return nil
+}
+
+func resourceAliyunImageUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+
+	d.Partial(true)
+	if err := setTags(client, TagResourceImage, d); err != nil {
+		return WrapError(err)
+	}
+	d.SetPartial("tags")
+
+	if err := modifyImageAttribute(client, d); err != nil {
+		return WrapError(err)
+	}
+	d.Partial(false)
+
+	return resourceAliyunImageRead(d, meta)
+}
+
+func resourceAliyunImageDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	return deleteImage(client, d)
+}
+
+// modifyImageAttribute updates the name and description of an image, which are also set by CreateImage.
+func modifyImageAttribute(client *connectivity.AliyunClient, d *schema.ResourceData) error {
+	if d.IsNewResource() || !(d.HasChange("name") || d.HasChange("description")) {
+		return nil
+	}
+	request := ecs.CreateModifyImageAttributeRequest()
+	request.RegionId = client.RegionId
+	request.ImageId = d.Id()
+	request.ImageName = d.Get("name").(string)
+	request.Description = d.Get("description").(string)
+	raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.ModifyImageAttribute(request)
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+	d.SetPartial("name")
+	d.SetPartial("description")
+	return nil
+}
+
+// deleteImage deletes an image and waits for it to disappear. The image which is used by instances is deleted
+// only if force is true.
+func deleteImage(client *connectivity.AliyunClient, d *schema.ResourceData) error {
+	ecsService := EcsService{client}
+
+	request := ecs.CreateDeleteImageRequest()
+	request.RegionId = client.RegionId
+	request.ImageId = d.Id()
+	request.Force = requests.NewBoolean(d.Get("force").(bool))
+
+	var raw interface{}
+	var err error
+	err = resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		raw, err = client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.DeleteImage(request)
+		})
+		if err != nil {
+			if IsExceptedErrors(err, []string{"OperationConflict", "ServiceUnavailable", "InternalError"}) {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		if IsExceptedError(err, ImageNotFound) {
+			return nil
+		}
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+
+	stateConf := BuildStateConf([]string{string(ImageAvailable), string(ImageUnAvailable), string(ImageCreateFailed)}, []string{}, d.Timeout(schema.TimeoutDelete), 0,
+		ecsService.ImageStateRefreshFunc(d.Id(), []string{}))
+	if _, err = stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package alicloud
+
+import (
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+// resourceAliyunImageCopy copies an image of another region to the region of the provider, which the copy belongs to.
+func resourceAliyunImageCopy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAliyunImageCopyCreate,
+		Read:   resourceAliyunImageCopyRead,
+		Update: resourceAliyunImageCopyUpdate,
+		Delete: resourceAliyunImageCopyDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		CustomizeDiff: defaultTagsCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(40 * time.Minute),
+			Delete: schema.DefaultTimeout(DefaultTimeout * time.Second),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"source_image_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"source_region_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateInstanceName,
+			},
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateInstanceDescription,
+			},
+			"encrypted": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+			"kms_key_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"force": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
+		},
+	}
+}
+
+func resourceAliyunImageCopyCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	// CopyImage is called in the region of the source image
+	request := ecs.CreateCopyImageRequest()
+	request.RegionId = d.Get("source_region_id").(string)
+	request.ImageId = d.Get("source_image_id").(string)
+	request.DestinationRegionId = client.RegionId
+	request.DestinationImageName = d.Get("name").(string)
+	request.DestinationDescription = d.Get("description").(string)
+	request.Encrypted = requests.NewBoolean(d.Get("encrypted").(bool))
+	request.KMSKeyId = d.Get("kms_key_id").(string)
+
+	raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.CopyImage(request)
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, "alicloud_image_copy", request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+	response := raw.(*ecs.CopyImageResponse)
+	d.SetId(response.ImageId)
+
+	stateConf := BuildStateConf([]string{string(ImageWaiting), string(ImageCreating)}, []string{string(ImageAvailable)}, d.Timeout(schema.TimeoutCreate), 0,
+		ecsService.ImageStateRefreshFunc(d.Id(), []string{string(ImageCreateFailed), string(ImageUnAvailable)}))
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+
+	return resourceAliyunImageCopyUpdate(d, meta)
+}
+
+func resourceAliyunImageCopyRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+	image, err := ecsService.DescribeImage(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+	d.Set("name", image.ImageName)
+	d.Set("description", image.Description)
+
+	tags, err := ecsService.DescribeTags(d.Id(), TagResourceImage)
+	if err != nil && !NotFoundError(err) {
+		return WrapError(err)
+	}
+	setTagsWithDefaults(client, d, tagsToMap(tags))
+
+	return nil
+}
+
+func resourceAliyunImageCopyUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+
+	d.Partial(true)
+	if err := setTags(client, TagResourceImage, d); err != nil {
+		return WrapError(err)
+	}
+	d.SetPartial("tags")
+
+	if err := modifyImageAttribute(client, d); err != nil {
+		return WrapError(err)
+	}
+	d.Partial(false)
+
+	return resourceAliyunImageCopyRead(d, meta)
+}
+
+func resourceAliyunImageCopyDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	return deleteImage(client, d)
+}
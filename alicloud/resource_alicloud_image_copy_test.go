@@ -0,0 +1,104 @@
+package alicloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func TestAccAlicloudImageCopyBasic(t *testing.T) {
+
+	var v *ecs.Image
+	resourceId := "alicloud_image_copy.default"
+	rand := acctest.RandInt()
+	name := fmt.Sprintf("tf-testAccImageCopyBasic%d", rand)
+	ra := resourceAttrInit(resourceId, map[string]string{
+		"source_image_id":  CHECKSET,
+		"source_region_id": CHECKSET,
+		"name":             name,
+		"description":      name,
+		"encrypted":        "true",
+		"force":            "true",
+		"tags.%":           "1",
+		"tags.version":     "1.0",
+	})
+
+	serviceFunc := func() interface{} {
+		return &EcsService{testAccProvider.Meta().(*connectivity.AliyunClient)}
+	}
+	rc := resourceCheckInitWithDescribeMethod(resourceId, &v, serviceFunc, "DescribeImage")
+
+	rac := resourceAttrCheckInit(rc, ra)
+
+	testAccCheck := rac.resourceAttrMapUpdateSet()
+	testAccConfig := resourceTestAccConfigFunc(resourceId, name, resourceImageCopyConfigDependence)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		// module name
+		IDRefreshName: resourceId,
+
+		Providers:    testAccProviders,
+		CheckDestroy: rac.checkResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				// An encrypted copy can be made in the region of the source image
+				Config: testAccConfig(map[string]interface{}{
+					"source_image_id":  "${alicloud_image.default.id}",
+					"source_region_id": "${data.alicloud_regions.current.ids.0}",
+					"name":             "${var.name}",
+					"description":      "${var.name}",
+					"encrypted":        "true",
+					"force":            "true",
+					"tags": map[string]string{
+						"version": "1.0",
+					},
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(nil),
+				),
+			},
+			{
+				ResourceName:            resourceId,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"source_image_id", "source_region_id", "encrypted", "kms_key_id", "force"},
+			},
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"name":        "${var.name}_update",
+					"description": "${var.name}_update",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"name":        name + "_update",
+						"description": name + "_update",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func resourceImageCopyConfigDependence(name string) string {
+	return fmt.Sprintf(`
+%s
+
+data "alicloud_regions" "current" {
+  current = true
+}
+
+resource "alicloud_image" "default" {
+  instance_id = "${alicloud_instance.default.id}"
+  name        = "${var.name}"
+  force       = true
+}
+`, resourceImageConfigDependence(name))
+}
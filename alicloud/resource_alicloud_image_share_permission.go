@@ -0,0 +1,96 @@
+package alicloud
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func resourceAliyunImageSharePermission() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAliyunImageSharePermissionCreate,
+		Read:   resourceAliyunImageSharePermissionRead,
+		Delete: resourceAliyunImageSharePermissionDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"image_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"account_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceAliyunImageSharePermissionCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	imageId := d.Get("image_id").(string)
+	accountId := d.Get("account_id").(string)
+
+	request := ecs.CreateModifyImageSharePermissionRequest()
+	request.RegionId = client.RegionId
+	request.ImageId = imageId
+	request.AddAccount = &[]string{accountId}
+	raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.ModifyImageSharePermission(request)
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, "alicloud_image_share_permission", request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+	d.SetId(fmt.Sprintf("%s%s%s", imageId, COLON_SEPARATED, accountId))
+
+	return resourceAliyunImageSharePermissionRead(d, meta)
+}
+
+func resourceAliyunImageSharePermissionRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+	if _, err := ecsService.DescribeImageSharePermission(d.Id()); err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+	parts := strings.Split(d.Id(), COLON_SEPARATED)
+	d.Set("image_id", parts[0])
+	d.Set("account_id", parts[1])
+	return nil
+}
+
+func resourceAliyunImageSharePermissionDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	parts := strings.Split(d.Id(), COLON_SEPARATED)
+	if len(parts) != 2 {
+		return WrapError(Error("invalid resource id %s, it should be <image id>:<account id>", d.Id()))
+	}
+
+	request := ecs.CreateModifyImageSharePermissionRequest()
+	request.RegionId = client.RegionId
+	request.ImageId = parts[0]
+	request.RemoveAccount = &[]string{parts[1]}
+	raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.ModifyImageSharePermission(request)
+	})
+	if err != nil {
+		if IsExceptedError(err, ImageNotFound) {
+			return nil
+		}
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+	return nil
+}
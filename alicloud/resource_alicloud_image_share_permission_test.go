@@ -0,0 +1,78 @@
+package alicloud
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func TestAccAlicloudImageSharePermissionBasic(t *testing.T) {
+
+	var v *ecs.Account
+	resourceId := "alicloud_image_share_permission.default"
+	rand := acctest.RandInt()
+	name := fmt.Sprintf("tf-testAccImageSharePermission%d", rand)
+	accountId := os.Getenv("ALICLOUD_ACCOUNT_ID_2")
+	ra := resourceAttrInit(resourceId, map[string]string{
+		"image_id":   CHECKSET,
+		"account_id": accountId,
+	})
+
+	serviceFunc := func() interface{} {
+		return &EcsService{testAccProvider.Meta().(*connectivity.AliyunClient)}
+	}
+	rc := resourceCheckInit(resourceId, &v, serviceFunc)
+
+	rac := resourceAttrCheckInit(rc, ra)
+
+	testAccCheck := rac.resourceAttrMapUpdateSet()
+	testAccConfig := resourceTestAccConfigFunc(resourceId, name, resourceImageSharePermissionConfigDependence)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if accountId == "" {
+				t.Skipf("Skipping the test case because ALICLOUD_ACCOUNT_ID_2 is not set. The image is shared with the account.")
+			}
+		},
+
+		// module name
+		IDRefreshName: resourceId,
+
+		Providers:    testAccProviders,
+		CheckDestroy: rac.checkResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"image_id":   "${alicloud_image.default.id}",
+					"account_id": accountId,
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(nil),
+				),
+			},
+			{
+				ResourceName:      resourceId,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func resourceImageSharePermissionConfigDependence(name string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "alicloud_image" "default" {
+  instance_id = "${alicloud_instance.default.id}"
+  name        = "${var.name}"
+  force       = true
+}
+`, resourceImageConfigDependence(name))
+}
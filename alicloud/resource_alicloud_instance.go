@@ -3,6 +3,7 @@ package alicloud
 import (
 	"fmt"
 	"log"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -29,6 +30,7 @@ func resourceAliyunInstance() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: resourceAliyunInstanceCustomizeDiff,
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
@@ -123,14 +125,23 @@ func resourceAliyunInstance() *schema.Resource {
 				Type:         schema.TypeString,
 				Default:      DiskCloudEfficiency,
 				Optional:     true,
-				ForceNew:     true,
 				ValidateFunc: validateDiskCategory,
 			},
+			"system_disk_performance_level": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateAllowedStringValue([]string{string(DiskPL0), string(DiskPL1), string(DiskPL2), string(DiskPL3)}),
+			},
 			"system_disk_size": {
 				Type:     schema.TypeInt,
 				Optional: true,
 				Default:  40,
 			},
+			"system_disk_auto_snapshot_policy_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
 			"data_disks": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -179,6 +190,10 @@ func resourceAliyunInstance() *schema.Resource {
 							ForceNew:     true,
 							ValidateFunc: validateDiskDescription,
 						},
+						"auto_snapshot_policy_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
 					},
 				},
 			},
@@ -201,6 +216,29 @@ func resourceAliyunInstance() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+			"secondary_private_ips": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"ipv6_addresses": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Computed:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				Set:           schema.HashString,
+				MaxItems:      10,
+				ConflictsWith: []string{"ipv6_address_count"},
+			},
+			"ipv6_address_count": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ValidateFunc:  validateIntegerInRange(0, 10),
+				ConflictsWith: []string{"ipv6_addresses"},
+			},
 
 			"instance_charge_type": {
 				Type:         schema.TypeString,
@@ -258,8 +296,16 @@ func resourceAliyunInstance() *schema.Resource {
 			},
 
 			"status": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateAllowedStringValue([]string{string(Running), string(Stopped)}),
+			},
+
+			"stopped_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateAllowedStringValue([]string{"KeepCharging", "StopCharging"}),
 			},
 
 			"user_data": {
@@ -322,12 +368,45 @@ func resourceAliyunInstance() *schema.Resource {
 				}),
 			},
 
+			"deployment_set_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"dedicated_host_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"hpc_cluster_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
 			"tags":        tagsSchema(),
+			"tags_all":    tagsAllSchema(),
 			"volume_tags": tagsSchemaComputed(),
 		},
 	}
 }
 
+// resourceAliyunInstanceCustomizeDiff replaces the instance when the category of its system disk is changed in a way
+// which cannot be done in place, like a downgrade or a change from cloud, and plans its tags_all by the provider
+// default_tags.
+func resourceAliyunInstanceCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && d.HasChange("system_disk_category") {
+		o, n := d.GetChange("system_disk_category")
+		if !diskCategoryUpgraded(o.(string), n.(string)) {
+			if err := d.ForceNew("system_disk_category"); err != nil {
+				return err
+			}
+		}
+	}
+	return defaultTagsCustomizeDiff(d, meta)
+}
+
 func resourceAliyunInstanceCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*connectivity.AliyunClient)
 	ecsService := EcsService{client}
@@ -410,6 +489,20 @@ func resourceAliyunInstanceRead(d *schema.ResourceData, meta interface{}) error
 	d.Set("instance_type", instance.InstanceType)
 	d.Set("system_disk_category", disk.Category)
 	d.Set("system_disk_size", disk.Size)
+	// The policies are only read back when they are managed here, so that they can be attached by alicloud_snapshot_policy_attachment as well
+	if d.Get("system_disk_auto_snapshot_policy_id").(string) != "" {
+		d.Set("system_disk_auto_snapshot_policy_id", disk.AutoSnapshotPolicyId)
+	}
+	if err := readInstanceDataDiskAutoSnapshotPolicy(d, meta); err != nil {
+		return WrapError(err)
+	}
+	performanceLevel := ""
+	if disk.Category == string(DiskCloudESSD) {
+		if performanceLevel, err = ecsService.DescribeDiskPerformanceLevel(disk.DiskId); err != nil {
+			return WrapError(err)
+		}
+	}
+	d.Set("system_disk_performance_level", performanceLevel)
 	d.Set("password", d.Get("password"))
 	d.Set("internet_max_bandwidth_out", instance.InternetMaxBandwidthOut)
 	d.Set("internet_max_bandwidth_in", instance.InternetMaxBandwidthIn)
@@ -419,6 +512,9 @@ func resourceAliyunInstanceRead(d *schema.ResourceData, meta interface{}) error
 	d.Set("spot_price_limit", instance.SpotPriceLimit)
 	d.Set("internet_charge_type", instance.InternetChargeType)
 	d.Set("deletion_protection", instance.DeletionProtection)
+	d.Set("deployment_set_id", instance.DeploymentSetId)
+	d.Set("dedicated_host_id", instance.DedicatedHostAttribute.DedicatedHostId)
+	d.Set("hpc_cluster_id", instance.HpcClusterId)
 
 	if len(instance.PublicIpAddress.IpAddress) > 0 {
 		d.Set("public_ip", instance.PublicIpAddress.IpAddress[0])
@@ -457,6 +553,24 @@ func resourceAliyunInstanceRead(d *schema.ResourceData, meta interface{}) error
 	d.Set("user_data", userDataHashSum(response.UserData))
 
 	if len(instance.VpcAttributes.VSwitchId) > 0 {
+		eni, err := ecsService.DescribeInstancePrimaryNetworkInterface(d.Id())
+		if err != nil {
+			return WrapError(err)
+		}
+		secondaryPrivateIps := make([]string, 0, len(eni.PrivateIpSets.PrivateIpSet))
+		for _, ip := range eni.PrivateIpSets.PrivateIpSet {
+			if !ip.Primary {
+				secondaryPrivateIps = append(secondaryPrivateIps, ip.PrivateIpAddress)
+			}
+		}
+		d.Set("secondary_private_ips", secondaryPrivateIps)
+		ipv6Addresses := make([]string, 0, len(eni.Ipv6Sets.Ipv6Set))
+		for _, ip := range eni.Ipv6Sets.Ipv6Set {
+			ipv6Addresses = append(ipv6Addresses, ip.Ipv6Address)
+		}
+		d.Set("ipv6_addresses", ipv6Addresses)
+		d.Set("ipv6_address_count", len(ipv6Addresses))
+
 		request := ecs.CreateDescribeInstanceRamRoleRequest()
 		request.InstanceIds = convertListToJsonString([]interface{}{d.Id()})
 		raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
@@ -494,9 +608,7 @@ func resourceAliyunInstanceRead(d *schema.ResourceData, meta interface{}) error
 	if err != nil && !NotFoundError(err) {
 		return WrapError(err)
 	}
-	if len(tags) > 0 {
-		d.Set("tags", tagsToMap(tags))
-	}
+	setTagsWithDefaults(client, d, tagsToMap(tags))
 
 	ids, err := ecsService.QueryInstanceAllDisks(d.Id())
 	if err != nil {
@@ -603,6 +715,10 @@ func resourceAliyunInstanceUpdate(d *schema.ResourceData, meta interface{}) erro
 			stopRequest := ecs.CreateStopInstanceRequest()
 			stopRequest.InstanceId = d.Id()
 			stopRequest.ForceStop = requests.NewBoolean(false)
+			// The instance stays stopped if it is going to be parked
+			if d.Get("status").(string) == string(Stopped) {
+				stopRequest.StoppedMode = d.Get("stopped_mode").(string)
+			}
 			raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
 				return ecsClient.StopInstance(stopRequest)
 			})
@@ -630,48 +746,40 @@ func resourceAliyunInstanceUpdate(d *schema.ResourceData, meta interface{}) erro
 			return WrapError(err)
 		}
 
-		log.Printf("[DEBUG] Start instance after changing image or password or vpc attribute")
-		startRequest := ecs.CreateStartInstanceRequest()
-		startRequest.InstanceId = d.Id()
-
-		err := resource.Retry(5*time.Minute, func() *resource.RetryError {
-			raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
-				return ecsClient.StartInstance(startRequest)
-			})
-			if err != nil {
-				if IsExceptedErrors(err, []string{"IncorrectInstanceStatus"}) {
-					time.Sleep(time.Second)
-					return resource.RetryableError(err)
-				}
-				return resource.NonRetryableError(err)
+		if d.Get("status").(string) != string(Stopped) {
+			log.Printf("[DEBUG] Start instance after changing image or password or vpc attribute")
+			if err := startInstance(client, d.Id()); err != nil {
+				return WrapError(err)
 			}
-			addDebug(startRequest.GetActionName(), raw)
-			return nil
-		})
 
-		if err != nil {
-			return WrapErrorf(err, DefaultErrorMsg, d.Id(), startRequest.GetActionName(), AlibabaCloudSdkGoERROR)
-		}
+			// Start instance sometimes costs more than 8 minutes when os type is centos.
+			stateConf = &resource.StateChangeConf{
+				Pending:    []string{"Pending", "Starting", "Stopped"},
+				Target:     []string{"Running"},
+				Refresh:    ecsService.InstanceStateRefreshFunc(d.Id(), []string{}),
+				Timeout:    d.Timeout(schema.TimeoutUpdate),
+				Delay:      5 * time.Second,
+				MinTimeout: 3 * time.Second,
+			}
 
-		// Start instance sometimes costs more than 8 minutes when os type is centos.
-		stateConf = &resource.StateChangeConf{
-			Pending:    []string{"Pending", "Starting", "Stopped"},
-			Target:     []string{"Running"},
-			Refresh:    ecsService.InstanceStateRefreshFunc(d.Id(), []string{}),
-			Timeout:    d.Timeout(schema.TimeoutUpdate),
-			Delay:      5 * time.Second,
-			MinTimeout: 3 * time.Second,
+			if _, err = stateConf.WaitForState(); err != nil {
+				return WrapErrorf(err, IdMsg, d.Id())
+			}
 		}
+	}
 
-		if _, err = stateConf.WaitForState(); err != nil {
-			return WrapErrorf(err, IdMsg, d.Id())
-		}
+	if err := modifyInstanceSystemDisk(d, meta); err != nil {
+		return WrapError(err)
 	}
 
 	if err := modifyInstanceNetworkSpec(d, meta); err != nil {
 		return WrapError(err)
 	}
 
+	if err := modifyInstanceNetworkInterfaceIps(d, meta); err != nil {
+		return WrapError(err)
+	}
+
 	if d.HasChange("force_delete") {
 		d.SetPartial("force_delete")
 	}
@@ -704,6 +812,18 @@ func resourceAliyunInstanceUpdate(d *schema.ResourceData, meta interface{}) erro
 		d.SetPartial("auto_renew_period")
 	}
 
+	if err := modifyInstanceDeployment(d, meta); err != nil {
+		return WrapError(err)
+	}
+
+	if err := modifyInstanceAutoSnapshotPolicy(d, meta); err != nil {
+		return WrapError(err)
+	}
+
+	if err := modifyInstanceStatus(d, meta); err != nil {
+		return WrapError(err)
+	}
+
 	d.Partial(false)
 	return resourceAliyunInstanceRead(d, meta)
 }
@@ -791,6 +911,10 @@ func buildAliyunInstanceArgs(d *schema.ResourceData, meta interface{}) (*ecs.Run
 
 	request.SystemDiskCategory = string(systemDiskCategory)
 	request.SystemDiskSize = strconv.Itoa(d.Get("system_disk_size").(int))
+	// The performance level of ESSD disks is not supported by the RunInstances request of the ECS SDK yet
+	if v, ok := d.GetOk("system_disk_performance_level"); ok {
+		request.QueryParams["SystemDisk.PerformanceLevel"] = v.(string)
+	}
 
 	sgs, ok := d.GetOk("security_groups")
 
@@ -843,6 +967,12 @@ func buildAliyunInstanceArgs(d *schema.ResourceData, meta interface{}) (*ecs.Run
 		if v, ok := d.GetOk("private_ip"); ok && v.(string) != "" {
 			request.PrivateIpAddress = v.(string)
 		}
+		if v, ok := d.GetOk("ipv6_addresses"); ok {
+			ipv6Addresses := expandStringList(v.(*schema.Set).List())
+			request.Ipv6Address = &ipv6Addresses
+		} else if v, ok := d.GetOk("ipv6_address_count"); ok {
+			request.Ipv6AddressCount = requests.NewInteger(v.(int))
+		}
 	}
 
 	if v := d.Get("instance_charge_type").(string); v != "" {
@@ -877,6 +1007,16 @@ func buildAliyunInstanceArgs(d *schema.ResourceData, meta interface{}) (*ecs.Run
 		value := v.(string)
 		request.SecurityEnhancementStrategy = value
 	}
+
+	if v, ok := d.GetOk("deployment_set_id"); ok {
+		request.DeploymentSetId = v.(string)
+	}
+	if v, ok := d.GetOk("dedicated_host_id"); ok {
+		request.DedicatedHostId = v.(string)
+	}
+	if v, ok := d.GetOk("hpc_cluster_id"); ok {
+		request.HpcClusterId = v.(string)
+	}
 	request.DryRun = requests.NewBoolean(d.Get("dry_run").(bool))
 	request.DeletionProtection = requests.NewBoolean(d.Get("deletion_protection").(bool))
 	request.ClientToken = buildClientToken(request.GetActionName())
@@ -980,7 +1120,7 @@ func modifyInstanceImage(d *schema.ResourceData, meta interface{}, run bool) (bo
 	client := meta.(*connectivity.AliyunClient)
 	ecsService := EcsService{client}
 	update := false
-	if d.HasChange("image_id") || d.HasChange("system_disk_size") {
+	if d.HasChange("image_id") || (d.HasChange("system_disk_size") && !systemDiskExpanded(d)) {
 		update = true
 		if !run {
 			return update, nil
@@ -1048,6 +1188,51 @@ func modifyInstanceImage(d *schema.ResourceData, meta interface{}, run bool) (bo
 	return update, nil
 }
 
+// systemDiskExpanded reports whether the system disk only grows, which is done by resizing the disk instead of replacing it.
+func systemDiskExpanded(d *schema.ResourceData) bool {
+	o, n := d.GetChange("system_disk_size")
+	return !d.HasChange("image_id") && n.(int) > o.(int)
+}
+
+// modifyInstanceSystemDisk expands the system disk and changes its category or performance level in place.
+func modifyInstanceSystemDisk(d *schema.ResourceData, meta interface{}) error {
+	if d.IsNewResource() {
+		return nil
+	}
+	resize := d.HasChange("system_disk_size") && systemDiskExpanded(d)
+	modify := d.HasChange("system_disk_category") || d.HasChange("system_disk_performance_level")
+	if !resize && !modify {
+		return nil
+	}
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+	disk, err := ecsService.QueryInstanceSystemDisk(d.Id())
+	if err != nil {
+		return WrapError(err)
+	}
+
+	if resize {
+		if err := ecsService.ResizeDisk(disk.DiskId, d.Get("system_disk_size").(int)); err != nil {
+			return WrapError(err)
+		}
+		d.SetPartial("system_disk_size")
+	}
+
+	if modify {
+		category := d.Get("system_disk_category").(string)
+		performanceLevel := ""
+		if category == string(DiskCloudESSD) {
+			performanceLevel = d.Get("system_disk_performance_level").(string)
+		}
+		if err := ecsService.ModifyDiskSpec(disk.DiskId, category, performanceLevel); err != nil {
+			return WrapError(err)
+		}
+		d.SetPartial("system_disk_category")
+		d.SetPartial("system_disk_performance_level")
+	}
+	return nil
+}
+
 func modifyInstanceAttribute(d *schema.ResourceData, meta interface{}) (bool, error) {
 	if d.IsNewResource() {
 		return false, nil
@@ -1377,3 +1562,226 @@ func modifyInstanceNetworkSpec(d *schema.ResourceData, meta interface{}) error {
 	}
 	return nil
 }
+
+// modifyInstanceNetworkInterfaceIps changes the secondary private IPs and the IPv6 addresses of the primary ENI of the instance.
+// The IPv6 addresses of a new instance have been assigned by RunInstances.
+func modifyInstanceNetworkInterfaceIps(d *schema.ResourceData, meta interface{}) error {
+	privateIpsChanged := d.HasChange("secondary_private_ips")
+	ipv6Changed := !d.IsNewResource() && (d.HasChange("ipv6_addresses") || d.HasChange("ipv6_address_count"))
+	if !privateIpsChanged && !ipv6Changed {
+		return nil
+	}
+	ecsService := EcsService{meta.(*connectivity.AliyunClient)}
+	eni, err := ecsService.DescribeInstancePrimaryNetworkInterface(d.Id())
+	if err != nil {
+		return WrapError(err)
+	}
+
+	if privateIpsChanged {
+		if err := updateNetworkInterfacePrivateIps(d, "secondary_private_ips", eni.NetworkInterfaceId, meta); err != nil {
+			return WrapError(err)
+		}
+		d.SetPartial("secondary_private_ips")
+	}
+
+	if ipv6Changed {
+		if err := updateNetworkInterfaceIpv6Addresses(d, eni.NetworkInterfaceId, meta); err != nil {
+			return WrapError(err)
+		}
+	}
+	return nil
+}
+
+// modifyInstanceDeployment moves the instance to another deployment set.
+func modifyInstanceDeployment(d *schema.ResourceData, meta interface{}) error {
+	if d.IsNewResource() || !d.HasChange("deployment_set_id") {
+		return nil
+	}
+	client := meta.(*connectivity.AliyunClient)
+	request := ecs.CreateModifyInstanceDeploymentRequest()
+	request.InstanceId = d.Id()
+	request.DeploymentSetId = d.Get("deployment_set_id").(string)
+	raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.ModifyInstanceDeployment(request)
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+	d.SetPartial("deployment_set_id")
+	return nil
+}
+
+// modifyInstanceAutoSnapshotPolicy applies or cancels the automatic snapshot policies of the system disk and the data disks.
+// The policy of the system disk is applied again after the system disk is replaced.
+func modifyInstanceAutoSnapshotPolicy(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	if d.HasChange("system_disk_auto_snapshot_policy_id") || d.HasChange("image_id") || d.HasChange("system_disk_size") {
+		policyId := d.Get("system_disk_auto_snapshot_policy_id").(string)
+		if policyId != "" || (!d.IsNewResource() && d.HasChange("system_disk_auto_snapshot_policy_id")) {
+			disk, err := ecsService.QueryInstanceSystemDisk(d.Id())
+			if err != nil {
+				return WrapError(err)
+			}
+			if policyId != "" && disk.AutoSnapshotPolicyId != policyId {
+				err = ecsService.ApplyAutoSnapshotPolicy(policyId, []string{disk.DiskId})
+			} else if policyId == "" && disk.AutoSnapshotPolicyId != "" {
+				err = ecsService.CancelAutoSnapshotPolicy([]string{disk.DiskId})
+			}
+			if err != nil {
+				return WrapError(err)
+			}
+		}
+		d.SetPartial("system_disk_auto_snapshot_policy_id")
+	}
+
+	if !d.HasChange("data_disks") {
+		return nil
+	}
+	var disks []ecs.Disk
+	for i, v := range d.Get("data_disks").([]interface{}) {
+		key := fmt.Sprintf("data_disks.%d.auto_snapshot_policy_id", i)
+		if !d.HasChange(key) {
+			continue
+		}
+		if disks == nil {
+			dataDisks, err := ecsService.DescribeDisksByType(d.Id(), DiskTypeData)
+			if err != nil {
+				return WrapError(err)
+			}
+			// The data disks are created in the order of the devices
+			sort.Slice(dataDisks, func(i, j int) bool {
+				return dataDisks[i].Device < dataDisks[j].Device
+			})
+			disks = dataDisks
+		}
+		if i >= len(disks) {
+			return WrapError(Error("The data disk %d of the instance %s is not found.", i, d.Id()))
+		}
+		policyId := v.(map[string]interface{})["auto_snapshot_policy_id"].(string)
+		var err error
+		if policyId != "" {
+			err = ecsService.ApplyAutoSnapshotPolicy(policyId, []string{disks[i].DiskId})
+		} else if disks[i].AutoSnapshotPolicyId != "" {
+			err = ecsService.CancelAutoSnapshotPolicy([]string{disks[i].DiskId})
+		}
+		if err != nil {
+			return WrapError(err)
+		}
+	}
+	d.SetPartial("data_disks")
+	return nil
+}
+
+// readInstanceDataDiskAutoSnapshotPolicy refreshes the automatic snapshot policies of the data disks which have one configured.
+func readInstanceDataDiskAutoSnapshotPolicy(d *schema.ResourceData, meta interface{}) error {
+	dataDisks := d.Get("data_disks").([]interface{})
+	managed := false
+	for _, v := range dataDisks {
+		if v.(map[string]interface{})["auto_snapshot_policy_id"].(string) != "" {
+			managed = true
+			break
+		}
+	}
+	if !managed {
+		return nil
+	}
+
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+	disks, err := ecsService.DescribeDisksByType(d.Id(), DiskTypeData)
+	if err != nil {
+		return WrapError(err)
+	}
+	sort.Slice(disks, func(i, j int) bool {
+		return disks[i].Device < disks[j].Device
+	})
+	for i, v := range dataDisks {
+		dataDisk := v.(map[string]interface{})
+		if dataDisk["auto_snapshot_policy_id"].(string) != "" && i < len(disks) {
+			dataDisk["auto_snapshot_policy_id"] = disks[i].AutoSnapshotPolicyId
+		}
+	}
+	return d.Set("data_disks", dataDisks)
+}
+
+// modifyInstanceStatus stops or starts the instance when its status is set to Stopped or Running.
+func modifyInstanceStatus(d *schema.ResourceData, meta interface{}) error {
+	target := d.Get("status").(string)
+	if target == "" || !d.HasChange("status") {
+		return nil
+	}
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+	instance, err := ecsService.DescribeInstance(d.Id())
+	if err != nil {
+		return WrapError(err)
+	}
+	timeout := int(d.Timeout(schema.TimeoutUpdate).Seconds())
+
+	if target == string(Stopped) && instance.Status != string(Stopped) {
+		request := ecs.CreateStopInstanceRequest()
+		request.InstanceId = d.Id()
+		request.ForceStop = requests.NewBoolean(false)
+		request.StoppedMode = d.Get("stopped_mode").(string)
+		err := resource.Retry(5*time.Minute, func() *resource.RetryError {
+			raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+				return ecsClient.StopInstance(request)
+			})
+			if err != nil {
+				if IsExceptedErrors(err, []string{"IncorrectInstanceStatus"}) {
+					time.Sleep(time.Second)
+					return resource.RetryableError(err)
+				}
+				return resource.NonRetryableError(err)
+			}
+			addDebug(request.GetActionName(), raw)
+			return nil
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
+		}
+		if err := ecsService.WaitForEcsInstance(d.Id(), Stopped, timeout); err != nil {
+			return WrapError(err)
+		}
+	}
+
+	if target == string(Running) && instance.Status != string(Running) {
+		if err := startInstance(client, d.Id()); err != nil {
+			return WrapError(err)
+		}
+		if err := ecsService.WaitForEcsInstance(d.Id(), Running, timeout); err != nil {
+			return WrapError(err)
+		}
+	}
+
+	d.SetPartial("status")
+	d.SetPartial("stopped_mode")
+	return nil
+}
+
+// startInstance starts the instance. It is retried while the instance is still stopping.
+func startInstance(client *connectivity.AliyunClient, id string) error {
+	request := ecs.CreateStartInstanceRequest()
+	request.InstanceId = id
+	err := resource.Retry(5*time.Minute, func() *resource.RetryError {
+		raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.StartInstance(request)
+		})
+		if err != nil {
+			if IsExceptedErrors(err, []string{"IncorrectInstanceStatus"}) {
+				time.Sleep(time.Second)
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw)
+		return nil
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	return nil
+}
@@ -1243,6 +1243,141 @@ func TestAccAlicloudInstanceTypeUpdate(t *testing.T) {
 	})
 }
 
+func TestAccAlicloudInstanceStatus(t *testing.T) {
+	var v ecs.Instance
+
+	resourceId := "alicloud_instance.default"
+	ra := resourceAttrInit(resourceId, testAccInstanceCheckMap)
+	serviceFunc := func() interface{} {
+		return &EcsService{testAccProvider.Meta().(*connectivity.AliyunClient)}
+	}
+	rc := resourceCheckInit(resourceId, &v, serviceFunc)
+	rac := resourceAttrCheckInit(rc, ra)
+
+	rand := acctest.RandIntRange(1000, 9999)
+	testAccCheck := rac.resourceAttrMapUpdateSet()
+	name := fmt.Sprintf("tf-testAccEcsInstanceConfigStatus%d", rand)
+	testAccConfig := resourceTestAccConfigFunc(resourceId, name, resourceInstanceVpcConfigDependence)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		CheckDestroy:  rac.checkResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"image_id":        "${data.alicloud_images.default.images.0.id}",
+					"security_groups": []string{"${alicloud_security_group.default.0.id}"},
+					"instance_type":   "${data.alicloud_instance_types.default.instance_types.0.id}",
+					"instance_name":   "${var.name}",
+					"vswitch_id":      "${alicloud_vswitch.default.id}",
+					"status":          "Stopped",
+					"stopped_mode":    "StopCharging",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"status":                        "Stopped",
+						"stopped_mode":                  "StopCharging",
+						"user_data":                     REMOVEKEY,
+						"security_enhancement_strategy": REMOVEKEY,
+					}),
+				),
+			},
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"status": "Running",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"status": "Running",
+					}),
+				),
+			},
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"status":       "Stopped",
+					"stopped_mode": "KeepCharging",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"status":       "Stopped",
+						"stopped_mode": "KeepCharging",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAlicloudInstanceDeploymentSet(t *testing.T) {
+	var v ecs.Instance
+
+	resourceId := "alicloud_instance.default"
+	ra := resourceAttrInit(resourceId, testAccInstanceCheckMap)
+	serviceFunc := func() interface{} {
+		return &EcsService{testAccProvider.Meta().(*connectivity.AliyunClient)}
+	}
+	rc := resourceCheckInit(resourceId, &v, serviceFunc)
+	rac := resourceAttrCheckInit(rc, ra)
+
+	rand := acctest.RandIntRange(1000, 9999)
+	testAccCheck := rac.resourceAttrMapUpdateSet()
+	name := fmt.Sprintf("tf-testAccEcsInstanceConfigDeploymentSet%d", rand)
+	testAccConfig := resourceTestAccConfigFunc(resourceId, name, resourceInstanceDeploymentSetConfigDependence)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		CheckDestroy:  rac.checkResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"image_id":          "${data.alicloud_images.default.images.0.id}",
+					"security_groups":   []string{"${alicloud_security_group.default.0.id}"},
+					"instance_type":     "${data.alicloud_instance_types.default.instance_types.0.id}",
+					"instance_name":     "${var.name}",
+					"vswitch_id":        "${alicloud_vswitch.default.id}",
+					"deployment_set_id": "${alicloud_ecs_deployment_set.default.0.id}",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"deployment_set_id":             CHECKSET,
+						"user_data":                     REMOVEKEY,
+						"security_enhancement_strategy": REMOVEKEY,
+					}),
+				),
+			},
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"deployment_set_id": "${alicloud_ecs_deployment_set.default.1.id}",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"deployment_set_id": CHECKSET,
+					}),
+				),
+			},
+		},
+	})
+}
+
+func resourceInstanceDeploymentSetConfigDependence(name string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "alicloud_ecs_deployment_set" "default" {
+  count = 2
+  name  = "${var.name}-${count.index}"
+}
+`, resourceInstanceVpcConfigDependence(name))
+}
+
 func TestAccAlicloudInstanceSpotInstanceLimit(t *testing.T) {
 	var v ecs.Instance
 
@@ -19,6 +19,7 @@ func resourceAlicloudKmsKey() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: defaultTagsCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"description": {
@@ -57,6 +58,8 @@ func resourceAlicloudKmsKey() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 		},
 	}
 }
@@ -102,6 +105,13 @@ func resourceAlicloudKmsKeyRead(d *schema.ResourceData, meta interface{}) error
 	d.Set("deletion_window_in_days", d.Get("deletion_window_in_days").(int))
 	d.Set("arn", object.KeyMetadata.Arn)
 
+	tagService := TagService{client}
+	tags, err := tagService.ListTags(d.Id(), TagResourceKmsKey)
+	if err != nil {
+		return WrapError(err)
+	}
+	setTagsWithDefaults(client, d, tags)
+
 	return nil
 }
 
@@ -110,6 +120,12 @@ func resourceAlicloudKmsKeyUpdate(d *schema.ResourceData, meta interface{}) erro
 
 	d.Partial(true)
 
+	tagService := TagService{client}
+	if err := tagService.SetTags(d, TagResourceKmsKey); err != nil {
+		return WrapError(err)
+	}
+	d.SetPartial("tags")
+
 	if d.HasChange("is_enabled") {
 		kmsService := &KmsService{client: client}
 		key, err := kmsService.DescribeKmsKey(d.Id())
@@ -25,6 +25,7 @@ func resourceAlicloudKVStoreInstance() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: defaultTagsCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"instance_name": {
@@ -145,6 +146,8 @@ func resourceAlicloudKVStoreInstance() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 		},
 	}
 }
@@ -182,6 +185,12 @@ func resourceAlicloudKVStoreInstanceUpdate(d *schema.ResourceData, meta interfac
 	kvstoreService := KvstoreService{client}
 	d.Partial(true)
 
+	tagService := TagService{client}
+	if err := tagService.SetTags(d, TagResourceKVStoreInstance); err != nil {
+		return WrapError(err)
+	}
+	d.SetPartial("tags")
+
 	if d.HasChange("parameters") {
 		config := make(map[string]interface{})
 		documented := d.Get("parameters").(*schema.Set).List()
@@ -420,6 +429,13 @@ func resourceAlicloudKVStoreInstanceRead(d *schema.ResourceData, meta interface{
 	d.Set("security_ips", strings.Split(object.SecurityIPList, COMMA_SEPARATED))
 	d.Set("vpc_auth_mode", object.VpcAuthMode)
 
+	tagService := TagService{client}
+	tags, err := tagService.ListTags(d.Id(), TagResourceKVStoreInstance)
+	if err != nil {
+		return WrapError(err)
+	}
+	setTagsWithDefaults(client, d, tags)
+
 	if object.ChargeType == string(Prepaid) {
 		request := r_kvstore.CreateDescribeInstanceAutoRenewalAttributeRequest()
 		request.DBInstanceId = d.Id()
@@ -2,11 +2,13 @@ package alicloud
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 
 	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
 )
 
@@ -191,6 +193,42 @@ func resourceAliyunLaunchTemplate() *schema.Resource {
 				Optional: true,
 			},
 
+			"deployment_set_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"dedicated_host_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"hpc_cluster_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"default_version_number": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ValidateFunc:  validation.IntAtLeast(1),
+				ConflictsWith: []string{"update_default_version"},
+			},
+			"latest_version_number": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"update_default_version": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"versions_to_keep": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validateIntegerInRange(1, 30),
+			},
+
 			"network_interfaces": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -266,6 +304,10 @@ func resourceAliyunLaunchTemplate() *schema.Resource {
 func resourceAliyunLaunchTemplateCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*connectivity.AliyunClient)
 
+	if err := launchTemplatePlacementValidation(d, meta); err != nil {
+		return WrapError(err)
+	}
+
 	request := ecs.CreateCreateLaunchTemplateRequest()
 	request.LaunchTemplateName = d.Get("name").(string)
 	request.Description = d.Get("description").(string)
@@ -297,6 +339,7 @@ func resourceAliyunLaunchTemplateCreate(d *schema.ResourceData, meta interface{}
 	request.VSwitchId = d.Get("vswitch_id").(string)
 	request.VpcId = d.Get("vpc_id").(string)
 	request.ZoneId = d.Get("zone_id").(string)
+	setLaunchTemplatePlacement(request.QueryParams, d)
 	netsRaw := d.Get("network_interfaces").([]interface{})
 	if netsRaw != nil {
 		var nets []ecs.CreateLaunchTemplateNetworkInterface
@@ -368,7 +411,7 @@ func resourceAliyunLaunchTemplateRead(d *schema.ResourceData, meta interface{})
 		}
 		return WrapError(err)
 	}
-	latestVersion, err := ecsService.DescribeLaunchTemplateVersion(d.Id(), int(object.LatestVersionNumber))
+	latestVersion, placement, err := ecsService.DescribeLaunchTemplateVersion(d.Id(), int(object.LatestVersionNumber))
 	if err != nil {
 		if NotFoundError(err) {
 			d.SetId("")
@@ -407,6 +450,11 @@ func resourceAliyunLaunchTemplateRead(d *schema.ResourceData, meta interface{})
 	d.Set("vswitch_id", latestVersion.LaunchTemplateData.VSwitchId)
 	d.Set("vpc_id", latestVersion.LaunchTemplateData.VpcId)
 	d.Set("zone_id", latestVersion.LaunchTemplateData.ZoneId)
+	d.Set("deployment_set_id", placement.DeploymentSetId)
+	d.Set("dedicated_host_id", placement.DedicatedHostId)
+	d.Set("hpc_cluster_id", placement.HpcClusterId)
+	d.Set("default_version_number", object.DefaultVersionNumber)
+	d.Set("latest_version_number", object.LatestVersionNumber)
 	var interfaces []map[string]interface{}
 	for _, net := range latestVersion.LaunchTemplateData.NetworkInterfaces.NetworkInterface {
 		ds := make(map[string]interface{})
@@ -447,26 +495,72 @@ func resourceAliyunLaunchTemplateRead(d *schema.ResourceData, meta interface{})
 }
 
 func resourceAliyunLaunchTemplateUpdate(d *schema.ResourceData, meta interface{}) error {
-	versions, err := getLaunchTemplateVersions(d.Id(), meta)
-	if err != nil {
-		return WrapError(err)
+	if launchTemplateDataChanged(d) {
+		if err := launchTemplatePlacementValidation(d, meta); err != nil {
+			return WrapError(err)
+		}
+
+		versions, err := getLaunchTemplateVersions(d.Id(), meta)
+		if err != nil {
+			return WrapError(err)
+		}
+		// Remove one of the oldest and non-default version when the total number reach 30
+		if len(versions) > 29 {
+			var oldestVersion int64
+			for _, version := range versions {
+				if !version.DefaultVersion && (oldestVersion == 0 || version.VersionNumber < oldestVersion) {
+					oldestVersion = version.VersionNumber
+				}
+			}
+
+			err = deleteLaunchTemplateVersion(d.Id(), int(oldestVersion), meta)
+			if err != nil {
+				return WrapError(err)
+			}
+		}
+		version, err := createLaunchTemplateVersion(d, meta)
+		if err != nil {
+			return WrapError(err)
+		}
+		if d.Get("update_default_version").(bool) {
+			if err := modifyLaunchTemplateDefaultVersion(d.Id(), int(version), meta); err != nil {
+				return WrapError(err)
+			}
+		}
 	}
-	// Remove one of the oldest and non-default version when the total number reach 30
-	if len(versions) > 29 {
-		var oldestVersion int64
-		for _, version := range versions {
-			if !version.DefaultVersion && (oldestVersion == 0 || version.VersionNumber < oldestVersion) {
-				oldestVersion = version.VersionNumber
+
+	if d.HasChange("default_version_number") {
+		if version, ok := d.GetOk("default_version_number"); ok {
+			if err := modifyLaunchTemplateDefaultVersion(d.Id(), version.(int), meta); err != nil {
+				return WrapError(err)
 			}
 		}
+	}
 
-		err = deleteLaunchTemplateVersion(d.Id(), int(oldestVersion), meta)
-		if err != nil {
+	if keep, ok := d.GetOk("versions_to_keep"); ok {
+		if err := pruneLaunchTemplateVersions(d.Id(), keep.(int), meta); err != nil {
 			return WrapError(err)
 		}
 	}
-	return WrapError(createLaunchTemplateVersion(d, meta))
 
+	return resourceAliyunLaunchTemplateRead(d, meta)
+}
+
+// launchTemplateVersionKeys are the arguments which manage the versions, and do not create a new version on change.
+var launchTemplateVersionKeys = map[string]bool{
+	"default_version_number": true,
+	"latest_version_number":  true,
+	"update_default_version": true,
+	"versions_to_keep":       true,
+}
+
+func launchTemplateDataChanged(d *schema.ResourceData) bool {
+	for key := range resourceAliyunLaunchTemplate().Schema {
+		if !launchTemplateVersionKeys[key] && d.HasChange(key) {
+			return true
+		}
+	}
+	return false
 }
 
 func resourceAliyunLaunchTemplateDelete(d *schema.ResourceData, meta interface{}) error {
@@ -526,7 +620,7 @@ func deleteLaunchTemplateVersion(id string, version int, meta interface{}) error
 	return nil
 }
 
-func createLaunchTemplateVersion(d *schema.ResourceData, meta interface{}) error {
+func createLaunchTemplateVersion(d *schema.ResourceData, meta interface{}) (int64, error) {
 	client := meta.(*connectivity.AliyunClient)
 	request := ecs.CreateCreateLaunchTemplateVersionRequest()
 	request.LaunchTemplateId = d.Id()
@@ -559,6 +653,7 @@ func createLaunchTemplateVersion(d *schema.ResourceData, meta interface{}) error
 	request.VSwitchId = d.Get("vswitch_id").(string)
 	request.VpcId = d.Get("vpc_id").(string)
 	request.ZoneId = d.Get("zone_id").(string)
+	setLaunchTemplatePlacement(request.QueryParams, d)
 	netsRaw := d.Get("network_interfaces").([]interface{})
 	if netsRaw != nil {
 		var nets []ecs.CreateLaunchTemplateVersionNetworkInterface
@@ -609,9 +704,77 @@ func createLaunchTemplateVersion(d *schema.ResourceData, meta interface{}) error
 		return client.CreateLaunchTemplateVersion(request)
 	})
 	if err != nil {
-		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
+		return 0, WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+	response, _ := raw.(*ecs.CreateLaunchTemplateVersionResponse)
+	return response.LaunchTemplateVersionNumber, nil
+
+}
+
+func modifyLaunchTemplateDefaultVersion(id string, version int, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	request := ecs.CreateModifyLaunchTemplateDefaultVersionRequest()
+	request.RegionId = client.RegionId
+	request.LaunchTemplateId = id
+	request.DefaultVersionNumber = requests.NewInteger(version)
+	raw, err := client.WithEcsClient(func(client *ecs.Client) (interface{}, error) {
+		return client.ModifyLaunchTemplateDefaultVersion(request)
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), AlibabaCloudSdkGoERROR)
 	}
 	addDebug(request.GetActionName(), raw)
 	return nil
+}
 
+// pruneLaunchTemplateVersions deletes the oldest versions until there are no more than keep of them. The default and
+// the latest versions are always kept.
+func pruneLaunchTemplateVersions(id string, keep int, meta interface{}) error {
+	versions, err := getLaunchTemplateVersions(id, meta)
+	if err != nil {
+		return WrapError(err)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].VersionNumber < versions[j].VersionNumber
+	})
+	count := len(versions)
+	for i := 0; i < len(versions)-1 && count > keep; i++ {
+		if versions[i].DefaultVersion {
+			continue
+		}
+		if err := deleteLaunchTemplateVersion(id, int(versions[i].VersionNumber), meta); err != nil {
+			return WrapError(err)
+		}
+		count--
+	}
+	return nil
+}
+
+// setLaunchTemplatePlacement sets the placement of the instances, which the launch template requests of the ECS SDK
+// do not have yet.
+func setLaunchTemplatePlacement(queryParams map[string]string, d *schema.ResourceData) {
+	if v, ok := d.GetOk("deployment_set_id"); ok {
+		queryParams["DeploymentSetId"] = v.(string)
+	}
+	if v, ok := d.GetOk("dedicated_host_id"); ok {
+		queryParams["DedicatedHostId"] = v.(string)
+	}
+	if v, ok := d.GetOk("hpc_cluster_id"); ok {
+		queryParams["HpcClusterId"] = v.(string)
+	}
+}
+
+// launchTemplatePlacementValidation ensures the instance type of the template is available on its dedicated host.
+func launchTemplatePlacementValidation(d *schema.ResourceData, meta interface{}) error {
+	instanceType := d.Get("instance_type").(string)
+	if d.Get("dedicated_host_id").(string) == "" || instanceType == "" {
+		return nil
+	}
+	ecsService := EcsService{meta.(*connectivity.AliyunClient)}
+	zoneId, validZones, err := ecsService.DescribeAvailableResources(d, meta, InstanceTypeResource)
+	if err != nil {
+		return WrapError(err)
+	}
+	return ecsService.InstanceTypeValidation(instanceType, zoneId, validZones)
 }
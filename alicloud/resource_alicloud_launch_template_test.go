@@ -3,6 +3,7 @@ package alicloud
 import (
 	"fmt"
 	"log"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -10,6 +11,7 @@ import (
 
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
 	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
 )
 
@@ -744,6 +746,315 @@ func TestAccAlicloudLaunchTemplateMulti(t *testing.T) {
 		},
 	})
 }
+
+// TestUnitAlicloudLaunchTemplatePlacement checks the placement of the instances, which is not modeled by the ECS SDK, is
+// written to and read from the launch template versions, against the local fake cloud.
+func TestUnitAlicloudLaunchTemplatePlacement(t *testing.T) {
+	fc := newFakeCloud(t, connectivity.ECSCode)
+	defer fc.Close()
+
+	deleted := false
+	var versions []map[string]interface{}
+	createVersion := func(request *fakeCloudRequest) {
+		versions = append(versions, map[string]interface{}{
+			"LaunchTemplateId":   "lt-fake0001",
+			"LaunchTemplateName": "tf-testAccLaunchTemplatePlacement",
+			"VersionNumber":      len(versions) + 1,
+			"DefaultVersion":     len(versions) == 0,
+			"LaunchTemplateData": map[string]interface{}{
+				"InstanceType":    request.Param("InstanceType"),
+				"DeploymentSetId": request.Param("DeploymentSetId"),
+				"DedicatedHostId": request.Param("DedicatedHostId"),
+				"HpcClusterId":    request.Param("HpcClusterId"),
+			},
+		})
+	}
+	fc.HandleRPC(connectivity.ECSCode, "DescribeAvailableResource", func(request *fakeCloudRequest) (int, interface{}) {
+		if request.Param("DedicatedHostId") != "dh-fake0001" {
+			return 400, map[string]interface{}{"RequestId": "fake-request", "Code": "InvalidParameter", "Message": "The dedicated host is missing."}
+		}
+		return 200, map[string]interface{}{
+			"RequestId": "fake-request",
+			"AvailableZones": map[string]interface{}{"AvailableZone": []map[string]interface{}{{
+				"ZoneId": "cn-hangzhou-g",
+				"Status": "Available",
+				"AvailableResources": map[string]interface{}{"AvailableResource": []map[string]interface{}{{
+					"Type": string(InstanceTypeResource),
+					"SupportedResources": map[string]interface{}{"SupportedResource": []map[string]interface{}{{
+						"Value":  "ecs.g5.large",
+						"Status": "Available",
+					}}},
+				}}},
+			}}},
+		}
+	})
+	fc.HandleRPC(connectivity.ECSCode, "CreateLaunchTemplate", func(request *fakeCloudRequest) (int, interface{}) {
+		createVersion(request)
+		return 200, map[string]interface{}{"RequestId": "fake-request", "LaunchTemplateId": "lt-fake0001"}
+	})
+	fc.HandleRPC(connectivity.ECSCode, "CreateLaunchTemplateVersion", func(request *fakeCloudRequest) (int, interface{}) {
+		createVersion(request)
+		return 200, map[string]interface{}{"RequestId": "fake-request", "LaunchTemplateVersionNumber": len(versions)}
+	})
+	fc.HandleRPC(connectivity.ECSCode, "DescribeLaunchTemplates", func(request *fakeCloudRequest) (int, interface{}) {
+		var sets []map[string]interface{}
+		if !deleted {
+			sets = append(sets, map[string]interface{}{
+				"LaunchTemplateId":    "lt-fake0001",
+				"LaunchTemplateName":  "tf-testAccLaunchTemplatePlacement",
+				"LatestVersionNumber": len(versions),
+			})
+		}
+		return 200, map[string]interface{}{"RequestId": "fake-request", "LaunchTemplateSets": map[string]interface{}{"LaunchTemplateSet": sets}}
+	})
+	fc.HandleRPC(connectivity.ECSCode, "DescribeLaunchTemplateVersions", func(request *fakeCloudRequest) (int, interface{}) {
+		sets := versions
+		if v := request.Param("LaunchTemplateVersion.1"); v != "" {
+			sets = nil
+			for _, version := range versions {
+				if fmt.Sprint(version["VersionNumber"]) == v {
+					sets = append(sets, version)
+				}
+			}
+		}
+		return 200, map[string]interface{}{"RequestId": "fake-request", "LaunchTemplateVersionSets": map[string]interface{}{"LaunchTemplateVersionSet": sets}}
+	})
+	fc.HandleRPC(connectivity.ECSCode, "DeleteLaunchTemplate", func(request *fakeCloudRequest) (int, interface{}) {
+		deleted = true
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	})
+
+	resourceId := "alicloud_launch_template.default"
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		CheckDestroy: func(*terraform.State) error {
+			if !deleted {
+				return fmt.Errorf("the launch template lt-fake0001 still exists")
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLaunchTemplatePlacementConfig(fc, "ecs.g5.large", "hpc-fake0001"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "deployment_set_id", "ds-fake0001"),
+					resource.TestCheckResourceAttr(resourceId, "dedicated_host_id", "dh-fake0001"),
+					resource.TestCheckResourceAttr(resourceId, "hpc_cluster_id", "hpc-fake0001"),
+				),
+			},
+			{
+				Config: testAccLaunchTemplatePlacementConfig(fc, "ecs.g5.large", "hpc-fake0002"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "hpc_cluster_id", "hpc-fake0002"),
+					func(*terraform.State) error {
+						if n := len(fc.Requests(connectivity.ECSCode, "CreateLaunchTemplateVersion")); n != 1 {
+							return fmt.Errorf("expected 1 CreateLaunchTemplateVersion request, got %d", n)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config:      testAccLaunchTemplatePlacementConfig(fc, "ecs.g5.xlarge", "hpc-fake0002"),
+				ExpectError: regexp.MustCompile("The instance type ecs.g5.xlarge is solded out or is not supported"),
+			},
+		},
+	})
+}
+
+// TestUnitAlicloudLaunchTemplateVersions checks the default version follows or is pinned to a version, and the oldest
+// versions are pruned, against the local fake cloud.
+func TestUnitAlicloudLaunchTemplateVersionNumbers(t *testing.T) {
+	schemas := resourceAliyunLaunchTemplate().Schema
+	// The version numbers keep growing after the old versions are deleted, so only the number of them is capped
+	cases := []struct {
+		key   string
+		value int
+		valid bool
+	}{
+		{"default_version_number", 1, true},
+		{"default_version_number", 45, true},
+		{"default_version_number", 0, false},
+		{"versions_to_keep", 30, true},
+		{"versions_to_keep", 31, false},
+	}
+	for _, c := range cases {
+		_, errs := schemas[c.key].ValidateFunc(c.value, c.key)
+		if valid := len(errs) == 0; valid != c.valid {
+			t.Errorf("Expected the %s %d to be valid: %t, got the errors %v.", c.key, c.value, c.valid, errs)
+		}
+	}
+}
+
+func TestUnitAlicloudLaunchTemplateVersions(t *testing.T) {
+	fc := newFakeCloud(t, connectivity.ECSCode)
+	defer fc.Close()
+
+	deleted := false
+	defaultVersion := 1
+	var versions []map[string]interface{}
+	createVersion := func(request *fakeCloudRequest) int {
+		number := 1
+		if len(versions) > 0 {
+			number = versions[len(versions)-1]["VersionNumber"].(int) + 1
+		}
+		versions = append(versions, map[string]interface{}{
+			"LaunchTemplateId":   "lt-fake0001",
+			"LaunchTemplateName": "tf-testAccLaunchTemplateVersions",
+			"VersionNumber":      number,
+			"LaunchTemplateData": map[string]interface{}{"InstanceType": request.Param("InstanceType")},
+		})
+		return number
+	}
+	describeVersions := func(filter func(version map[string]interface{}) bool) []map[string]interface{} {
+		var sets []map[string]interface{}
+		for _, version := range versions {
+			version["DefaultVersion"] = version["VersionNumber"] == defaultVersion
+			if filter(version) {
+				sets = append(sets, version)
+			}
+		}
+		return sets
+	}
+	fc.HandleRPC(connectivity.ECSCode, "CreateLaunchTemplate", func(request *fakeCloudRequest) (int, interface{}) {
+		createVersion(request)
+		return 200, map[string]interface{}{"RequestId": "fake-request", "LaunchTemplateId": "lt-fake0001"}
+	})
+	fc.HandleRPC(connectivity.ECSCode, "CreateLaunchTemplateVersion", func(request *fakeCloudRequest) (int, interface{}) {
+		return 200, map[string]interface{}{"RequestId": "fake-request", "LaunchTemplateVersionNumber": createVersion(request)}
+	})
+	fc.HandleRPC(connectivity.ECSCode, "ModifyLaunchTemplateDefaultVersion", func(request *fakeCloudRequest) (int, interface{}) {
+		fmt.Sscan(request.Param("DefaultVersionNumber"), &defaultVersion)
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	})
+	fc.HandleRPC(connectivity.ECSCode, "DeleteLaunchTemplateVersion", func(request *fakeCloudRequest) (int, interface{}) {
+		versions = describeVersions(func(version map[string]interface{}) bool {
+			return fmt.Sprint(version["VersionNumber"]) != request.Param("DeleteVersion.1")
+		})
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	})
+	fc.HandleRPC(connectivity.ECSCode, "DescribeLaunchTemplates", func(request *fakeCloudRequest) (int, interface{}) {
+		var sets []map[string]interface{}
+		if !deleted {
+			sets = append(sets, map[string]interface{}{
+				"LaunchTemplateId":     "lt-fake0001",
+				"LaunchTemplateName":   "tf-testAccLaunchTemplateVersions",
+				"DefaultVersionNumber": defaultVersion,
+				"LatestVersionNumber":  versions[len(versions)-1]["VersionNumber"],
+			})
+		}
+		return 200, map[string]interface{}{"RequestId": "fake-request", "LaunchTemplateSets": map[string]interface{}{"LaunchTemplateSet": sets}}
+	})
+	fc.HandleRPC(connectivity.ECSCode, "DescribeLaunchTemplateVersions", func(request *fakeCloudRequest) (int, interface{}) {
+		sets := describeVersions(func(version map[string]interface{}) bool {
+			if v := request.Param("LaunchTemplateVersion.1"); v != "" && fmt.Sprint(version["VersionNumber"]) != v {
+				return false
+			}
+			if v := request.Param("DefaultVersion"); v != "" && fmt.Sprint(version["DefaultVersion"]) != v {
+				return false
+			}
+			return true
+		})
+		return 200, map[string]interface{}{"RequestId": "fake-request", "LaunchTemplateVersionSets": map[string]interface{}{"LaunchTemplateVersionSet": sets}}
+	})
+	fc.HandleRPC(connectivity.ECSCode, "DeleteLaunchTemplate", func(request *fakeCloudRequest) (int, interface{}) {
+		deleted = true
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	})
+
+	resourceId := "alicloud_launch_template.default"
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		CheckDestroy: func(*terraform.State) error {
+			if !deleted {
+				return fmt.Errorf("the launch template lt-fake0001 still exists")
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLaunchTemplateVersionsConfig(fc, "ecs.g5.large", "update_default_version = true"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "default_version_number", "1"),
+					resource.TestCheckResourceAttr(resourceId, "latest_version_number", "1"),
+				),
+			},
+			{
+				Config: testAccLaunchTemplateVersionsConfig(fc, "ecs.g5.xlarge", "update_default_version = true"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "default_version_number", "2"),
+					resource.TestCheckResourceAttr(resourceId, "latest_version_number", "2"),
+				),
+			},
+			{
+				Config: testAccLaunchTemplateVersionsConfig(fc, "ecs.g5.2xlarge", "update_default_version = true"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "default_version_number", "3"),
+					resource.TestCheckResourceAttr(resourceId, "latest_version_number", "3"),
+					resource.TestCheckResourceAttr("data.alicloud_launch_template_versions.default", "ids.0", "3"),
+					func(*terraform.State) error {
+						requests := fc.Requests(connectivity.ECSCode, "DeleteLaunchTemplateVersion")
+						if len(requests) != 1 || requests[0].Param("DeleteVersion.1") != "1" {
+							return fmt.Errorf("expected the version 1 to be pruned, got %d DeleteLaunchTemplateVersion requests", len(requests))
+						}
+						return nil
+					},
+				),
+			},
+			{
+				// Roll back to the previous version without creating a new one
+				Config: testAccLaunchTemplateVersionsConfig(fc, "ecs.g5.2xlarge", "default_version_number = 2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "default_version_number", "2"),
+					resource.TestCheckResourceAttr(resourceId, "latest_version_number", "3"),
+					resource.TestCheckResourceAttr("data.alicloud_launch_template_versions.default", "versions.0.version_number", "2"),
+					resource.TestCheckResourceAttr("data.alicloud_launch_template_versions.default", "versions.0.instance_type", "ecs.g5.xlarge"),
+					func(*terraform.State) error {
+						if n := len(fc.Requests(connectivity.ECSCode, "CreateLaunchTemplateVersion")); n != 2 {
+							return fmt.Errorf("expected 2 CreateLaunchTemplateVersion requests, got %d", n)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccLaunchTemplateVersionsConfig(fc *fakeCloud, instanceType, defaultVersion string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "alicloud_launch_template" "default" {
+  name             = "tf-testAccLaunchTemplateVersions"
+  instance_type    = "%s"
+  versions_to_keep = 2
+  %s
+}
+
+data "alicloud_launch_template_versions" "default" {
+  launch_template_id = "${alicloud_launch_template.default.id}"
+  default_version    = true
+}
+`, fc.ProviderConfig(), instanceType, defaultVersion)
+}
+
+func testAccLaunchTemplatePlacementConfig(fc *fakeCloud, instanceType, hpcClusterId string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "alicloud_launch_template" "default" {
+  name              = "tf-testAccLaunchTemplatePlacement"
+  instance_type     = "%s"
+  deployment_set_id = "ds-fake0001"
+  dedicated_host_id = "dh-fake0001"
+  hpc_cluster_id    = "%s"
+}
+`, fc.ProviderConfig(), instanceType, hpcClusterId)
+}
+
 func resourceLaunchTemplateConfigDependence(name string) string {
 	return fmt.Sprintf(`
 variable "name" {
@@ -792,6 +1103,8 @@ resource "alicloud_security_group_rule" "default" {
 }
 
 var testAccLaunchTemplateCheckMap = map[string]string{
+	"default_version_number":                 "1",
+	"latest_version_number":                  CHECKSET,
 	"image_id":                               CHECKSET,
 	"instance_charge_type":                   "PrePaid",
 	"instance_type":                          CHECKSET,
@@ -22,6 +22,7 @@ func resourceAlicloudMongoDBInstance() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: defaultTagsCustomizeDiff,
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(30 * time.Minute),
 			Update: schema.DefaultTimeout(30 * time.Minute),
@@ -114,6 +115,8 @@ func resourceAlicloudMongoDBInstance() *schema.Resource {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 		},
 	}
 }
@@ -246,6 +249,13 @@ func resourceAlicloudMongoDBInstanceRead(d *schema.ResourceData, meta interface{
 		d.Set("replication_factor", replication_factor)
 	}
 
+	tagService := TagService{client}
+	tags, err := tagService.ListTags(d.Id(), TagResourceMongoDBInstance)
+	if err != nil {
+		return WrapError(err)
+	}
+	setTagsWithDefaults(client, d, tags)
+
 	return nil
 }
 
@@ -255,6 +265,12 @@ func resourceAlicloudMongoDBInstanceUpdate(d *schema.ResourceData, meta interfac
 
 	d.Partial(true)
 
+	tagService := TagService{client}
+	if err := tagService.SetTags(d, TagResourceMongoDBInstance); err != nil {
+		return WrapError(err)
+	}
+	d.SetPartial("tags")
+
 	if d.HasChange("backup_time") || d.HasChange("backup_period") {
 		if err := ddsService.MotifyMongoDBBackupPolicy(d); err != nil {
 			return WrapError(err)
@@ -23,6 +23,7 @@ func resourceAliyunNatGateway() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: defaultTagsCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"vpc_id": {
@@ -108,6 +109,9 @@ func resourceAliyunNatGateway() *schema.Resource {
 				DiffSuppressFunc: ecsPostPaidDiffSuppressFunc,
 				ValidateFunc:     validateRouterInterfaceChargeTypePeriod,
 			},
+
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 		},
 	}
 }
@@ -176,7 +180,7 @@ func resourceAliyunNatGatewayCreate(d *schema.ResourceData, meta interface{}) er
 	if err := vpcService.WaitForNatGateway(d.Id(), Available, DefaultTimeout); err != nil {
 		return WrapError(err)
 	}
-	return resourceAliyunNatGatewayRead(d, meta)
+	return resourceAliyunNatGatewayUpdate(d, meta)
 }
 
 func resourceAliyunNatGatewayRead(d *schema.ResourceData, meta interface{}) error {
@@ -202,6 +206,13 @@ func resourceAliyunNatGatewayRead(d *schema.ResourceData, meta interface{}) erro
 	d.Set("vpc_id", object.VpcId)
 	d.Set("instance_charge_type", object.InstanceChargeType)
 
+	tagService := TagService{client}
+	tags, err := tagService.ListTags(d.Id(), TagResourceNatGateway)
+	if err != nil {
+		return WrapError(err)
+	}
+	setTagsWithDefaults(client, d, tags)
+
 	bindWidthPackages, err := flattenBandWidthPackages(object.BandwidthPackageIds.BandwidthPackageId, meta, d)
 	if err != nil {
 		return WrapError(err)
@@ -223,6 +234,17 @@ func resourceAliyunNatGatewayUpdate(d *schema.ResourceData, meta interface{}) er
 	}
 
 	d.Partial(true)
+	tagService := TagService{client}
+	if err := tagService.SetTags(d, TagResourceNatGateway); err != nil {
+		return WrapError(err)
+	}
+	d.SetPartial("tags")
+
+	if d.IsNewResource() {
+		d.Partial(false)
+		return resourceAliyunNatGatewayRead(d, meta)
+	}
+
 	attributeUpdate := false
 	modifyNatGatewayAttributeRequest := vpc.CreateModifyNatGatewayAttributeRequest()
 	modifyNatGatewayAttributeRequest.RegionId = natGateway.RegionId
@@ -3,8 +3,6 @@ package alicloud
 import (
 	"time"
 
-	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
-
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
@@ -21,6 +19,7 @@ func resourceAliyunNetworkInterface() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: defaultTagsCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -62,12 +61,29 @@ func resourceAliyunNetworkInterface() *schema.Resource {
 				ValidateFunc:  validateIntegerInRange(0, 10),
 				ConflictsWith: []string{"private_ips"},
 			},
+			"ipv6_addresses": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Computed:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				Set:           schema.HashString,
+				MaxItems:      10,
+				ConflictsWith: []string{"ipv6_address_count"},
+			},
+			"ipv6_address_count": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ValidateFunc:  validateIntegerInRange(0, 10),
+				ConflictsWith: []string{"ipv6_addresses"},
+			},
 			"description": {
 				Type:     schema.TypeString,
 				Optional: true,
 				Default:  "",
 			},
-			"tags": tagsSchema(),
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 		},
 	}
 }
@@ -137,15 +153,19 @@ func resourceAliyunNetworkInterfaceRead(d *schema.ResourceData, meta interface{}
 	}
 	d.Set("private_ips", privateIps)
 	d.Set("private_ips_count", len(privateIps))
+	ipv6Addresses := make([]string, 0, len(object.Ipv6Sets.Ipv6Set))
+	for _, ip := range object.Ipv6Sets.Ipv6Set {
+		ipv6Addresses = append(ipv6Addresses, ip.Ipv6Address)
+	}
+	d.Set("ipv6_addresses", ipv6Addresses)
+	d.Set("ipv6_address_count", len(ipv6Addresses))
 
 	tags, err := ecsService.DescribeTags(d.Id(), TagResourceEni)
 	if err != nil && !NotFoundError(err) {
 		return WrapError(err)
 	}
 
-	if len(tags) > 0 {
-		d.Set("tags", tagsToMap(tags))
-	}
+	setTagsWithDefaults(client, d, tagsToMap(tags))
 
 	return nil
 }
@@ -191,61 +211,9 @@ func resourceAliyunNetworkInterfaceUpdate(d *schema.ResourceData, meta interface
 	}
 
 	if d.HasChange("private_ips") {
-		oldIps, newIps := d.GetChange("private_ips")
-		oldIpsSet := oldIps.(*schema.Set)
-		newIpsSet := newIps.(*schema.Set)
-
-		unAssignIps := oldIpsSet.Difference(newIpsSet)
-		if unAssignIps.Len() > 0 {
-			unAssignIpList := expandStringList(unAssignIps.List())
-			unAssignPrivateIpAddressesRequest := ecs.CreateUnassignPrivateIpAddressesRequest()
-			unAssignPrivateIpAddressesRequest.NetworkInterfaceId = d.Id()
-			unAssignPrivateIpAddressesRequest.PrivateIpAddress = &unAssignIpList
-			err := resource.Retry(DefaultTimeout*time.Second, func() *resource.RetryError {
-				_, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
-					return ecsClient.UnassignPrivateIpAddresses(unAssignPrivateIpAddressesRequest)
-				})
-				if err != nil {
-					if IsExceptedErrors(err, NetworkInterfaceInvalidOperations) {
-						return resource.RetryableError(err)
-					}
-					return resource.NonRetryableError(err)
-				}
-				return nil
-			})
-			if err != nil {
-				return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
-			}
-		}
-
-		assignIps := newIpsSet.Difference(oldIpsSet)
-		if assignIps.Len() > 0 {
-			assignIpList := expandStringList(assignIps.List())
-			assignPrivateIpAddressesRequest := ecs.CreateAssignPrivateIpAddressesRequest()
-			assignPrivateIpAddressesRequest.NetworkInterfaceId = d.Id()
-			assignPrivateIpAddressesRequest.PrivateIpAddress = &assignIpList
-			err := resource.Retry(DefaultTimeout*time.Second, func() *resource.RetryError {
-				_, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
-					return ecsClient.AssignPrivateIpAddresses(assignPrivateIpAddressesRequest)
-				})
-				if err != nil {
-					if IsExceptedErrors(err, NetworkInterfaceInvalidOperations) {
-						return resource.RetryableError(WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR))
-					}
-					return resource.NonRetryableError(WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR))
-				}
-
-				return nil
-			})
-			if err != nil {
-				return err
-			}
-		}
-
-		if err := ecsService.WaitForPrivateIpsListChanged(d.Id(), expandStringList(newIpsSet.List())); err != nil {
+		if err := updateNetworkInterfacePrivateIps(d, "private_ips", d.Id(), meta); err != nil {
 			return WrapError(err)
 		}
-
 		d.SetPartial("private_ips")
 	}
 
@@ -255,48 +223,15 @@ func resourceAliyunNetworkInterfaceUpdate(d *schema.ResourceData, meta interface
 		if oldIpsCount != nil && newIpsCount != nil && newIpsCount != len(privateIpList) {
 			diff := newIpsCount.(int) - oldIpsCount.(int)
 			if diff > 0 {
-				assignPrivateIpAddressesRequest := ecs.CreateAssignPrivateIpAddressesRequest()
-				assignPrivateIpAddressesRequest.NetworkInterfaceId = d.Id()
-				assignPrivateIpAddressesRequest.SecondaryPrivateIpAddressCount = requests.NewInteger(diff)
-				err := resource.Retry(DefaultTimeout*time.Second, func() *resource.RetryError {
-					_, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
-						return ecsClient.AssignPrivateIpAddresses(assignPrivateIpAddressesRequest)
-					})
-
-					if err != nil {
-						if IsExceptedErrors(err, NetworkInterfaceInvalidOperations) {
-							return resource.RetryableError(WrapErrorf(err, DefaultErrorMsg, d.Id(), assignPrivateIpAddressesRequest.GetActionName(), AlibabaCloudSdkGoERROR))
-						}
-						return resource.NonRetryableError(WrapErrorf(err, DefaultErrorMsg, d.Id(), assignPrivateIpAddressesRequest.GetActionName(), AlibabaCloudSdkGoERROR))
-					}
-					return nil
-				})
-				if err != nil {
-					return err
+				if err := ecsService.AssignPrivateIpAddresses(d.Id(), nil, diff); err != nil {
+					return WrapError(err)
 				}
 			}
 
 			if diff < 0 {
 				diff *= -1
-				unAssignIps := privateIpList[:diff]
-				unAssignPrivateIpAddressesRequest := ecs.CreateUnassignPrivateIpAddressesRequest()
-				err := resource.Retry(DefaultTimeout*time.Second, func() *resource.RetryError {
-					unAssignPrivateIpAddressesRequest.NetworkInterfaceId = d.Id()
-					unAssignPrivateIpAddressesRequest.PrivateIpAddress = &unAssignIps
-					raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
-						return ecsClient.UnassignPrivateIpAddresses(unAssignPrivateIpAddressesRequest)
-					})
-					if err != nil {
-						if IsExceptedErrors(err, NetworkInterfaceInvalidOperations) {
-							return resource.RetryableError(err)
-						}
-						return resource.RetryableError(err)
-					}
-					addDebug(unAssignPrivateIpAddressesRequest.GetActionName(), raw)
-					return nil
-				})
-				if err != nil {
-					return WrapErrorf(err, DefaultErrorMsg, d.Id(), unAssignPrivateIpAddressesRequest.GetActionName(), AlibabaCloudSdkGoERROR)
+				if err := ecsService.UnassignPrivateIpAddresses(d.Id(), privateIpList[:diff]); err != nil {
+					return WrapError(err)
 				}
 			}
 
@@ -308,6 +243,10 @@ func resourceAliyunNetworkInterfaceUpdate(d *schema.ResourceData, meta interface
 		}
 	}
 
+	if err := updateNetworkInterfaceIpv6Addresses(d, d.Id(), meta); err != nil {
+		return WrapError(err)
+	}
+
 	if err := setTags(client, TagResourceEni, d); err != nil {
 		return WrapError(err)
 	} else {
@@ -343,3 +282,75 @@ func resourceAliyunNetworkInterfaceDelete(d *schema.ResourceData, meta interface
 	}
 	return WrapError(ecsService.WaitForNetworkInterface(d.Id(), Deleted, DefaultTimeoutMedium))
 }
+
+// updateNetworkInterfacePrivateIps unassigns the secondary private IPs which are removed from the key and assigns the added ones.
+func updateNetworkInterfacePrivateIps(d *schema.ResourceData, key, eniId string, meta interface{}) error {
+	ecsService := EcsService{meta.(*connectivity.AliyunClient)}
+	oldIps, newIps := d.GetChange(key)
+	oldIpsSet := oldIps.(*schema.Set)
+	newIpsSet := newIps.(*schema.Set)
+
+	if unAssignIps := oldIpsSet.Difference(newIpsSet); unAssignIps.Len() > 0 {
+		if err := ecsService.UnassignPrivateIpAddresses(eniId, expandStringList(unAssignIps.List())); err != nil {
+			return WrapError(err)
+		}
+	}
+
+	if assignIps := newIpsSet.Difference(oldIpsSet); assignIps.Len() > 0 {
+		if err := ecsService.AssignPrivateIpAddresses(eniId, expandStringList(assignIps.List()), 0); err != nil {
+			return WrapError(err)
+		}
+	}
+
+	return WrapError(ecsService.WaitForPrivateIpsListChanged(eniId, expandStringList(newIpsSet.List())))
+}
+
+// updateNetworkInterfaceIpv6Addresses changes the IPv6 addresses of the ENI by "ipv6_addresses" and "ipv6_address_count",
+// in the same way as the secondary private IPs.
+func updateNetworkInterfaceIpv6Addresses(d *schema.ResourceData, eniId string, meta interface{}) error {
+	ecsService := EcsService{meta.(*connectivity.AliyunClient)}
+
+	if d.HasChange("ipv6_addresses") {
+		oldIps, newIps := d.GetChange("ipv6_addresses")
+		oldIpsSet := oldIps.(*schema.Set)
+		newIpsSet := newIps.(*schema.Set)
+
+		if unAssignIps := oldIpsSet.Difference(newIpsSet); unAssignIps.Len() > 0 {
+			if err := ecsService.UnassignIpv6Addresses(eniId, expandStringList(unAssignIps.List())); err != nil {
+				return WrapError(err)
+			}
+		}
+		if assignIps := newIpsSet.Difference(oldIpsSet); assignIps.Len() > 0 {
+			if err := ecsService.AssignIpv6Addresses(eniId, expandStringList(assignIps.List()), 0); err != nil {
+				return WrapError(err)
+			}
+		}
+		if err := ecsService.WaitForIpv6AddressesListChanged(eniId, expandStringList(newIpsSet.List())); err != nil {
+			return WrapError(err)
+		}
+		d.SetPartial("ipv6_addresses")
+	}
+
+	if d.HasChange("ipv6_address_count") {
+		ipList := expandStringList(d.Get("ipv6_addresses").(*schema.Set).List())
+		oldCount, newCount := d.GetChange("ipv6_address_count")
+		if newCount.(int) != len(ipList) {
+			diff := newCount.(int) - oldCount.(int)
+			if diff > 0 {
+				if err := ecsService.AssignIpv6Addresses(eniId, nil, diff); err != nil {
+					return WrapError(err)
+				}
+			}
+			if diff < 0 {
+				if err := ecsService.UnassignIpv6Addresses(eniId, ipList[:-diff]); err != nil {
+					return WrapError(err)
+				}
+			}
+			if err := ecsService.WaitForIpv6AddressesCountChanged(eniId, newCount.(int)); err != nil {
+				return WrapError(err)
+			}
+			d.SetPartial("ipv6_address_count")
+		}
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package alicloud
 import (
 	"fmt"
 	"log"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -294,6 +295,116 @@ func TestAccAlicloudNetworkInterfaceMulti(t *testing.T) {
 	})
 }
 
+// TestUnitAlicloudNetworkInterfaceIpv6 checks the IPv6 addresses of an ENI are assigned and unassigned by the changes of
+// ipv6_address_count and ipv6_addresses, against the local fake cloud.
+func TestUnitAlicloudNetworkInterfaceIpv6(t *testing.T) {
+	fc := newFakeCloud(t, connectivity.ECSCode)
+	defer fc.Close()
+
+	var ipv6Addresses []string
+	next := 1
+	status := string(Available)
+	fc.HandleRPC(connectivity.ECSCode, "CreateNetworkInterface", func(request *fakeCloudRequest) (int, interface{}) {
+		return 200, map[string]interface{}{"RequestId": "fake-request", "NetworkInterfaceId": "eni-fake0001"}
+	})
+	fc.HandleRPC(connectivity.ECSCode, "DescribeNetworkInterfaces", func(request *fakeCloudRequest) (int, interface{}) {
+		var sets []map[string]interface{}
+		if status != string(Deleted) {
+			var ipv6Sets []map[string]interface{}
+			for _, ip := range ipv6Addresses {
+				ipv6Sets = append(ipv6Sets, map[string]interface{}{"Ipv6Address": ip})
+			}
+			sets = append(sets, map[string]interface{}{
+				"NetworkInterfaceId": "eni-fake0001",
+				"Status":             status,
+				"VSwitchId":          "vsw-fake0001",
+				"PrivateIpAddress":   "192.168.0.10",
+				"SecurityGroupIds":   map[string]interface{}{"SecurityGroupId": []string{"sg-fake0001"}},
+				"Ipv6Sets":           map[string]interface{}{"Ipv6Set": ipv6Sets},
+			})
+		}
+		return 200, map[string]interface{}{
+			"RequestId":            "fake-request",
+			"TotalCount":           len(sets),
+			"NetworkInterfaceSets": map[string]interface{}{"NetworkInterfaceSet": sets},
+		}
+	})
+	fc.HandleRPC(connectivity.ECSCode, "DescribeTags", func(request *fakeCloudRequest) (int, interface{}) {
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	})
+	fc.HandleRPC(connectivity.ECSCode, "AssignIpv6Addresses", func(request *fakeCloudRequest) (int, interface{}) {
+		count, _ := strconv.Atoi(request.Param("Ipv6AddressCount"))
+		for i := 0; i < count; i++ {
+			ipv6Addresses = append(ipv6Addresses, fmt.Sprintf("2408:4004:1::%d", next))
+			next++
+		}
+		for i := 1; request.Param(fmt.Sprintf("Ipv6Address.%d", i)) != ""; i++ {
+			ipv6Addresses = append(ipv6Addresses, request.Param(fmt.Sprintf("Ipv6Address.%d", i)))
+		}
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	})
+	fc.HandleRPC(connectivity.ECSCode, "UnassignIpv6Addresses", func(request *fakeCloudRequest) (int, interface{}) {
+		removed := make(map[string]bool)
+		for i := 1; request.Param(fmt.Sprintf("Ipv6Address.%d", i)) != ""; i++ {
+			removed[request.Param(fmt.Sprintf("Ipv6Address.%d", i))] = true
+		}
+		var kept []string
+		for _, ip := range ipv6Addresses {
+			if !removed[ip] {
+				kept = append(kept, ip)
+			}
+		}
+		ipv6Addresses = kept
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	})
+	fc.HandleRPC(connectivity.ECSCode, "DeleteNetworkInterface", func(request *fakeCloudRequest) (int, interface{}) {
+		status = string(Deleted)
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	})
+
+	resourceId := "alicloud_network_interface.default"
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetworkInterfaceConfigFake(fc, "ipv6_address_count = 2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "ipv6_address_count", "2"),
+					resource.TestCheckResourceAttr(resourceId, "ipv6_addresses.#", "2"),
+				),
+			},
+			{
+				Config: testAccNetworkInterfaceConfigFake(fc, `ipv6_addresses = ["2408:4004:1::2", "2408:4004:1::8"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "ipv6_address_count", "2"),
+					func(*terraform.State) error {
+						if !sameIpList(ipv6Addresses, []string{"2408:4004:1::2", "2408:4004:1::8"}) {
+							return fmt.Errorf("unexpected IPv6 addresses %v", ipv6Addresses)
+						}
+						if n := len(fc.Requests(connectivity.ECSCode, "UnassignIpv6Addresses")); n != 1 {
+							return fmt.Errorf("expected the removed address to be unassigned once, got %d", n)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccNetworkInterfaceConfigFake(fc *fakeCloud, ipv6 string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "alicloud_network_interface" "default" {
+  vswitch_id      = "vsw-fake0001"
+  security_groups = ["sg-fake0001"]
+  %s
+}
+`, fc.ProviderConfig(), ipv6)
+}
+
 func testAccNetworkInterfaceConfigBasic(rand int) string {
 	return fmt.Sprintf(`
 variable "name" {
@@ -631,11 +742,13 @@ resource "alicloud_network_interface" "default" {
 }
 
 var testAccCheckNetworkInterfaceCheckMap = map[string]string{
-	"vswitch_id":        CHECKSET,
-	"security_groups.#": "1",
-	"private_ip":        CHECKSET,
-	"private_ips.#":     "0",
-	"private_ips_count": "0",
-	"description":       "",
-	"tags.%":            NOSET,
+	"vswitch_id":         CHECKSET,
+	"security_groups.#":  "1",
+	"private_ip":         CHECKSET,
+	"private_ips.#":      "0",
+	"private_ips_count":  "0",
+	"ipv6_addresses.#":   "0",
+	"ipv6_address_count": "0",
+	"description":        "",
+	"tags.%":             NOSET,
 }
@@ -23,6 +23,7 @@ func resourceAlicloudOssBucket() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: defaultTagsCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"bucket": {
@@ -232,7 +233,8 @@ func resourceAlicloudOssBucket() *schema.Resource {
 				MaxItems: 1,
 			},
 
-			"tags": tagsSchema(),
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 
 			"force_destroy": {
 				Type:     schema.TypeBool,
@@ -531,7 +533,7 @@ func resourceAlicloudOssBucketRead(d *schema.ResourceData, meta interface{}) err
 			tagsMap[t.Key] = t.Value
 		}
 	}
-	if err := d.Set("tags", tagsMap); err != nil {
+	if err := setTagsWithDefaults(client, d, tagsMap); err != nil {
 		return WrapError(err)
 	}
 
@@ -603,7 +605,7 @@ func resourceAlicloudOssBucketUpdate(d *schema.ResourceData, meta interface{}) e
 		d.SetPartial("server_side_encryption_rule")
 	}
 
-	if d.HasChange("tags") {
+	if tagsChanged(client, d) {
 		if err := resourceAlicloudOssBucketTaggingUpdate(client, d); err != nil {
 			return WrapError(err)
 		}
@@ -909,8 +911,8 @@ func resourceAlicloudOssBucketEncryptionUpdate(client *connectivity.AliyunClient
 }
 
 func resourceAlicloudOssBucketTaggingUpdate(client *connectivity.AliyunClient, d *schema.ResourceData) error {
-	tagsMap := d.Get("tags").(map[string]interface{})
-	if tagsMap == nil || len(tagsMap) == 0 {
+	tagsMap := mergeDefaultTags(client, d.Get("tags"))
+	if len(tagsMap) == 0 {
 		raw, err := client.WithOssClient(func(ossClient *oss.Client) (interface{}, error) {
 			return nil, ossClient.DeleteBucketTagging(d.Id())
 		})
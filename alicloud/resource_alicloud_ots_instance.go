@@ -19,6 +19,7 @@ func resourceAlicloudOtsInstance() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: defaultTagsCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -54,7 +55,8 @@ func resourceAlicloudOtsInstance() *schema.Resource {
 					return d.Id() != ""
 				},
 			},
-			"tags": tagsSchema(),
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 		},
 	}
 }
@@ -114,7 +116,7 @@ func resourceAliyunOtsInstanceRead(d *schema.ResourceData, meta interface{}) err
 	d.Set("accessed_by", convertInstanceAccessedByRevert(inst.Network))
 	d.Set("instance_type", convertInstanceTypeRevert(inst.ClusterType))
 	d.Set("description", inst.Description)
-	d.Set("tags", otsTagsToMap(inst.TagInfos.TagInfo))
+	setTagsWithDefaults(client, d, otsTagsToMap(inst.TagInfos.TagInfo))
 	return nil
 }
 
@@ -137,10 +139,10 @@ func resourceAliyunOtsInstanceUpdate(d *schema.ResourceData, meta interface{}) e
 		d.SetPartial("accessed_by")
 	}
 
-	if d.HasChange("tags") {
+	if tagsChanged(client, d) {
 		oraw, nraw := d.GetChange("tags")
 		o := oraw.(map[string]interface{})
-		n := nraw.(map[string]interface{})
+		n := mergeDefaultTags(client, nraw)
 		create, remove := diffTags(tagsFromMap(o), tagsFromMap(n))
 
 		if len(remove) > 0 {
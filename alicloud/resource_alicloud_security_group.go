@@ -18,6 +18,7 @@ func resourceAliyunSecurityGroup() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: defaultTagsCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -42,7 +43,8 @@ func resourceAliyunSecurityGroup() *schema.Resource {
 				Optional: true,
 				Default:  true,
 			},
-			"tags": tagsSchema(),
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 		},
 	}
 }
@@ -98,9 +100,7 @@ func resourceAliyunSecurityGroupRead(d *schema.ResourceData, meta interface{}) e
 	if err != nil && !NotFoundError(err) {
 		return WrapError(err)
 	}
-	if len(tags) > 0 {
-		d.Set("tags", tagsToMap(tags))
-	}
+	setTagsWithDefaults(client, d, tagsToMap(tags))
 
 	return nil
 }
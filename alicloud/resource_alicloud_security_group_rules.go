@@ -0,0 +1,484 @@
+package alicloud
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+// resourceAlicloudSecurityGroupRules manages the complete rule set of a security group. The rules which are not in the
+// configuration, like the ones added in the console, are revoked on apply.
+func resourceAlicloudSecurityGroupRules() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAlicloudSecurityGroupRulesCreate,
+		Read:   resourceAlicloudSecurityGroupRulesRead,
+		Update: resourceAlicloudSecurityGroupRulesUpdate,
+		Delete: resourceAlicloudSecurityGroupRulesDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"security_group_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"ingress": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Set:      securityGroupRulesHash,
+				Elem: &schema.Resource{
+					Schema: securityGroupRulesElemSchema("source"),
+				},
+			},
+			"egress": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Set:      securityGroupRulesHash,
+				Elem: &schema.Resource{
+					Schema: securityGroupRulesElemSchema("dest"),
+				},
+			},
+		},
+	}
+}
+
+// securityGroupRulesElemSchema returns the schema of an ingress or egress rule, whose peer is named by the prefix
+// like the API, e.g. source_cidr_ip for ingress and dest_cidr_ip for egress.
+func securityGroupRulesElemSchema(prefix string) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"ip_protocol": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validateSecurityRuleIpProtocol,
+		},
+		"port_range": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  AllPortRange,
+		},
+		"nic_type": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validateSecurityRuleNicType,
+		},
+		"policy": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      string(GroupRulePolicyAccept),
+			ValidateFunc: validateSecurityRulePolicy,
+		},
+		"priority": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      1,
+			ValidateFunc: validateSecurityPriority,
+		},
+		prefix + "_cidr_ip": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		prefix + "_ipv6_cidr_ip": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		prefix + "_security_group_id": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		prefix + "_group_owner_account": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"description": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validateStringLengthInRange(0, 512),
+		},
+	}
+}
+
+func resourceAlicloudSecurityGroupRulesCreate(d *schema.ResourceData, meta interface{}) error {
+	d.SetId(d.Get("security_group_id").(string))
+
+	return resourceAlicloudSecurityGroupRulesUpdate(d, meta)
+}
+
+func resourceAlicloudSecurityGroupRulesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	_, permissions, err := ecsService.DescribeSecurityGroupPermissions(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	// All of the rules are read back, so the ones added outside of Terraform show up in the plan
+	var ingress, egress []map[string]interface{}
+	for _, permission := range permissions {
+		rule := securityGroupRuleFromPermission(permission)
+		if rule.direction == string(DirectionIngress) {
+			ingress = append(ingress, rule.flatten("source"))
+		} else {
+			egress = append(egress, rule.flatten("dest"))
+		}
+	}
+	d.Set("security_group_id", d.Id())
+	if err := d.Set("ingress", ingress); err != nil {
+		return WrapError(err)
+	}
+	if err := d.Set("egress", egress); err != nil {
+		return WrapError(err)
+	}
+	return nil
+}
+
+func resourceAlicloudSecurityGroupRulesUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	group, permissions, err := ecsService.DescribeSecurityGroupPermissions(d.Id())
+	if err != nil {
+		return WrapError(err)
+	}
+
+	var rules []securityGroupRule
+	for _, direction := range []Direction{DirectionIngress, DirectionEgress} {
+		for _, e := range d.Get(string(direction)).(*schema.Set).List() {
+			rule, err := expandSecurityGroupRule(direction, e.(map[string]interface{}), group.VpcId != "")
+			if err != nil {
+				return WrapError(err)
+			}
+			rules = append(rules, rule)
+		}
+	}
+
+	if err := applySecurityGroupRules(client, d.Id(), rules, permissions); err != nil {
+		return WrapError(err)
+	}
+	return resourceAlicloudSecurityGroupRulesRead(d, meta)
+}
+
+// resourceAlicloudSecurityGroupRulesDelete revokes all of the rules of the security group.
+func resourceAlicloudSecurityGroupRulesDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	_, permissions, err := ecsService.DescribeSecurityGroupPermissions(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			return nil
+		}
+		return WrapError(err)
+	}
+	return WrapError(applySecurityGroupRules(client, d.Id(), nil, permissions))
+}
+
+// applySecurityGroupRules makes the rules of the security group the same as the given ones. The missing rules are
+// authorized before the unknown ones are revoked, so a replaced rule does not drop the traffic in between.
+func applySecurityGroupRules(client *connectivity.AliyunClient, groupId string, rules []securityGroupRule, permissions []ecs.Permission) error {
+	current := make(map[string]securityGroupRule)
+	for _, permission := range permissions {
+		rule := securityGroupRuleFromPermission(permission)
+		current[rule.key()] = rule
+	}
+	expected := make(map[string]bool)
+	for _, rule := range rules {
+		expected[rule.key()] = true
+		old, ok := current[rule.key()]
+		if !ok {
+			if err := authorizeSecurityGroupRule(client, groupId, rule); err != nil {
+				return WrapError(err)
+			}
+		} else if old.description != rule.description {
+			if err := modifySecurityGroupRule(client, groupId, rule); err != nil {
+				return WrapError(err)
+			}
+		}
+	}
+	for key, rule := range current {
+		if !expected[key] {
+			if err := revokeSecurityGroupRule(client, groupId, rule); err != nil {
+				return WrapError(err)
+			}
+		}
+	}
+	return nil
+}
+
+type securityGroupRule struct {
+	direction         string
+	ipProtocol        string
+	portRange         string
+	nicType           string
+	policy            string
+	priority          string
+	cidrIp            string
+	ipv6CidrIp        string
+	groupId           string
+	groupOwnerAccount string
+	description       string
+}
+
+// key identifies the rule in the security group. The description is not a part of it, and can be modified in place.
+func (r securityGroupRule) key() string {
+	return strings.Join([]string{r.direction, r.ipProtocol, r.portRange, r.nicType, r.policy, r.priority,
+		r.cidrIp, r.ipv6CidrIp, r.groupId, r.groupOwnerAccount}, COLON_SEPARATED)
+}
+
+func (r securityGroupRule) flatten(prefix string) map[string]interface{} {
+	priority, _ := strconv.Atoi(r.priority)
+	return map[string]interface{}{
+		"ip_protocol":                   r.ipProtocol,
+		"port_range":                    r.portRange,
+		"nic_type":                      r.nicType,
+		"policy":                        r.policy,
+		"priority":                      priority,
+		prefix + "_cidr_ip":             r.cidrIp,
+		prefix + "_ipv6_cidr_ip":        r.ipv6CidrIp,
+		prefix + "_security_group_id":   r.groupId,
+		prefix + "_group_owner_account": r.groupOwnerAccount,
+		"description":                   r.description,
+	}
+}
+
+func securityGroupRuleFromPermission(permission ecs.Permission) securityGroupRule {
+	rule := securityGroupRule{
+		direction:   permission.Direction,
+		ipProtocol:  strings.ToLower(permission.IpProtocol),
+		portRange:   permission.PortRange,
+		nicType:     permission.NicType,
+		policy:      strings.ToLower(permission.Policy),
+		priority:    permission.Priority,
+		description: permission.Description,
+	}
+	if rule.direction == string(DirectionIngress) {
+		rule.cidrIp = permission.SourceCidrIp
+		rule.ipv6CidrIp = permission.Ipv6SourceCidrIp
+		rule.groupId = permission.SourceGroupId
+		rule.groupOwnerAccount = permission.SourceGroupOwnerAccount
+	} else {
+		rule.cidrIp = permission.DestCidrIp
+		rule.ipv6CidrIp = permission.Ipv6DestCidrIp
+		rule.groupId = permission.DestGroupId
+		rule.groupOwnerAccount = permission.DestGroupOwnerAccount
+	}
+	return rule
+}
+
+func expandSecurityGroupRule(direction Direction, m map[string]interface{}, vpc bool) (rule securityGroupRule, err error) {
+	prefix := "source"
+	if direction == DirectionEgress {
+		prefix = "dest"
+	}
+	rule = securityGroupRule{
+		direction:         string(direction),
+		ipProtocol:        m["ip_protocol"].(string),
+		portRange:         m["port_range"].(string),
+		nicType:           m["nic_type"].(string),
+		policy:            m["policy"].(string),
+		priority:          strconv.Itoa(m["priority"].(int)),
+		cidrIp:            m[prefix+"_cidr_ip"].(string),
+		ipv6CidrIp:        m[prefix+"_ipv6_cidr_ip"].(string),
+		groupId:           m[prefix+"_security_group_id"].(string),
+		groupOwnerAccount: m[prefix+"_group_owner_account"].(string),
+		description:       m["description"].(string),
+	}
+
+	peers := 0
+	for _, peer := range []string{rule.cidrIp, rule.ipv6CidrIp, rule.groupId} {
+		if peer != "" {
+			peers++
+		}
+	}
+	if peers != 1 {
+		return rule, fmt.Errorf("Exactly one of '%s_cidr_ip', '%s_ipv6_cidr_ip' and '%s_security_group_id' must be specified in the %s rule.", prefix, prefix, prefix, direction)
+	}
+	if rule.ipv6CidrIp != "" && !vpc {
+		return rule, fmt.Errorf("'%s_ipv6_cidr_ip' is only supported by the security group in the vpc.", prefix)
+	}
+	if rule.ipProtocol == string(Tcp) || rule.ipProtocol == string(Udp) {
+		if rule.portRange == AllPortRange {
+			return rule, fmt.Errorf("'tcp' and 'udp' can support port range: [1, 65535]. Please correct it and try again.")
+		}
+	} else if rule.portRange != AllPortRange {
+		return rule, fmt.Errorf("'icmp', 'gre' and 'all' only support port range '-1/-1'. Please correct it and try again.")
+	}
+
+	if rule.nicType == "" {
+		rule.nicType = string(GroupRuleInternet)
+		if vpc || rule.groupId != "" {
+			rule.nicType = string(GroupRuleIntranet)
+		}
+	} else if (vpc || rule.groupId != "") && rule.nicType != string(GroupRuleIntranet) {
+		return rule, fmt.Errorf("When security group in the vpc or authorizing permission for source/destination security group, " +
+			"the nic_type must be 'intranet'.")
+	}
+	return rule, nil
+}
+
+// securityGroupRulesHash leaves out the nic_type, which is computed when it is not set, and the description.
+func securityGroupRulesHash(v interface{}) int {
+	var buf bytes.Buffer
+	m := v.(map[string]interface{})
+	for _, key := range []string{"ip_protocol", "port_range", "policy",
+		"source_cidr_ip", "source_security_group_id", "source_group_owner_account",
+		"dest_cidr_ip", "dest_security_group_id", "dest_group_owner_account"} {
+		if v, ok := m[key]; ok {
+			buf.WriteString(fmt.Sprintf("%s-", v.(string)))
+		}
+	}
+	// The IPv6 peers are only hashed when they are set, so the hashes of the IPv4 rules stay the same
+	for _, key := range []string{"source_ipv6_cidr_ip", "dest_ipv6_cidr_ip"} {
+		if v, ok := m[key]; ok && v.(string) != "" {
+			buf.WriteString(fmt.Sprintf("%s-", v.(string)))
+		}
+	}
+	buf.WriteString(fmt.Sprintf("%d-", m["priority"].(int)))
+	return hashcode.String(buf.String())
+}
+
+func authorizeSecurityGroupRule(client *connectivity.AliyunClient, groupId string, rule securityGroupRule) error {
+	if rule.direction == string(DirectionIngress) {
+		r := ecs.CreateAuthorizeSecurityGroupRequest()
+		r.RegionId = client.RegionId
+		r.SecurityGroupId = groupId
+		r.IpProtocol = rule.ipProtocol
+		r.PortRange = rule.portRange
+		r.NicType = rule.nicType
+		r.Policy = rule.policy
+		r.Priority = rule.priority
+		r.SourceCidrIp = rule.cidrIp
+		r.Ipv6SourceCidrIp = rule.ipv6CidrIp
+		r.SourceGroupId = rule.groupId
+		r.SourceGroupOwnerAccount = rule.groupOwnerAccount
+		r.Description = rule.description
+		return invokeSecurityGroupRuleRequest(client, groupId, r.GetActionName(), func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.AuthorizeSecurityGroup(r)
+		})
+	} else {
+		r := ecs.CreateAuthorizeSecurityGroupEgressRequest()
+		r.RegionId = client.RegionId
+		r.SecurityGroupId = groupId
+		r.IpProtocol = rule.ipProtocol
+		r.PortRange = rule.portRange
+		r.NicType = rule.nicType
+		r.Policy = rule.policy
+		r.Priority = rule.priority
+		r.DestCidrIp = rule.cidrIp
+		r.Ipv6DestCidrIp = rule.ipv6CidrIp
+		r.DestGroupId = rule.groupId
+		r.DestGroupOwnerAccount = rule.groupOwnerAccount
+		r.Description = rule.description
+		return invokeSecurityGroupRuleRequest(client, groupId, r.GetActionName(), func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.AuthorizeSecurityGroupEgress(r)
+		})
+	}
+}
+
+func modifySecurityGroupRule(client *connectivity.AliyunClient, groupId string, rule securityGroupRule) error {
+	if rule.direction == string(DirectionIngress) {
+		r := ecs.CreateModifySecurityGroupRuleRequest()
+		r.RegionId = client.RegionId
+		r.SecurityGroupId = groupId
+		r.IpProtocol = rule.ipProtocol
+		r.PortRange = rule.portRange
+		r.NicType = rule.nicType
+		r.Policy = rule.policy
+		r.Priority = rule.priority
+		r.SourceCidrIp = rule.cidrIp
+		r.Ipv6SourceCidrIp = rule.ipv6CidrIp
+		r.SourceGroupId = rule.groupId
+		r.SourceGroupOwnerAccount = rule.groupOwnerAccount
+		r.Description = rule.description
+		return invokeSecurityGroupRuleRequest(client, groupId, r.GetActionName(), func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.ModifySecurityGroupRule(r)
+		})
+	} else {
+		r := ecs.CreateModifySecurityGroupEgressRuleRequest()
+		r.RegionId = client.RegionId
+		r.SecurityGroupId = groupId
+		r.IpProtocol = rule.ipProtocol
+		r.PortRange = rule.portRange
+		r.NicType = rule.nicType
+		r.Policy = rule.policy
+		r.Priority = rule.priority
+		r.DestCidrIp = rule.cidrIp
+		r.Ipv6DestCidrIp = rule.ipv6CidrIp
+		r.DestGroupId = rule.groupId
+		r.DestGroupOwnerAccount = rule.groupOwnerAccount
+		r.Description = rule.description
+		return invokeSecurityGroupRuleRequest(client, groupId, r.GetActionName(), func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.ModifySecurityGroupEgressRule(r)
+		})
+	}
+}
+
+func revokeSecurityGroupRule(client *connectivity.AliyunClient, groupId string, rule securityGroupRule) error {
+	if rule.direction == string(DirectionIngress) {
+		r := ecs.CreateRevokeSecurityGroupRequest()
+		r.RegionId = client.RegionId
+		r.SecurityGroupId = groupId
+		r.IpProtocol = rule.ipProtocol
+		r.PortRange = rule.portRange
+		r.NicType = rule.nicType
+		r.Policy = rule.policy
+		r.Priority = rule.priority
+		r.SourceCidrIp = rule.cidrIp
+		r.Ipv6SourceCidrIp = rule.ipv6CidrIp
+		r.SourceGroupId = rule.groupId
+		r.SourceGroupOwnerAccount = rule.groupOwnerAccount
+		return invokeSecurityGroupRuleRequest(client, groupId, r.GetActionName(), func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.RevokeSecurityGroup(r)
+		})
+	} else {
+		r := ecs.CreateRevokeSecurityGroupEgressRequest()
+		r.RegionId = client.RegionId
+		r.SecurityGroupId = groupId
+		r.IpProtocol = rule.ipProtocol
+		r.PortRange = rule.portRange
+		r.NicType = rule.nicType
+		r.Policy = rule.policy
+		r.Priority = rule.priority
+		r.DestCidrIp = rule.cidrIp
+		r.Ipv6DestCidrIp = rule.ipv6CidrIp
+		r.DestGroupId = rule.groupId
+		r.DestGroupOwnerAccount = rule.groupOwnerAccount
+		return invokeSecurityGroupRuleRequest(client, groupId, r.GetActionName(), func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.RevokeSecurityGroupEgress(r)
+		})
+	}
+}
+
+func invokeSecurityGroupRuleRequest(client *connectivity.AliyunClient, groupId, action string, invoker func(ecsClient *ecs.Client) (interface{}, error)) error {
+	err := resource.Retry(5*time.Minute, func() *resource.RetryError {
+		raw, err := client.WithEcsClient(invoker)
+		if err != nil {
+			if IsExceptedErrors(err, []string{"OperationConflict", "ServiceUnavailable", "InternalError"}) {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(action, raw)
+		return nil
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, groupId, action, AlibabaCloudSdkGoERROR)
+	}
+	return nil
+}
@@ -0,0 +1,262 @@
+package alicloud
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func TestAccAlicloudSecurityGroupRulesBasic(t *testing.T) {
+	var v ecs.DescribeSecurityGroupAttributeResponse
+	resourceId := "alicloud_security_group_rules.default"
+	rand := acctest.RandIntRange(1000, 9999)
+	name := fmt.Sprintf("tf-testAccSecurityGroupRules%d", rand)
+	ra := resourceAttrInit(resourceId, map[string]string{
+		"security_group_id": CHECKSET,
+	})
+
+	serviceFunc := func() interface{} {
+		return &EcsService{testAccProvider.Meta().(*connectivity.AliyunClient)}
+	}
+	rc := resourceCheckInitWithDescribeMethod(resourceId, &v, serviceFunc, "DescribeSecurityGroup")
+
+	rac := resourceAttrCheckInit(rc, ra)
+
+	testAccCheck := rac.resourceAttrMapUpdateSet()
+	testAccConfig := resourceTestAccConfigFunc(resourceId, name, resourceSecurityGroupRulesConfigDependence)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		// module name
+		IDRefreshName: resourceId,
+
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"security_group_id": "${alicloud_security_group.default.id}",
+					"ingress": []map[string]interface{}{
+						{
+							"ip_protocol":    "tcp",
+							"port_range":     "22/22",
+							"source_cidr_ip": "10.0.0.0/8",
+							"description":    "ssh",
+						},
+					},
+					"egress": []map[string]interface{}{
+						{
+							"ip_protocol":  "all",
+							"dest_cidr_ip": "0.0.0.0/0",
+						},
+					},
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"ingress.#": "1",
+						"egress.#":  "1",
+					}),
+				),
+			},
+			{
+				ResourceName:      resourceId,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"ingress": []map[string]interface{}{
+						{
+							"ip_protocol":    "tcp",
+							"port_range":     "22/22",
+							"source_cidr_ip": "10.0.0.0/8",
+							"description":    "ssh from the office",
+						},
+						{
+							"ip_protocol":              "tcp",
+							"port_range":               "80/80",
+							"source_security_group_id": "${alicloud_security_group.peer.id}",
+							"priority":                 "10",
+						},
+					},
+					"egress": REMOVEKEY,
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"ingress.#": "2",
+						"egress.#":  "0",
+					}),
+				),
+			},
+		},
+	})
+}
+
+// TestUnitAlicloudSecurityGroupRulesDrift checks the rules which are added outside of Terraform are revoked on apply,
+// against the local fake cloud.
+func TestUnitAlicloudSecurityGroupRulesDrift(t *testing.T) {
+	fc := newFakeCloud(t, connectivity.ECSCode)
+	defer fc.Close()
+
+	var permissions []map[string]interface{}
+	addPermission := func(direction, protocol, portRange, cidrIp string) {
+		permission := map[string]interface{}{
+			"Direction":  direction,
+			"IpProtocol": protocol,
+			"PortRange":  portRange,
+			"NicType":    string(GroupRuleIntranet),
+			"Policy":     "Accept",
+			"Priority":   "1",
+		}
+		peer := "SourceCidrIp"
+		if direction == string(DirectionEgress) {
+			peer = "DestCidrIp"
+		}
+		if strings.Contains(cidrIp, ":") {
+			peer = "Ipv6" + peer
+		}
+		permission[peer] = cidrIp
+		permissions = append(permissions, permission)
+	}
+	// The rules which were added in the console before the group is managed by Terraform, the IPv6 one is declared
+	addPermission("ingress", "TCP", "3389/3389", "0.0.0.0/0")
+	addPermission("ingress", "TCP", "443/443", "::/0")
+
+	fc.HandleRPC(connectivity.ECSCode, "DescribeSecurityGroupAttribute", func(request *fakeCloudRequest) (int, interface{}) {
+		return 200, map[string]interface{}{
+			"RequestId":       "fake-request",
+			"SecurityGroupId": request.Param("SecurityGroupId"),
+			"VpcId":           "vpc-fake0001",
+			"Permissions":     map[string]interface{}{"Permission": permissions},
+		}
+	})
+	authorize := func(direction string) fakeCloudHandler {
+		return func(request *fakeCloudRequest) (int, interface{}) {
+			cidrIp := request.Param("SourceCidrIp") + request.Param("Ipv6SourceCidrIp")
+			if direction == string(DirectionEgress) {
+				cidrIp = request.Param("DestCidrIp") + request.Param("Ipv6DestCidrIp")
+			}
+			addPermission(direction, request.Param("IpProtocol"), request.Param("PortRange"), cidrIp)
+			return 200, map[string]interface{}{"RequestId": "fake-request"}
+		}
+	}
+	revoke := func(request *fakeCloudRequest) (int, interface{}) {
+		var kept []map[string]interface{}
+		for _, permission := range permissions {
+			if permission["PortRange"] != request.Param("PortRange") {
+				kept = append(kept, permission)
+			}
+		}
+		permissions = kept
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	}
+	fc.HandleRPC(connectivity.ECSCode, "AuthorizeSecurityGroup", authorize("ingress"))
+	fc.HandleRPC(connectivity.ECSCode, "AuthorizeSecurityGroupEgress", authorize("egress"))
+	fc.HandleRPC(connectivity.ECSCode, "RevokeSecurityGroup", revoke)
+	fc.HandleRPC(connectivity.ECSCode, "RevokeSecurityGroupEgress", revoke)
+
+	checkRevoked := func(portRanges ...string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			requests := fc.Requests(connectivity.ECSCode, "RevokeSecurityGroup")
+			if len(requests) != len(portRanges) {
+				return fmt.Errorf("expected %d rules to be revoked, got %d", len(portRanges), len(requests))
+			}
+			for i, portRange := range portRanges {
+				if requests[i].Param("PortRange") != portRange || requests[i].Param("NicType") != string(GroupRuleIntranet) {
+					return fmt.Errorf("expected the rule of port range %s to be revoked, got %s", portRange, requests[i].Param("PortRange"))
+				}
+			}
+			return nil
+		}
+	}
+
+	resourceId := "alicloud_security_group_rules.default"
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSecurityGroupRulesConfigFake(fc),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "ingress.#", "2"),
+					resource.TestCheckResourceAttr(resourceId, "egress.#", "2"),
+					checkRevoked("3389/3389"),
+					fc.CheckRequests(connectivity.ECSCode, "AuthorizeSecurityGroupEgress", 2, nil),
+				),
+			},
+			{
+				// Another rule is added in the console after the apply
+				PreConfig: func() {
+					addPermission("ingress", "TCP", "8080/8080", "0.0.0.0/0")
+				},
+				Config: testAccSecurityGroupRulesConfigFake(fc),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "ingress.#", "2"),
+					checkRevoked("3389/3389", "8080/8080"),
+					// The declared IPv6 rule existed already, so only the IPv4 one is authorized
+					fc.CheckRequests(connectivity.ECSCode, "AuthorizeSecurityGroup", 1, map[string]string{"Ipv6SourceCidrIp": ""}),
+					fc.CheckRequests(connectivity.ECSCode, "AuthorizeSecurityGroupEgress", 2, nil),
+				),
+			},
+		},
+	})
+}
+
+func testAccSecurityGroupRulesConfigFake(fc *fakeCloud) string {
+	return fmt.Sprintf(`
+%s
+
+resource "alicloud_security_group_rules" "default" {
+  security_group_id = "sg-fake0001"
+  ingress {
+    ip_protocol    = "tcp"
+    port_range     = "22/22"
+    source_cidr_ip = "10.0.0.0/8"
+  }
+  ingress {
+    ip_protocol         = "tcp"
+    port_range          = "443/443"
+    source_ipv6_cidr_ip = "::/0"
+  }
+  egress {
+    ip_protocol  = "all"
+    dest_cidr_ip = "0.0.0.0/0"
+  }
+  egress {
+    ip_protocol       = "all"
+    dest_ipv6_cidr_ip = "::/0"
+  }
+}
+`, fc.ProviderConfig())
+}
+
+func resourceSecurityGroupRulesConfigDependence(name string) string {
+	return fmt.Sprintf(`
+variable "name" {
+  default = "%s"
+}
+
+resource "alicloud_vpc" "default" {
+  name = "${var.name}"
+  cidr_block = "192.168.0.0/16"
+}
+
+resource "alicloud_security_group" "default" {
+  name = "${var.name}"
+  vpc_id = "${alicloud_vpc.default.id}"
+}
+
+resource "alicloud_security_group" "peer" {
+  name = "${var.name}-peer"
+  vpc_id = "${alicloud_vpc.default.id}"
+}
+`, name)
+}
@@ -218,6 +218,138 @@ func TestAccAlicloudSecurityGroupBasic(t *testing.T) {
 	})
 }
 
+// TestUnitAlicloudSecurityGroupDefaultTags checks the provider default_tags are written to the cloud and do not
+// show up in the plan, against the local fake cloud.
+func TestUnitAlicloudSecurityGroupDefaultTags(t *testing.T) {
+	fc := newFakeCloud(t, connectivity.ECSCode)
+	defer fc.Close()
+
+	deleted := false
+	tags := make(map[string]string)
+	fc.HandleRPC(connectivity.ECSCode, "CreateSecurityGroup", func(request *fakeCloudRequest) (int, interface{}) {
+		return 200, map[string]interface{}{"RequestId": "fake-request", "SecurityGroupId": "sg-fake0001"}
+	})
+	fc.HandleRPC(connectivity.ECSCode, "DescribeSecurityGroupAttribute", func(request *fakeCloudRequest) (int, interface{}) {
+		if deleted {
+			return 404, map[string]interface{}{"RequestId": "fake-request", "Code": InvalidSecurityGroupIdNotFound, "Message": "The specified security group does not exist."}
+		}
+		return 200, map[string]interface{}{
+			"RequestId":         "fake-request",
+			"SecurityGroupId":   "sg-fake0001",
+			"SecurityGroupName": "tf-testAccSecurityGroupFake",
+			"InnerAccessPolicy": string(GroupInnerAccept),
+		}
+	})
+	fc.HandleRPC(connectivity.ECSCode, "TagResources", func(request *fakeCloudRequest) (int, interface{}) {
+		for i := 1; request.Param(fmt.Sprintf("Tag.%d.Key", i)) != ""; i++ {
+			tags[request.Param(fmt.Sprintf("Tag.%d.Key", i))] = request.Param(fmt.Sprintf("Tag.%d.Value", i))
+		}
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	})
+	fc.HandleRPC(connectivity.ECSCode, "UntagResources", func(request *fakeCloudRequest) (int, interface{}) {
+		for i := 1; request.Param(fmt.Sprintf("TagKey.%d", i)) != ""; i++ {
+			delete(tags, request.Param(fmt.Sprintf("TagKey.%d", i)))
+		}
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	})
+	fc.HandleRPC(connectivity.ECSCode, "DescribeTags", func(request *fakeCloudRequest) (int, interface{}) {
+		var list []map[string]string
+		for key, value := range tags {
+			list = append(list, map[string]string{"TagKey": key, "TagValue": value})
+		}
+		return 200, map[string]interface{}{"RequestId": "fake-request", "Tags": map[string]interface{}{"Tag": list}}
+	})
+	fc.HandleRPC(connectivity.ECSCode, "DeleteSecurityGroup", func(request *fakeCloudRequest) (int, interface{}) {
+		deleted = true
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	})
+	checkCloudTags := func(expected map[string]string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			if len(tags) != len(expected) {
+				return fmt.Errorf("expected the cloud tags %v, got %v", expected, tags)
+			}
+			for key, value := range expected {
+				if tags[key] != value {
+					return fmt.Errorf("expected the cloud tags %v, got %v", expected, tags)
+				}
+			}
+			return nil
+		}
+	}
+
+	resourceId := "alicloud_security_group.default"
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckSecurityGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSecurityGroupConfigDefaultTags(fc, `Owner = "override"`, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "tags.%", "2"),
+					resource.TestCheckResourceAttr(resourceId, "tags.Name", "tf-testAccSecurityGroupFake"),
+					resource.TestCheckResourceAttr(resourceId, "tags.Owner", "override"),
+					resource.TestCheckResourceAttr(resourceId, "tags_all.%", "3"),
+					resource.TestCheckResourceAttr(resourceId, "tags_all.CostCenter", "tf-testAcc"),
+					checkCloudTags(map[string]string{
+						"Name":       "tf-testAccSecurityGroupFake",
+						"Owner":      "override",
+						"CostCenter": "tf-testAcc",
+					}),
+				),
+			},
+			{
+				Config: testAccCheckSecurityGroupConfigDefaultTags(fc, "", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceId, "tags.Name", "tf-testAccSecurityGroupFake"),
+					checkCloudTags(map[string]string{
+						"Name":       "tf-testAccSecurityGroupFake",
+						"Owner":      "terraform",
+						"CostCenter": "tf-testAcc",
+					}),
+				),
+			},
+			{
+				// The key added to default_tags after the security group was created is assigned to it
+				Config: testAccCheckSecurityGroupConfigDefaultTags(fc, "", `Env = "test"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceId, "tags_all.%", "4"),
+					resource.TestCheckResourceAttr(resourceId, "tags_all.Env", "test"),
+					checkCloudTags(map[string]string{
+						"Name":       "tf-testAccSecurityGroupFake",
+						"Owner":      "terraform",
+						"CostCenter": "tf-testAcc",
+						"Env":        "test",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSecurityGroupConfigDefaultTags(fc *fakeCloud, tags, defaultTags string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "alicloud_security_group" "default" {
+  name = "tf-testAccSecurityGroupFake"
+  tags = {
+    Name = "tf-testAccSecurityGroupFake"
+    %s
+  }
+}
+`, fc.ProviderConfig(fmt.Sprintf(`
+  default_tags {
+    tags = {
+      Owner      = "terraform"
+      CostCenter = "tf-testAcc"
+      %s
+    }
+  }`, defaultTags)), tags)
+}
+
 func TestAccAlicloudSecurityGroupMulti(t *testing.T) {
 	var v ecs.DescribeSecurityGroupAttributeResponse
 	resourceId := "alicloud_security_group.default.9"
@@ -19,6 +19,7 @@ func resourceAliyunSlb() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: defaultTagsCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -219,6 +220,8 @@ func resourceAliyunSlb() *schema.Resource {
 				ValidateFunc: validateSlbInstanceTagNum,
 			},
 
+			"tags_all": tagsAllSchema(),
+
 			"instance_charge_type": {
 				Type:         schema.TypeString,
 				Optional:     true,
@@ -382,11 +385,13 @@ func resourceAliyunSlbRead(d *schema.ResourceData, meta interface{}) error {
 		d.Set("instance_charge_type", PostPaid)
 	}
 	d.Set("delete_protection", object.DeleteProtection)
-	tags, _ := slbService.describeTags(d.Id())
-	if len(tags) > 0 {
-		if err := d.Set("tags", slbService.slbTagsToMap(tags)); err != nil {
-			return WrapError(err)
-		}
+	tagService := TagService{client}
+	tags, err := tagService.ListTags(d.Id(), TagResourceSlb)
+	if err != nil {
+		return WrapError(err)
+	}
+	if err := setTagsWithDefaults(client, d, tags); err != nil {
+		return WrapError(err)
 	}
 	return nil
 }
@@ -394,13 +399,14 @@ func resourceAliyunSlbRead(d *schema.ResourceData, meta interface{}) error {
 func resourceAliyunSlbUpdate(d *schema.ResourceData, meta interface{}) error {
 
 	client := meta.(*connectivity.AliyunClient)
-	slbService := SlbService{client}
+	tagService := TagService{client}
 	d.Partial(true)
 
 	// set instance tags
-	if err := slbService.setSlbInstanceTags(d); err != nil {
+	if err := tagService.SetTags(d, TagResourceSlb); err != nil {
 		return WrapError(err)
 	}
+	d.SetPartial("tags")
 
 	if d.IsNewResource() {
 		d.Partial(false)
@@ -3,6 +3,7 @@ package alicloud
 import (
 	"fmt"
 	"log"
+	"strconv"
 	"testing"
 
 	"strings"
@@ -89,6 +90,56 @@ func testSweepSLBs(region string) error {
 	return nil
 }
 
+func TestUnitAlicloudSlbListTags(t *testing.T) {
+	fc := newFakeCloud(t, connectivity.SLBCode)
+	defer fc.Close()
+
+	tags := []map[string]string{
+		{"TagKey": "Name", "TagValue": "tf-testAcc"},
+		{"TagKey": "Env", "TagValue": "test"},
+		{"TagKey": "CostCenter", "TagValue": "1234"},
+	}
+	// The tags are returned two per page, and the last page is not full
+	fc.HandleRPC(connectivity.SLBCode, "DescribeTags", func(request *fakeCloudRequest) (int, interface{}) {
+		page, _ := strconv.Atoi(request.Param("PageNumber"))
+		start, end := (page-1)*2, page*2
+		if start > len(tags) {
+			start = len(tags)
+		}
+		if end > len(tags) {
+			end = len(tags)
+		}
+		return 200, map[string]interface{}{
+			"RequestId":  "fake-request",
+			"TotalCount": len(tags),
+			"TagSets":    map[string]interface{}{"TagSet": tags[start:end]},
+		}
+	})
+
+	config := connectivity.Config{
+		Region:      connectivity.Region("cn-hangzhou"),
+		RegionId:    "cn-hangzhou",
+		AccessKey:   "fake-access-key",
+		SecretKey:   "fake-secret-key",
+		SlbEndpoint: fc.URL(connectivity.SLBCode),
+	}
+	client, err := config.Client()
+	if err != nil {
+		t.Fatalf("Creating the client got an error: %#v.", err)
+	}
+
+	result, err := (&slbTagProduct{}).listTags(client, "lb-fake0001")
+	if err != nil {
+		t.Fatalf("Listing the tags got an error: %#v.", err)
+	}
+	if len(result) != len(tags) {
+		t.Fatalf("Listing the tags got %d tags, expected %d.", len(result), len(tags))
+	}
+	if requests := fc.Requests(connectivity.SLBCode, "DescribeTags"); len(requests) != 2 {
+		t.Fatalf("Listing the tags sent %d DescribeTags requests, expected 2.", len(requests))
+	}
+}
+
 func TestAccAlicloudSlb_classictest(t *testing.T) {
 	var v *slb.DescribeLoadBalancerAttributeResponse
 	resourceId := "alicloud_slb.default"
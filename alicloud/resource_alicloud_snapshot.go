@@ -19,6 +19,7 @@ func resourceAliyunSnapshot() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: defaultTagsCustomizeDiff,
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(DefaultTimeout * time.Second),
@@ -41,7 +42,8 @@ func resourceAliyunSnapshot() *schema.Resource {
 				Optional: true,
 				ForceNew: true,
 			},
-			"tags": tagsSchema(),
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 		},
 	}
 }
@@ -100,9 +102,7 @@ func resourceAliyunSnapshotRead(d *schema.ResourceData, meta interface{}) error
 	if err != nil && !NotFoundError(err) {
 		return WrapError(err)
 	}
-	if len(tags) > 0 {
-		d.Set("tags", tagsToMap(tags))
-	}
+	setTagsWithDefaults(client, d, tagsToMap(tags))
 
 	return nil
 }
@@ -0,0 +1,138 @@
+package alicloud
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func resourceAlicloudSnapshotPolicyAttachment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAlicloudSnapshotPolicyAttachmentCreate,
+		Read:   resourceAlicloudSnapshotPolicyAttachmentRead,
+		Update: resourceAlicloudSnapshotPolicyAttachmentUpdate,
+		Delete: resourceAlicloudSnapshotPolicyAttachmentDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"snapshot_policy_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"disk_ids": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+		},
+	}
+}
+
+func resourceAlicloudSnapshotPolicyAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	policyId := d.Get("snapshot_policy_id").(string)
+	diskIds := expandStringList(d.Get("disk_ids").(*schema.Set).List())
+	if err := ecsService.ApplyAutoSnapshotPolicy(policyId, diskIds); err != nil {
+		return WrapError(err)
+	}
+	d.SetId(policyId)
+
+	return resourceAlicloudSnapshotPolicyAttachmentRead(d, meta)
+}
+
+func resourceAlicloudSnapshotPolicyAttachmentRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	if _, err := ecsService.DescribeSnapshotPolicy(d.Id()); err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+	disks, err := ecsService.DescribeSnapshotPolicyDisks(d.Id())
+	if err != nil {
+		return WrapError(err)
+	}
+
+	// Only the disks managed by this resource are reported, the policy may also be applied
+	// to the other disks by alicloud_disk or alicloud_instance.
+	configured := d.Get("disk_ids").(*schema.Set)
+	var diskIds []string
+	for _, disk := range disks {
+		if configured.Len() < 1 || configured.Contains(disk.DiskId) {
+			diskIds = append(diskIds, disk.DiskId)
+		}
+	}
+	if len(diskIds) < 1 {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("snapshot_policy_id", d.Id())
+	d.Set("disk_ids", diskIds)
+
+	return nil
+}
+
+func resourceAlicloudSnapshotPolicyAttachmentUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	if d.HasChange("disk_ids") {
+		o, n := d.GetChange("disk_ids")
+		os := o.(*schema.Set)
+		ns := n.(*schema.Set)
+
+		if add := ns.Difference(os); add.Len() > 0 {
+			if err := ecsService.ApplyAutoSnapshotPolicy(d.Id(), expandStringList(add.List())); err != nil {
+				return WrapError(err)
+			}
+		}
+		if remove := os.Difference(ns); remove.Len() > 0 {
+			if err := cancelSnapshotPolicyDisks(d.Id(), expandStringList(remove.List()), meta); err != nil {
+				return WrapError(err)
+			}
+		}
+	}
+
+	return resourceAlicloudSnapshotPolicyAttachmentRead(d, meta)
+}
+
+func resourceAlicloudSnapshotPolicyAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
+	return WrapError(cancelSnapshotPolicyDisks(d.Id(), expandStringList(d.Get("disk_ids").(*schema.Set).List()), meta))
+}
+
+// cancelSnapshotPolicyDisks cancels the policy on the given disks which still have it, the disks
+// which have been released or bound to another policy in the meantime are skipped.
+func cancelSnapshotPolicyDisks(policyId string, diskIds []string, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	disks, err := ecsService.DescribeSnapshotPolicyDisks(policyId)
+	if err != nil {
+		return WrapError(err)
+	}
+	attached := make(map[string]bool)
+	for _, disk := range disks {
+		attached[disk.DiskId] = true
+	}
+	var cancel []string
+	for _, id := range diskIds {
+		if attached[id] {
+			cancel = append(cancel, id)
+		}
+	}
+	if len(cancel) < 1 {
+		return nil
+	}
+	return WrapError(ecsService.CancelAutoSnapshotPolicy(cancel))
+}
@@ -0,0 +1,219 @@
+package alicloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func TestAccAlicloudSnapshotPolicyAttachmentBasic(t *testing.T) {
+	var v *ecs.AutoSnapshotPolicy
+	resourceId := "alicloud_snapshot_policy_attachment.default"
+	rand := acctest.RandIntRange(10000, 99999)
+	name := fmt.Sprintf("tf-testAccSnapshotPolicyAttachment%d", rand)
+	ra := resourceAttrInit(resourceId, map[string]string{
+		"snapshot_policy_id": CHECKSET,
+	})
+
+	serviceFunc := func() interface{} {
+		return &EcsService{testAccProvider.Meta().(*connectivity.AliyunClient)}
+	}
+	rc := resourceCheckInitWithDescribeMethod(resourceId, &v, serviceFunc, "DescribeSnapshotPolicy")
+
+	rac := resourceAttrCheckInit(rc, ra)
+
+	testAccCheck := rac.resourceAttrMapUpdateSet()
+	testAccConfig := resourceTestAccConfigFunc(resourceId, name, resourceSnapshotPolicyAttachmentConfigDependence)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		// module name
+		IDRefreshName: resourceId,
+
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"snapshot_policy_id": "${alicloud_snapshot_policy.default.id}",
+					"disk_ids":           []string{"${alicloud_disk.default.0.id}"},
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"disk_ids.#": "1",
+					}),
+				),
+			},
+			{
+				ResourceName:      resourceId,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"disk_ids": []string{"${alicloud_disk.default.0.id}", "${alicloud_disk.default.1.id}"},
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"disk_ids.#": "2",
+					}),
+				),
+			},
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"disk_ids": []string{"${alicloud_disk.default.1.id}"},
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"disk_ids.#": "1",
+					}),
+				),
+			},
+		},
+	})
+}
+
+// TestUnitAlicloudSnapshotPolicyAttachment checks only the changed disks are applied and cancelled,
+// against the local fake cloud.
+func TestUnitAlicloudSnapshotPolicyAttachment(t *testing.T) {
+	fc := newFakeCloud(t, connectivity.ECSCode)
+	defer fc.Close()
+
+	policyId := "sp-fake0001"
+	disks := map[string]string{"d-fake0001": "", "d-fake0002": "", "d-fake0003": ""}
+
+	fc.HandleRPC(connectivity.ECSCode, "DescribeAutoSnapshotPolicyEx", func(request *fakeCloudRequest) (int, interface{}) {
+		return 200, map[string]interface{}{
+			"RequestId": "fake-request",
+			"AutoSnapshotPolicies": map[string]interface{}{
+				"AutoSnapshotPolicy": []map[string]interface{}{
+					{"AutoSnapshotPolicyId": policyId, "Status": string(SnapshotPolicyNormal)},
+				},
+			},
+		}
+	})
+	fc.HandleRPC(connectivity.ECSCode, "DescribeDisks", func(request *fakeCloudRequest) (int, interface{}) {
+		var matched []map[string]interface{}
+		for id, policy := range disks {
+			if policy == request.Param("AutoSnapshotPolicyId") {
+				matched = append(matched, map[string]interface{}{"DiskId": id, "AutoSnapshotPolicyId": policy})
+			}
+		}
+		return 200, map[string]interface{}{
+			"RequestId":  "fake-request",
+			"TotalCount": len(matched),
+			"Disks":      map[string]interface{}{"Disk": matched},
+		}
+	})
+	diskIds := func(request *fakeCloudRequest) []string {
+		var ids []string
+		if err := json.Unmarshal([]byte(request.Param("diskIds")), &ids); err != nil {
+			t.Errorf("invalid diskIds %q: %s", request.Param("diskIds"), err)
+		}
+		sort.Strings(ids)
+		return ids
+	}
+	fc.HandleRPC(connectivity.ECSCode, "ApplyAutoSnapshotPolicy", func(request *fakeCloudRequest) (int, interface{}) {
+		for _, id := range diskIds(request) {
+			disks[id] = request.Param("autoSnapshotPolicyId")
+		}
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	})
+	fc.HandleRPC(connectivity.ECSCode, "CancelAutoSnapshotPolicy", func(request *fakeCloudRequest) (int, interface{}) {
+		for _, id := range diskIds(request) {
+			disks[id] = ""
+		}
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	})
+
+	checkRequests := func(action string, expected ...string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			var actual []string
+			for _, request := range fc.Requests(connectivity.ECSCode, action) {
+				actual = append(actual, strings.Join(diskIds(request), ","))
+			}
+			if strings.Join(actual, " ") != strings.Join(expected, " ") {
+				return fmt.Errorf("expected %s on %v, got %v", action, expected, actual)
+			}
+			return nil
+		}
+	}
+
+	resourceId := "alicloud_snapshot_policy_attachment.default"
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		CheckDestroy: func(*terraform.State) error {
+			for id, policy := range disks {
+				if policy != "" {
+					return fmt.Errorf("the policy %s is still applied to the disk %s", policy, id)
+				}
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSnapshotPolicyAttachmentConfigFake(fc, "d-fake0001", "d-fake0002"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "disk_ids.#", "2"),
+					checkRequests("ApplyAutoSnapshotPolicy", "d-fake0001,d-fake0002"),
+				),
+			},
+			{
+				Config: testAccSnapshotPolicyAttachmentConfigFake(fc, "d-fake0002", "d-fake0003"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "disk_ids.#", "2"),
+					checkRequests("ApplyAutoSnapshotPolicy", "d-fake0001,d-fake0002", "d-fake0003"),
+					checkRequests("CancelAutoSnapshotPolicy", "d-fake0001"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSnapshotPolicyAttachmentConfigFake(fc *fakeCloud, diskIds ...string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "alicloud_snapshot_policy_attachment" "default" {
+  snapshot_policy_id = "sp-fake0001"
+  disk_ids           = ["%s"]
+}
+`, fc.ProviderConfig(), strings.Join(diskIds, `", "`))
+}
+
+func resourceSnapshotPolicyAttachmentConfigDependence(name string) string {
+	return fmt.Sprintf(`
+variable "name" {
+  default = "%s"
+}
+
+data "alicloud_zones" "default" {
+  available_disk_category = "cloud_efficiency"
+}
+
+resource "alicloud_snapshot_policy" "default" {
+  name = "${var.name}"
+  repeat_weekdays = ["1"]
+  retention_days = "-1"
+  time_points = ["1"]
+}
+
+resource "alicloud_disk" "default" {
+  count = 2
+  availability_zone = "${data.alicloud_zones.default.zones.0.id}"
+  name = "${var.name}"
+  size = 20
+}
+`, name)
+}
@@ -21,6 +21,7 @@ func resourceAliyunVpc() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: resourceAliyunVpcCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"cidr_block": {
@@ -29,6 +30,16 @@ func resourceAliyunVpc() *schema.Resource {
 				ForceNew:     true,
 				ValidateFunc: validateCIDRNetworkAddress,
 			},
+			"enable_ipv6": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"ipv6_cidr_block": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
 			"resource_group_id": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -68,10 +79,41 @@ func resourceAliyunVpc() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 		},
 	}
 }
 
+// resourceAliyunVpcCustomizeDiff replaces the VPC when IPv6 is disabled or its allocated IPv6 CIDR block is changed,
+// since the IPv6 CIDR block of a VPC cannot be released once it is allocated, and plans its tags_all by the provider
+// default_tags. The ipv6_cidr_block can only be set with enable_ipv6, and it is allocated when IPv6 is enabled in place.
+func resourceAliyunVpcCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	enableIpv6, ipv6CidrBlock := d.Get("enable_ipv6").(bool), d.Get("ipv6_cidr_block").(string)
+	if !enableIpv6 && ipv6CidrBlock != "" && (d.Id() == "" || d.HasChange("ipv6_cidr_block")) {
+		return WrapError(Error("The ipv6_cidr_block %s requires enable_ipv6 to be true.", ipv6CidrBlock))
+	}
+	if d.Id() != "" {
+		if d.HasChange("enable_ipv6") && !enableIpv6 {
+			if err := d.ForceNew("enable_ipv6"); err != nil {
+				return err
+			}
+		}
+		if d.HasChange("ipv6_cidr_block") {
+			if old, _ := d.GetChange("ipv6_cidr_block"); old.(string) != "" || !d.HasChange("enable_ipv6") {
+				if err := d.ForceNew("ipv6_cidr_block"); err != nil {
+					return err
+				}
+			}
+		} else if d.HasChange("enable_ipv6") && enableIpv6 && ipv6CidrBlock == "" {
+			if err := d.SetNewComputed("ipv6_cidr_block"); err != nil {
+				return err
+			}
+		}
+	}
+	return defaultTagsCustomizeDiff(d, meta)
+}
+
 func resourceAliyunVpcCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*connectivity.AliyunClient)
 	vpcService := VpcService{client}
@@ -108,7 +150,7 @@ func resourceAliyunVpcCreate(d *schema.ResourceData, meta interface{}) error {
 		return WrapError(err)
 	}
 
-	return resourceAliyunVpcRead(d, meta)
+	return resourceAliyunVpcUpdate(d, meta)
 }
 
 func resourceAliyunVpcRead(d *schema.ResourceData, meta interface{}) error {
@@ -125,11 +167,20 @@ func resourceAliyunVpcRead(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	d.Set("cidr_block", object.CidrBlock)
+	d.Set("enable_ipv6", object.Ipv6CidrBlock != "")
+	d.Set("ipv6_cidr_block", object.Ipv6CidrBlock)
 	d.Set("name", object.VpcName)
 	d.Set("description", object.Description)
 	d.Set("router_id", object.VRouterId)
 	d.Set("resource_group_id", object.ResourceGroupId)
 
+	tagService := TagService{client}
+	tags, err := tagService.ListTags(d.Id(), TagResourceVpc)
+	if err != nil {
+		return WrapError(err)
+	}
+	setTagsWithDefaults(client, d, tags)
+
 	// Retrieve all route tables and filter to get system
 	request := vpc.CreateDescribeRouteTablesRequest()
 	request.RegionId = client.RegionId
@@ -180,6 +231,19 @@ func resourceAliyunVpcRead(d *schema.ResourceData, meta interface{}) error {
 
 func resourceAliyunVpcUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+	tagService := TagService{client}
+	d.Partial(true)
+
+	if err := tagService.SetTags(d, TagResourceVpc); err != nil {
+		return WrapError(err)
+	}
+	d.SetPartial("tags")
+
+	if d.IsNewResource() {
+		d.Partial(false)
+		return resourceAliyunVpcRead(d, meta)
+	}
 
 	attributeUpdate := false
 	request := vpc.CreateModifyVpcAttributeRequest()
@@ -195,6 +259,16 @@ func resourceAliyunVpcUpdate(d *schema.ResourceData, meta interface{}) error {
 		attributeUpdate = true
 	}
 
+	// IPv6 is only enabled in place, and disabling it replaces the VPC
+	if d.HasChange("enable_ipv6") {
+		request.EnableIPv6 = requests.NewBoolean(true)
+		// The IPv6 CIDR block is not supported by the ModifyVpcAttribute request of the VPC SDK yet
+		if v := d.Get("ipv6_cidr_block").(string); v != "" {
+			request.QueryParams["Ipv6CidrBlock"] = v
+		}
+		attributeUpdate = true
+	}
+
 	if attributeUpdate {
 		_, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
 			return vpcClient.ModifyVpcAttribute(request)
@@ -202,8 +276,18 @@ func resourceAliyunVpcUpdate(d *schema.ResourceData, meta interface{}) error {
 		if err != nil {
 			return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
 		}
+		d.SetPartial("name")
+		d.SetPartial("description")
+		d.SetPartial("enable_ipv6")
+		d.SetPartial("ipv6_cidr_block")
+		if d.HasChange("enable_ipv6") {
+			if err := vpcService.WaitForVpc(d.Id(), Available, DefaultTimeout); err != nil {
+				return WrapError(err)
+			}
+		}
 	}
 
+	d.Partial(false)
 	return resourceAliyunVpcRead(d, meta)
 }
 
@@ -249,6 +333,13 @@ func buildAliyunVpcArgs(d *schema.ResourceData, meta interface{}) *vpc.CreateVpc
 		request.ResourceGroupId = v
 	}
 
+	if d.Get("enable_ipv6").(bool) {
+		request.EnableIpv6 = requests.NewBoolean(true)
+		if v := d.Get("ipv6_cidr_block").(string); v != "" {
+			request.Ipv6CidrBlock = v
+		}
+	}
+
 	request.ClientToken = buildClientToken(request.GetActionName())
 
 	return request
@@ -0,0 +1,233 @@
+package alicloud
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+// resourceAlicloudVpcBgpGroup holds the BGP settings which a virtual border router shares with the peers of an autonomous system.
+func resourceAlicloudVpcBgpGroup() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAlicloudVpcBgpGroupCreate,
+		Read:   resourceAlicloudVpcBgpGroupRead,
+		Update: resourceAlicloudVpcBgpGroupUpdate,
+		Delete: resourceAlicloudVpcBgpGroupDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"router_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"peer_asn": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"auth_key": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"is_fake_asn": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateStringLengthInRange(2, 128),
+			},
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateStringLengthInRange(2, 256),
+			},
+			"local_asn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAlicloudVpcBgpGroupCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+
+	request := vpc.CreateCreateBgpGroupRequest()
+	request.RegionId = client.RegionId
+	request.RouterId = d.Get("router_id").(string)
+	request.PeerAsn = requests.NewInteger(d.Get("peer_asn").(int))
+	request.AuthKey = d.Get("auth_key").(string)
+	request.IsFakeAsn = requests.NewBoolean(d.Get("is_fake_asn").(bool))
+	request.Name = d.Get("name").(string)
+	request.Description = d.Get("description").(string)
+	request.ClientToken = buildClientToken(request.GetActionName())
+
+	var raw interface{}
+	err := resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+		var err error
+		raw, err = client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.CreateBgpGroup(request)
+		})
+		if err != nil {
+			// The virtual border router is locked while an other BGP group or peer of it is changing
+			if IsExceptedErrors(err, []string{IncorrectStatus, TaskConflict, Throttling}) {
+				time.Sleep(5 * time.Second)
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, "alicloud_vpc_bgp_group", request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+	response := raw.(*vpc.CreateBgpGroupResponse)
+	d.SetId(response.BgpGroupId)
+
+	stateConf := BuildStateConf([]string{"", string(Pending), string(Modifying)}, []string{string(Available)}, d.Timeout(schema.TimeoutCreate), 3*time.Second,
+		vpcService.VpcBgpGroupStateRefreshFunc(d.Id(), []string{}))
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+
+	return resourceAlicloudVpcBgpGroupRead(d, meta)
+}
+
+func resourceAlicloudVpcBgpGroupRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+	object, err := vpcService.DescribeVpcBgpGroup(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	peerAsn, err := strconv.Atoi(object.PeerAsn)
+	if err != nil {
+		return WrapError(err)
+	}
+	d.Set("router_id", object.RouterId)
+	d.Set("peer_asn", peerAsn)
+	d.Set("auth_key", object.AuthKey)
+	d.Set("is_fake_asn", object.IsFake == "true")
+	d.Set("name", object.Name)
+	d.Set("description", object.Description)
+	d.Set("local_asn", object.LocalAsn)
+	d.Set("status", object.Status)
+	return nil
+}
+
+func resourceAlicloudVpcBgpGroupUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+
+	update := false
+	request := vpc.CreateModifyBgpGroupAttributeRequest()
+	request.RegionId = client.RegionId
+	request.BgpGroupId = d.Id()
+	request.ClientToken = buildClientToken(request.GetActionName())
+
+	if d.HasChange("peer_asn") {
+		request.PeerAsn = requests.NewInteger(d.Get("peer_asn").(int))
+		update = true
+	}
+	if d.HasChange("auth_key") {
+		request.AuthKey = d.Get("auth_key").(string)
+		update = true
+	}
+	if d.HasChange("is_fake_asn") {
+		request.IsFakeAsn = requests.NewBoolean(d.Get("is_fake_asn").(bool))
+		update = true
+	}
+	if d.HasChange("name") {
+		request.Name = d.Get("name").(string)
+		update = true
+	}
+	if d.HasChange("description") {
+		request.Description = d.Get("description").(string)
+		update = true
+	}
+
+	if update {
+		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.ModifyBgpGroupAttribute(request)
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw)
+
+		stateConf := BuildStateConf([]string{string(Modifying)}, []string{string(Available)}, d.Timeout(schema.TimeoutUpdate), 3*time.Second,
+			vpcService.VpcBgpGroupStateRefreshFunc(d.Id(), []string{}))
+		if _, err := stateConf.WaitForState(); err != nil {
+			return WrapErrorf(err, IdMsg, d.Id())
+		}
+	}
+
+	return resourceAlicloudVpcBgpGroupRead(d, meta)
+}
+
+func resourceAlicloudVpcBgpGroupDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+
+	request := vpc.CreateDeleteBgpGroupRequest()
+	request.RegionId = client.RegionId
+	request.BgpGroupId = d.Id()
+	request.ClientToken = buildClientToken(request.GetActionName())
+	err := resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.DeleteBgpGroup(request)
+		})
+		if err != nil {
+			// The peers of the group are released asynchronously
+			if IsExceptedErrors(err, []string{IncorrectStatus, TaskConflict, Throttling}) {
+				time.Sleep(5 * time.Second)
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw)
+		return nil
+	})
+	if err != nil {
+		if NotFoundError(err) {
+			return nil
+		}
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+
+	stateConf := BuildStateConf([]string{string(Available), string(Deleting)}, []string{}, d.Timeout(schema.TimeoutDelete), 3*time.Second,
+		vpcService.VpcBgpGroupStateRefreshFunc(d.Id(), []string{}))
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+	return nil
+}
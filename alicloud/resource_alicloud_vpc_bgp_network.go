@@ -0,0 +1,147 @@
+package alicloud
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+// resourceAlicloudVpcBgpNetwork advertises a CIDR block to the BGP peers of a virtual border router.
+// Its id is spliced from the router ID and the CIDR block.
+func resourceAlicloudVpcBgpNetwork() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAlicloudVpcBgpNetworkCreate,
+		Read:   resourceAlicloudVpcBgpNetworkRead,
+		Delete: resourceAlicloudVpcBgpNetworkDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"router_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"dst_cidr_block": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCIDRNetworkAddress,
+			},
+			"vpc_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceAlicloudVpcBgpNetworkCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+
+	request := vpc.CreateAddBgpNetworkRequest()
+	request.RegionId = client.RegionId
+	request.RouterId = d.Get("router_id").(string)
+	request.DstCidrBlock = d.Get("dst_cidr_block").(string)
+	request.VpcId = d.Get("vpc_id").(string)
+	request.ClientToken = buildClientToken(request.GetActionName())
+
+	err := resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.AddBgpNetwork(request)
+		})
+		if err != nil {
+			if IsExceptedErrors(err, []string{IncorrectStatus, TaskConflict, Throttling}) {
+				time.Sleep(5 * time.Second)
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw)
+		return nil
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, "alicloud_vpc_bgp_network", request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	d.SetId(fmt.Sprintf("%s%s%s", request.RouterId, COLON_SEPARATED, request.DstCidrBlock))
+
+	return resourceAlicloudVpcBgpNetworkRead(d, meta)
+}
+
+func resourceAlicloudVpcBgpNetworkRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+	object, err := vpcService.DescribeVpcBgpNetwork(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	d.Set("router_id", object.RouterId)
+	d.Set("dst_cidr_block", object.DstCidrBlock)
+	d.Set("vpc_id", object.VpcId)
+	return nil
+}
+
+func resourceAlicloudVpcBgpNetworkDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+	parts, err := ParseResourceId(d.Id(), 2)
+	if err != nil {
+		return WrapError(err)
+	}
+
+	request := vpc.CreateDeleteBgpNetworkRequest()
+	request.RegionId = client.RegionId
+	request.RouterId = parts[0]
+	request.DstCidrBlock = parts[1]
+	request.ClientToken = buildClientToken(request.GetActionName())
+	err = resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.DeleteBgpNetwork(request)
+		})
+		if err != nil {
+			if IsExceptedErrors(err, []string{IncorrectStatus, TaskConflict, Throttling}) {
+				time.Sleep(5 * time.Second)
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw)
+		return nil
+	})
+	if err != nil {
+		if NotFoundError(err) {
+			return nil
+		}
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+
+	// The network is withdrawn from the peers asynchronously
+	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		if _, err := vpcService.DescribeVpcBgpNetwork(d.Id()); err != nil {
+			if NotFoundError(err) {
+				return nil
+			}
+			return resource.NonRetryableError(WrapError(err))
+		}
+		time.Sleep(3 * time.Second)
+		return resource.RetryableError(WrapErrorf(err, DeleteTimeoutMsg, d.Id(), request.GetActionName(), ProviderERROR))
+	})
+}
@@ -0,0 +1,153 @@
+package alicloud
+
+import (
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+// resourceAlicloudVpcBgpPeer is a BGP session between a virtual border router and an address of the peer autonomous system.
+// The peer can not be modified in place, so all of its arguments are ForceNew.
+func resourceAlicloudVpcBgpPeer() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAlicloudVpcBgpPeerCreate,
+		Read:   resourceAlicloudVpcBgpPeerRead,
+		Delete: resourceAlicloudVpcBgpPeerDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"bgp_group_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"peer_ip_address": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"router_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"bgp_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAlicloudVpcBgpPeerCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+
+	request := vpc.CreateCreateBgpPeerRequest()
+	request.RegionId = client.RegionId
+	request.BgpGroupId = d.Get("bgp_group_id").(string)
+	request.PeerIpAddress = d.Get("peer_ip_address").(string)
+	request.ClientToken = buildClientToken(request.GetActionName())
+
+	var raw interface{}
+	err := resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+		var err error
+		raw, err = client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.CreateBgpPeer(request)
+		})
+		if err != nil {
+			// The BGP group is locked until it becomes available
+			if IsExceptedErrors(err, []string{IncorrectStatus, TaskConflict, Throttling}) {
+				time.Sleep(5 * time.Second)
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, "alicloud_vpc_bgp_peer", request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+	response := raw.(*vpc.CreateBgpPeerResponse)
+	d.SetId(response.BgpPeerId)
+
+	stateConf := BuildStateConf([]string{"", string(Pending), string(Modifying)}, []string{string(Available)}, d.Timeout(schema.TimeoutCreate), 3*time.Second,
+		vpcService.VpcBgpPeerStateRefreshFunc(d.Id(), []string{}))
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+
+	return resourceAlicloudVpcBgpPeerRead(d, meta)
+}
+
+func resourceAlicloudVpcBgpPeerRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+	object, err := vpcService.DescribeVpcBgpPeer(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	d.Set("bgp_group_id", object.BgpGroupId)
+	d.Set("peer_ip_address", object.PeerIpAddress)
+	d.Set("router_id", object.RouterId)
+	d.Set("bgp_status", object.BgpStatus)
+	d.Set("status", object.Status)
+	return nil
+}
+
+func resourceAlicloudVpcBgpPeerDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+
+	request := vpc.CreateDeleteBgpPeerRequest()
+	request.RegionId = client.RegionId
+	request.BgpPeerId = d.Id()
+	request.ClientToken = buildClientToken(request.GetActionName())
+	err := resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.DeleteBgpPeer(request)
+		})
+		if err != nil {
+			if IsExceptedErrors(err, []string{IncorrectStatus, TaskConflict, Throttling}) {
+				time.Sleep(5 * time.Second)
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw)
+		return nil
+	})
+	if err != nil {
+		if NotFoundError(err) {
+			return nil
+		}
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+
+	stateConf := BuildStateConf([]string{string(Available), string(Deleting)}, []string{}, d.Timeout(schema.TimeoutDelete), 3*time.Second,
+		vpcService.VpcBgpPeerStateRefreshFunc(d.Id(), []string{}))
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package alicloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func TestAccAlicloudVpcBgpPeerBasic(t *testing.T) {
+	var v *vpc.BgpPeer
+	resourceId := "alicloud_vpc_bgp_peer.default"
+	rand := acctest.RandIntRange(10000, 99999)
+	name := fmt.Sprintf("tf-testacc-bgp-peer-%d", rand)
+	ra := resourceAttrInit(resourceId, map[string]string{
+		"bgp_group_id":    CHECKSET,
+		"peer_ip_address": "10.0.0.2",
+		"router_id":       CHECKSET,
+		"bgp_status":      CHECKSET,
+		"status":          string(Available),
+	})
+
+	serviceFunc := func() interface{} {
+		return &VpcService{testAccProvider.Meta().(*connectivity.AliyunClient)}
+	}
+	rc := resourceCheckInit(resourceId, &v, serviceFunc)
+
+	rac := resourceAttrCheckInit(rc, ra)
+
+	testAccCheck := rac.resourceAttrMapUpdateSet()
+	testAccConfig := resourceTestAccConfigFunc(resourceId, name, resourceVpcBgpPeerConfigDependence)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckWithPhysicalConnectionSetting(t)
+		},
+
+		// module name
+		IDRefreshName: resourceId,
+
+		Providers:    testAccProviders,
+		CheckDestroy: rac.checkResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"bgp_group_id":    "${alicloud_vpc_bgp_group.default.id}",
+					"peer_ip_address": "${alicloud_express_connect_virtual_border_router.default.peer_gateway_ip}",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(nil),
+				),
+			},
+			{
+				ResourceName:      resourceId,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func resourceVpcBgpPeerConfigDependence(name string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "alicloud_vpc_bgp_group" "default" {
+  router_id = "${alicloud_express_connect_virtual_border_router.default.id}"
+  peer_asn  = 65001
+  name      = "${var.name}"
+}
+`, resourceVpcBgpGroupConfigDependence(name))
+}
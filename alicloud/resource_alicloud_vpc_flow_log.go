@@ -0,0 +1,251 @@
+package alicloud
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+// resourceAlicloudVpcFlowLog captures the traffic of a VPC, a VSwitch or an ENI and delivers it to a log store.
+func resourceAlicloudVpcFlowLog() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAlicloudVpcFlowLogCreate,
+		Read:   resourceAlicloudVpcFlowLogRead,
+		Update: resourceAlicloudVpcFlowLogUpdate,
+		Delete: resourceAlicloudVpcFlowLogDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		CustomizeDiff: resourceAlicloudVpcFlowLogCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateStringLengthInRange(2, 128),
+			},
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateStringLengthInRange(2, 256),
+			},
+			"resource_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateAllowedStringValue([]string{"VPC", "VSwitch", "NetworkInterface"}),
+			},
+			"resource_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"traffic_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateAllowedStringValue([]string{"All", "Allow", "Drop"}),
+			},
+			"project_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"log_store_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"status": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      string(Active),
+				ValidateFunc: validateAllowedStringValue([]string{string(Active), string(Inactive)}),
+			},
+		},
+	}
+}
+
+// resourceAlicloudVpcFlowLogCustomizeDiff checks the log store at plan time. The store is not checked when its name is
+// unknown, and a missing store does not fail the plan, because it can be created in the same run even when its name is
+// known already. Create checks the store again before the flow log is created.
+func resourceAlicloudVpcFlowLogCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("project_name") || !d.NewValueKnown("log_store_name") {
+		return nil
+	}
+	if d.Id() != "" && !d.HasChange("project_name") && !d.HasChange("log_store_name") {
+		return nil
+	}
+	if err := checkVpcFlowLogStore(meta, d.Get("project_name").(string), d.Get("log_store_name").(string)); err != nil {
+		if NotFoundError(err) {
+			log.Printf("[WARN] %s It must be created before the flow log.", err)
+			return nil
+		}
+		return WrapError(err)
+	}
+	return nil
+}
+
+// checkVpcFlowLogStore returns a not found error naming the project and the log store when the store does not exist.
+func checkVpcFlowLogStore(meta interface{}, projectName, logStoreName string) error {
+	client := meta.(*connectivity.AliyunClient)
+	logService := LogService{client}
+
+	if _, err := logService.DescribeLogStore(fmt.Sprintf("%s%s%s", projectName, COLON_SEPARATED, logStoreName)); err != nil {
+		if NotFoundError(err) {
+			return WrapErrorf(Error("The log store %s of the project %s does not exist.", logStoreName, projectName), NotFoundMsg, ProviderERROR)
+		}
+		return WrapError(err)
+	}
+	return nil
+}
+
+func resourceAlicloudVpcFlowLogCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+
+	request := vpc.CreateCreateFlowLogRequest()
+	request.RegionId = client.RegionId
+	request.FlowLogName = d.Get("name").(string)
+	request.Description = d.Get("description").(string)
+	request.ResourceType = d.Get("resource_type").(string)
+	request.ResourceId = d.Get("resource_id").(string)
+	request.TrafficType = d.Get("traffic_type").(string)
+	request.ProjectName = d.Get("project_name").(string)
+	request.LogStoreName = d.Get("log_store_name").(string)
+	if err := checkVpcFlowLogStore(meta, request.ProjectName, request.LogStoreName); err != nil {
+		return WrapError(err)
+	}
+
+	raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+		return vpcClient.CreateFlowLog(request)
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, "alicloud_vpc_flow_log", request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+	response := raw.(*vpc.CreateFlowLogResponse)
+	d.SetId(response.FlowLogId)
+
+	stateConf := BuildStateConf([]string{"", string(FlowLogActivating)}, []string{string(Active)}, d.Timeout(schema.TimeoutCreate), 3*time.Second,
+		vpcService.VpcFlowLogStateRefreshFunc(d.Id(), []string{}))
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+
+	return resourceAlicloudVpcFlowLogUpdate(d, meta)
+}
+
+func resourceAlicloudVpcFlowLogRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+	object, err := vpcService.DescribeVpcFlowLog(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	d.Set("name", object.FlowLogName)
+	d.Set("description", object.Description)
+	d.Set("resource_type", object.ResourceType)
+	d.Set("resource_id", object.ResourceId)
+	d.Set("traffic_type", object.TrafficType)
+	d.Set("project_name", object.ProjectName)
+	d.Set("log_store_name", object.LogStoreName)
+	d.Set("status", object.Status)
+	return nil
+}
+
+func resourceAlicloudVpcFlowLogUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+
+	if !d.IsNewResource() && (d.HasChange("name") || d.HasChange("description")) {
+		request := vpc.CreateModifyFlowLogAttributeRequest()
+		request.RegionId = client.RegionId
+		request.FlowLogId = d.Id()
+		request.FlowLogName = d.Get("name").(string)
+		request.Description = d.Get("description").(string)
+		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.ModifyFlowLogAttribute(request)
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw)
+	}
+
+	// The flow log is active once it is created
+	if status := d.Get("status").(string); d.HasChange("status") && !(d.IsNewResource() && status == string(Active)) {
+		var raw interface{}
+		var err error
+		var action string
+		if status == string(Active) {
+			request := vpc.CreateActiveFlowLogRequest()
+			request.RegionId = client.RegionId
+			request.FlowLogId = d.Id()
+			action = request.GetActionName()
+			raw, err = client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+				return vpcClient.ActiveFlowLog(request)
+			})
+		} else {
+			request := vpc.CreateDeactiveFlowLogRequest()
+			request.RegionId = client.RegionId
+			request.FlowLogId = d.Id()
+			action = request.GetActionName()
+			raw, err = client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+				return vpcClient.DeactiveFlowLog(request)
+			})
+		}
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, d.Id(), action, AlibabaCloudSdkGoERROR)
+		}
+		addDebug(action, raw)
+
+		stateConf := BuildStateConf([]string{string(FlowLogActivating), string(Active), string(Inactive)}, []string{status}, d.Timeout(schema.TimeoutUpdate), 3*time.Second,
+			vpcService.VpcFlowLogStateRefreshFunc(d.Id(), []string{}))
+		if _, err := stateConf.WaitForState(); err != nil {
+			return WrapErrorf(err, IdMsg, d.Id())
+		}
+	}
+
+	return resourceAlicloudVpcFlowLogRead(d, meta)
+}
+
+func resourceAlicloudVpcFlowLogDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+
+	request := vpc.CreateDeleteFlowLogRequest()
+	request.RegionId = client.RegionId
+	request.FlowLogId = d.Id()
+	raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+		return vpcClient.DeleteFlowLog(request)
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+
+	stateConf := BuildStateConf([]string{string(FlowLogActivating), string(Active), string(Inactive)}, []string{}, d.Timeout(schema.TimeoutDelete), 3*time.Second,
+		vpcService.VpcFlowLogStateRefreshFunc(d.Id(), []string{}))
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+	return nil
+}
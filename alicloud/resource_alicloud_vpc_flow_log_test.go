@@ -0,0 +1,259 @@
+package alicloud
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func TestAccAlicloudVpcFlowLogBasic(t *testing.T) {
+	var v *vpc.FlowLog
+	resourceId := "alicloud_vpc_flow_log.default"
+	rand := acctest.RandIntRange(10000, 99999)
+	name := fmt.Sprintf("tf-testacc-vpc-flow-log-%d", rand)
+	ra := resourceAttrInit(resourceId, map[string]string{
+		"name":           name,
+		"resource_type":  "VPC",
+		"resource_id":    CHECKSET,
+		"traffic_type":   "All",
+		"project_name":   name,
+		"log_store_name": name,
+		"status":         string(Active),
+	})
+
+	serviceFunc := func() interface{} {
+		return &VpcService{testAccProvider.Meta().(*connectivity.AliyunClient)}
+	}
+	rc := resourceCheckInit(resourceId, &v, serviceFunc)
+
+	rac := resourceAttrCheckInit(rc, ra)
+
+	testAccCheck := rac.resourceAttrMapUpdateSet()
+	testAccConfig := resourceTestAccConfigFunc(resourceId, name, resourceVpcFlowLogConfigDependence)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		// module name
+		IDRefreshName: resourceId,
+
+		Providers:    testAccProviders,
+		CheckDestroy: rac.checkResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"name":           "${var.name}",
+					"resource_type":  "VPC",
+					"resource_id":    "${alicloud_vpc.default.id}",
+					"traffic_type":   "All",
+					"project_name":   "${alicloud_log_project.default.name}",
+					"log_store_name": "${alicloud_log_store.default.name}",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(nil),
+				),
+			},
+			{
+				ResourceName:      resourceId,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"description": "${var.name}",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"description": name,
+					}),
+				),
+			},
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"status": string(Inactive),
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"status": string(Inactive),
+					}),
+				),
+			},
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"status": string(Active),
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"status": string(Active),
+					}),
+				),
+			},
+		},
+	})
+}
+
+// TestUnitAlicloudVpcFlowLog checks a missing log store is reported, a log store can be created together with the
+// flow log and the status is toggled, against the local fake cloud.
+func TestUnitAlicloudVpcFlowLog(t *testing.T) {
+	fc := newFakeCloud(t, connectivity.VPCCode, connectivity.LOGCode)
+	defer fc.Close()
+
+	flowLog := map[string]interface{}{}
+	fc.HandleROA(connectivity.LOGCode, "GET", "/tf-flow-log/logstores/default", func(request *fakeCloudRequest) (int, interface{}) {
+		return 200, map[string]interface{}{"logstoreName": "default", "ttl": 7, "shardCount": 2}
+	})
+	fc.HandleROA(connectivity.LOGCode, "GET", "/tf-flow-log/logstores/missing", func(request *fakeCloudRequest) (int, interface{}) {
+		return 404, map[string]interface{}{"errorCode": LogStoreNotExist, "errorMessage": "logstore missing does not exist"}
+	})
+	// The log store "created" does not exist until it is created in the same run as the flow log
+	created := false
+	fc.HandleROA(connectivity.LOGCode, "POST", "/tf-flow-log/logstores", func(request *fakeCloudRequest) (int, interface{}) {
+		created = true
+		return 200, map[string]interface{}{}
+	})
+	fc.HandleROA(connectivity.LOGCode, "GET", "/tf-flow-log/logstores/created", func(request *fakeCloudRequest) (int, interface{}) {
+		if !created {
+			return 404, map[string]interface{}{"errorCode": LogStoreNotExist, "errorMessage": "logstore created does not exist"}
+		}
+		return 200, map[string]interface{}{"logstoreName": "created", "ttl": 30, "shardCount": 2, "appendMeta": true}
+	})
+	fc.HandleROA(connectivity.LOGCode, "DELETE", "/tf-flow-log/logstores/created", func(request *fakeCloudRequest) (int, interface{}) {
+		created = false
+		return 200, map[string]interface{}{}
+	})
+	fc.HandleROA(connectivity.LOGCode, "GET", "/tf-flow-log/", func(request *fakeCloudRequest) (int, interface{}) {
+		return 200, map[string]interface{}{"projectName": "tf-flow-log", "status": "Normal"}
+	})
+	fc.HandleROA(connectivity.LOGCode, "GET", "/tf-flow-log/logstores/created/shards", func(request *fakeCloudRequest) (int, interface{}) {
+		return 200, []interface{}{}
+	})
+	fc.HandleRPC(connectivity.VPCCode, "CreateFlowLog", func(request *fakeCloudRequest) (int, interface{}) {
+		flowLog = map[string]interface{}{
+			"FlowLogId":    "fl-fake0001",
+			"FlowLogName":  request.Param("FlowLogName"),
+			"ResourceType": request.Param("ResourceType"),
+			"ResourceId":   request.Param("ResourceId"),
+			"TrafficType":  request.Param("TrafficType"),
+			"ProjectName":  request.Param("ProjectName"),
+			"LogStoreName": request.Param("LogStoreName"),
+			"Status":       string(Active),
+		}
+		return 200, map[string]interface{}{"RequestId": "fake-request", "Success": "true", "FlowLogId": "fl-fake0001"}
+	})
+	fc.HandleRPC(connectivity.VPCCode, "DescribeFlowLogs", func(request *fakeCloudRequest) (int, interface{}) {
+		var flowLogs []map[string]interface{}
+		if flowLog["FlowLogId"] == request.Param("FlowLogId") {
+			flowLogs = append(flowLogs, flowLog)
+		}
+		return 200, map[string]interface{}{
+			"RequestId": "fake-request",
+			"FlowLogs":  map[string]interface{}{"FlowLog": flowLogs},
+		}
+	})
+	fc.HandleRPC(connectivity.VPCCode, "DeactiveFlowLog", func(request *fakeCloudRequest) (int, interface{}) {
+		flowLog["Status"] = string(Inactive)
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	})
+	fc.HandleRPC(connectivity.VPCCode, "ActiveFlowLog", func(request *fakeCloudRequest) (int, interface{}) {
+		flowLog["Status"] = string(Active)
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	})
+	fc.HandleRPC(connectivity.VPCCode, "DeleteFlowLog", func(request *fakeCloudRequest) (int, interface{}) {
+		flowLog = map[string]interface{}{}
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	})
+
+	resourceId := "alicloud_vpc_flow_log.default"
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		CheckDestroy: func(*terraform.State) error {
+			if len(flowLog) > 0 {
+				return fmt.Errorf("the flow log %s is not deleted", flowLog["FlowLogId"])
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				// The missing log store only logs a warning at plan time, and fails the creation of the flow log
+				Config:      testAccVpcFlowLogConfigFake(fc, "missing", string(Active)),
+				ExpectError: regexp.MustCompile("The log store missing of the project tf-flow-log does not exist"),
+			},
+			{
+				Config: testAccVpcFlowLogConfigFake(fc, "default", string(Active)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "status", string(Active)),
+					resource.TestCheckResourceAttr(resourceId, "log_store_name", "default"),
+					fc.CheckRequests(connectivity.VPCCode, "CreateFlowLog", 1, nil),
+					fc.CheckRequests(connectivity.VPCCode, "ActiveFlowLog", 0, nil),
+				),
+			},
+			{
+				Config: testAccVpcFlowLogConfigFake(fc, "default", string(Inactive)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "status", string(Inactive)),
+					fc.CheckRequests(connectivity.VPCCode, "DeactiveFlowLog", 1, nil),
+				),
+			},
+			{
+				// The name of the log store is known at plan time, but the store is only created on apply
+				Config: testAccVpcFlowLogConfigFake(fc, "${alicloud_log_store.default.name}", string(Active)) + `
+resource "alicloud_log_store" "default" {
+  project = "tf-flow-log"
+  name    = "created"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "log_store_name", "created"),
+					fc.CheckRequests(connectivity.VPCCode, "CreateFlowLog", 2, map[string]string{"LogStoreName": "created"}),
+				),
+			},
+		},
+	})
+}
+
+func testAccVpcFlowLogConfigFake(fc *fakeCloud, logStoreName, status string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "alicloud_vpc_flow_log" "default" {
+  name           = "tf-flow-log"
+  resource_type  = "VPC"
+  resource_id    = "vpc-fake0001"
+  traffic_type   = "All"
+  project_name   = "tf-flow-log"
+  log_store_name = "%s"
+  status         = "%s"
+}
+`, fc.ProviderConfig(), logStoreName, status)
+}
+
+func resourceVpcFlowLogConfigDependence(name string) string {
+	return fmt.Sprintf(`
+variable "name" {
+  default = "%s"
+}
+
+resource "alicloud_vpc" "default" {
+  name       = "${var.name}"
+  cidr_block = "172.16.0.0/16"
+}
+
+resource "alicloud_log_project" "default" {
+  name = "${var.name}"
+}
+
+resource "alicloud_log_store" "default" {
+  project = "${alicloud_log_project.default.name}"
+  name    = "${var.name}"
+}
+`, name)
+}
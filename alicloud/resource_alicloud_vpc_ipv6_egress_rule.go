@@ -0,0 +1,173 @@
+package alicloud
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+// resourceAlicloudVpcIpv6EgressRule lets an IPv6 address reach the Internet through the IPv6 gateway,
+// while the Internet can not reach the address.
+func resourceAlicloudVpcIpv6EgressRule() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAlicloudVpcIpv6EgressRuleCreate,
+		Read:   resourceAlicloudVpcIpv6EgressRuleRead,
+		Delete: resourceAlicloudVpcIpv6EgressRuleDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"ipv6_gateway_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"instance_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"instance_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "IPv6Address",
+				ValidateFunc: validateAllowedStringValue([]string{"IPv6Address"}),
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateStringLengthInRange(2, 128),
+			},
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateStringLengthInRange(2, 256),
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAlicloudVpcIpv6EgressRuleCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+
+	request := vpc.CreateCreateIpv6EgressOnlyRuleRequest()
+	request.RegionId = client.RegionId
+	request.Ipv6GatewayId = d.Get("ipv6_gateway_id").(string)
+	request.InstanceId = d.Get("instance_id").(string)
+	request.InstanceType = d.Get("instance_type").(string)
+	request.Name = d.Get("name").(string)
+	request.Description = d.Get("description").(string)
+	request.ClientToken = buildClientToken(request.GetActionName())
+
+	var raw interface{}
+	err := resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+		var err error
+		raw, err = client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.CreateIpv6EgressOnlyRule(request)
+		})
+		if err != nil {
+			if IsExceptedErrors(err, []string{IncorrectStatus, TaskConflict, Throttling}) {
+				time.Sleep(5 * time.Second)
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, "alicloud_vpc_ipv6_egress_rule", request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+	response := raw.(*vpc.CreateIpv6EgressOnlyRuleResponse)
+	d.SetId(fmt.Sprintf("%s%s%s", request.Ipv6GatewayId, COLON_SEPARATED, response.Ipv6EgressRuleId))
+
+	stateConf := BuildStateConf([]string{"", string(Pending), string(Creating)}, []string{string(Available)}, d.Timeout(schema.TimeoutCreate), 3*time.Second,
+		vpcService.VpcIpv6EgressRuleStateRefreshFunc(d.Id(), []string{}))
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+
+	return resourceAlicloudVpcIpv6EgressRuleRead(d, meta)
+}
+
+func resourceAlicloudVpcIpv6EgressRuleRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+	object, err := vpcService.DescribeVpcIpv6EgressRule(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+	parts, err := ParseResourceId(d.Id(), 2)
+	if err != nil {
+		return WrapError(err)
+	}
+
+	d.Set("ipv6_gateway_id", parts[0])
+	d.Set("instance_id", object.InstanceId)
+	d.Set("instance_type", object.InstanceType)
+	d.Set("name", object.Name)
+	d.Set("description", object.Description)
+	d.Set("status", object.Status)
+	return nil
+}
+
+func resourceAlicloudVpcIpv6EgressRuleDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+	parts, err := ParseResourceId(d.Id(), 2)
+	if err != nil {
+		return WrapError(err)
+	}
+
+	request := vpc.CreateDeleteIpv6EgressOnlyRuleRequest()
+	request.RegionId = client.RegionId
+	request.Ipv6EgressOnlyRuleId = parts[1]
+	request.ClientToken = buildClientToken(request.GetActionName())
+	err = resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.DeleteIpv6EgressOnlyRule(request)
+		})
+		if err != nil {
+			if IsExceptedErrors(err, []string{IncorrectStatus, TaskConflict, Throttling}) {
+				time.Sleep(5 * time.Second)
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw)
+		return nil
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+
+	stateConf := BuildStateConf([]string{string(Available), string(Deleting)}, []string{}, d.Timeout(schema.TimeoutDelete), 3*time.Second,
+		vpcService.VpcIpv6EgressRuleStateRefreshFunc(d.Id(), []string{}))
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package alicloud
+
+import (
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+// resourceAlicloudVpcIpv6Gateway routes the IPv6 traffic between a VPC and the Internet.
+func resourceAlicloudVpcIpv6Gateway() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAlicloudVpcIpv6GatewayCreate,
+		Read:   resourceAlicloudVpcIpv6GatewayRead,
+		Update: resourceAlicloudVpcIpv6GatewayUpdate,
+		Delete: resourceAlicloudVpcIpv6GatewayDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"vpc_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateStringLengthInRange(2, 128),
+			},
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateStringLengthInRange(2, 256),
+			},
+			"spec": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "Small",
+				ValidateFunc: validateAllowedStringValue([]string{"Small", "Medium", "Large"}),
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAlicloudVpcIpv6GatewayCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+
+	request := vpc.CreateCreateIpv6GatewayRequest()
+	request.RegionId = client.RegionId
+	request.VpcId = d.Get("vpc_id").(string)
+	request.Name = d.Get("name").(string)
+	request.Description = d.Get("description").(string)
+	request.Spec = d.Get("spec").(string)
+	request.ClientToken = buildClientToken(request.GetActionName())
+
+	var raw interface{}
+	err := resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+		var err error
+		raw, err = client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.CreateIpv6Gateway(request)
+		})
+		if err != nil {
+			// The VPC is busy when its IPv6 CIDR block is just allocated
+			if IsExceptedErrors(err, []string{IncorrectVpcStatus, TaskConflict, Throttling}) {
+				time.Sleep(5 * time.Second)
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, "alicloud_vpc_ipv6_gateway", request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+	response := raw.(*vpc.CreateIpv6GatewayResponse)
+	d.SetId(response.Ipv6GatewayId)
+
+	stateConf := BuildStateConf([]string{"", string(Creating)}, []string{string(Available)}, d.Timeout(schema.TimeoutCreate), 3*time.Second,
+		vpcService.VpcIpv6GatewayStateRefreshFunc(d.Id(), []string{}))
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+
+	return resourceAlicloudVpcIpv6GatewayRead(d, meta)
+}
+
+func resourceAlicloudVpcIpv6GatewayRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+	object, err := vpcService.DescribeVpcIpv6Gateway(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	d.Set("vpc_id", object.VpcId)
+	d.Set("name", object.Name)
+	d.Set("description", object.Description)
+	d.Set("spec", object.Spec)
+	d.Set("status", object.Status)
+	return nil
+}
+
+func resourceAlicloudVpcIpv6GatewayUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+	d.Partial(true)
+
+	if d.HasChange("name") || d.HasChange("description") {
+		request := vpc.CreateModifyIpv6GatewayAttributeRequest()
+		request.RegionId = client.RegionId
+		request.Ipv6GatewayId = d.Id()
+		request.Name = d.Get("name").(string)
+		request.Description = d.Get("description").(string)
+		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.ModifyIpv6GatewayAttribute(request)
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw)
+		d.SetPartial("name")
+		d.SetPartial("description")
+	}
+
+	if d.HasChange("spec") {
+		request := vpc.CreateModifyIpv6GatewaySpecRequest()
+		request.RegionId = client.RegionId
+		request.Ipv6GatewayId = d.Id()
+		request.Spec = d.Get("spec").(string)
+		request.ClientToken = buildClientToken(request.GetActionName())
+		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.ModifyIpv6GatewaySpec(request)
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw)
+
+		stateConf := BuildStateConf([]string{string(Modifying)}, []string{string(Available)}, d.Timeout(schema.TimeoutUpdate), 3*time.Second,
+			vpcService.VpcIpv6GatewayStateRefreshFunc(d.Id(), []string{}))
+		if _, err := stateConf.WaitForState(); err != nil {
+			return WrapErrorf(err, IdMsg, d.Id())
+		}
+		d.SetPartial("spec")
+	}
+
+	d.Partial(false)
+	return resourceAlicloudVpcIpv6GatewayRead(d, meta)
+}
+
+func resourceAlicloudVpcIpv6GatewayDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+
+	request := vpc.CreateDeleteIpv6GatewayRequest()
+	request.RegionId = client.RegionId
+	request.Ipv6GatewayId = d.Id()
+	err := resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.DeleteIpv6Gateway(request)
+		})
+		if err != nil {
+			if IsExceptedErrors(err, []string{Ipv6GatewayNotFound}) {
+				return nil
+			}
+			// The egress-only rules and the Internet bandwidth of the gateway are released asynchronously
+			if IsExceptedErrors(err, []string{IncorrectStatus, TaskConflict, Throttling}) {
+				time.Sleep(5 * time.Second)
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw)
+		return nil
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+
+	stateConf := BuildStateConf([]string{string(Available), string(Deleting)}, []string{}, d.Timeout(schema.TimeoutDelete), 3*time.Second,
+		vpcService.VpcIpv6GatewayStateRefreshFunc(d.Id(), []string{}))
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package alicloud
+
+import (
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+// resourceAlicloudVpcIpv6InternetBandwidth allocates the Internet bandwidth to an IPv6 address, so that the address
+// can be reached from the Internet through the IPv6 gateway.
+func resourceAlicloudVpcIpv6InternetBandwidth() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAlicloudVpcIpv6InternetBandwidthCreate,
+		Read:   resourceAlicloudVpcIpv6InternetBandwidthRead,
+		Update: resourceAlicloudVpcIpv6InternetBandwidthUpdate,
+		Delete: resourceAlicloudVpcIpv6InternetBandwidthDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"ipv6_gateway_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"ipv6_address_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"bandwidth": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validateIntegerInRange(1, 5000),
+			},
+			"internet_charge_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "PayByBandwidth",
+				ValidateFunc: validateAllowedStringValue([]string{"PayByBandwidth", "PayByTraffic"}),
+			},
+			"ipv6_address": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAlicloudVpcIpv6InternetBandwidthCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+
+	request := vpc.CreateAllocateIpv6InternetBandwidthRequest()
+	request.RegionId = client.RegionId
+	request.Ipv6GatewayId = d.Get("ipv6_gateway_id").(string)
+	request.Ipv6AddressId = d.Get("ipv6_address_id").(string)
+	request.Bandwidth = requests.NewInteger(d.Get("bandwidth").(int))
+	request.InternetChargeType = d.Get("internet_charge_type").(string)
+	request.ClientToken = buildClientToken(request.GetActionName())
+
+	raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+		return vpcClient.AllocateIpv6InternetBandwidth(request)
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, "alicloud_vpc_ipv6_internet_bandwidth", request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+	response := raw.(*vpc.AllocateIpv6InternetBandwidthResponse)
+	d.SetId(response.InternetBandwidthId)
+
+	return resourceAlicloudVpcIpv6InternetBandwidthRead(d, meta)
+}
+
+func resourceAlicloudVpcIpv6InternetBandwidthRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+	object, err := vpcService.DescribeVpcIpv6InternetBandwidth(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	d.Set("ipv6_gateway_id", object.Ipv6GatewayId)
+	d.Set("ipv6_address_id", object.Ipv6AddressId)
+	d.Set("ipv6_address", object.Ipv6Address)
+	d.Set("bandwidth", object.Ipv6InternetBandwidth.Bandwidth)
+	d.Set("internet_charge_type", object.Ipv6InternetBandwidth.InternetChargeType)
+	return nil
+}
+
+func resourceAlicloudVpcIpv6InternetBandwidthUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+
+	if d.HasChange("bandwidth") {
+		request := vpc.CreateModifyIpv6InternetBandwidthRequest()
+		request.RegionId = client.RegionId
+		request.Ipv6InternetBandwidthId = d.Id()
+		request.Ipv6AddressId = d.Get("ipv6_address_id").(string)
+		request.Bandwidth = requests.NewInteger(d.Get("bandwidth").(int))
+		request.ClientToken = buildClientToken(request.GetActionName())
+		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.ModifyIpv6InternetBandwidth(request)
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw)
+	}
+
+	return resourceAlicloudVpcIpv6InternetBandwidthRead(d, meta)
+}
+
+func resourceAlicloudVpcIpv6InternetBandwidthDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+
+	request := vpc.CreateDeleteIpv6InternetBandwidthRequest()
+	request.RegionId = client.RegionId
+	request.Ipv6InternetBandwidthId = d.Id()
+	request.Ipv6AddressId = d.Get("ipv6_address_id").(string)
+	err := resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.DeleteIpv6InternetBandwidth(request)
+		})
+		if err != nil {
+			if IsExceptedErrors(err, []string{IncorrectStatus, TaskConflict, Throttling}) {
+				time.Sleep(5 * time.Second)
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw)
+		return nil
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+
+	stateConf := BuildStateConf([]string{string(Normal), string(FinancialLocked)}, []string{}, d.Timeout(schema.TimeoutDelete), 3*time.Second,
+		vpcService.VpcIpv6InternetBandwidthStateRefreshFunc(d.Id(), []string{}))
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+	return nil
+}
@@ -0,0 +1,306 @@
+package alicloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func TestAccAlicloudVpcIpv6InternetBandwidthBasic(t *testing.T) {
+	var v *vpc.Ipv6Address
+	resourceId := "alicloud_vpc_ipv6_internet_bandwidth.default"
+	rand := acctest.RandIntRange(10000, 99999)
+	name := fmt.Sprintf("tf-testacc-ipv6-bandwidth-%d", rand)
+	ra := resourceAttrInit(resourceId, map[string]string{
+		"ipv6_gateway_id":      CHECKSET,
+		"ipv6_address_id":      CHECKSET,
+		"ipv6_address":         CHECKSET,
+		"bandwidth":            "10",
+		"internet_charge_type": "PayByBandwidth",
+	})
+
+	serviceFunc := func() interface{} {
+		return &VpcService{testAccProvider.Meta().(*connectivity.AliyunClient)}
+	}
+	rc := resourceCheckInit(resourceId, &v, serviceFunc)
+
+	rac := resourceAttrCheckInit(rc, ra)
+
+	testAccCheck := rac.resourceAttrMapUpdateSet()
+	testAccConfig := resourceTestAccConfigFunc(resourceId, name, resourceVpcIpv6AddressConfigDependence)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		// module name
+		IDRefreshName: resourceId,
+
+		Providers:    testAccProviders,
+		CheckDestroy: rac.checkResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"ipv6_gateway_id": "${alicloud_vpc_ipv6_gateway.default.id}",
+					"ipv6_address_id": "${data.alicloud_vpc_ipv6_addresses.default.ids.0}",
+					"bandwidth":       "10",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(nil),
+				),
+			},
+			{
+				ResourceName:      resourceId,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"bandwidth": "20",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"bandwidth": "20",
+					}),
+				),
+			},
+		},
+	})
+}
+
+// TestUnitAlicloudVpcIpv6InternetBandwidth builds the IPv6 gateway, the Internet bandwidth and the egress-only rule of
+// an IPv6 address which is looked up by its instance, against the local fake cloud.
+func TestUnitAlicloudVpcIpv6InternetBandwidth(t *testing.T) {
+	fc := newFakeCloud(t, connectivity.VPCCode)
+	defer fc.Close()
+
+	gateway := map[string]interface{}{}
+	address := map[string]interface{}{
+		"Ipv6AddressId":         "ipv6-fake0001",
+		"Ipv6Address":           "2408:4002:10c4:4e03::1",
+		"VpcId":                 "vpc-fake0001",
+		"VSwitchId":             "vsw-fake0001",
+		"AssociatedInstanceId":  "i-fake0001",
+		"NetworkType":           "Private",
+		"Status":                string(Available),
+		"Ipv6InternetBandwidth": map[string]interface{}{},
+	}
+	rules := map[string]map[string]interface{}{}
+
+	fc.HandleRPC(connectivity.VPCCode, "CreateIpv6Gateway", func(request *fakeCloudRequest) (int, interface{}) {
+		gateway = map[string]interface{}{
+			"RequestId":     "fake-request",
+			"Ipv6GatewayId": "ipv6gw-fake0001",
+			"VpcId":         request.Param("VpcId"),
+			"Name":          request.Param("Name"),
+			"Spec":          request.Param("Spec"),
+			"Status":        string(Available),
+		}
+		return 200, map[string]interface{}{"RequestId": "fake-request", "Ipv6GatewayId": "ipv6gw-fake0001"}
+	})
+	fc.HandleRPC(connectivity.VPCCode, "DescribeIpv6GatewayAttribute", func(request *fakeCloudRequest) (int, interface{}) {
+		if len(gateway) == 0 {
+			return 404, map[string]interface{}{"RequestId": "fake-request", "Code": Ipv6GatewayNotFound, "Message": "The IPv6 gateway does not exist."}
+		}
+		return 200, gateway
+	})
+	fc.HandleRPC(connectivity.VPCCode, "ModifyIpv6GatewaySpec", func(request *fakeCloudRequest) (int, interface{}) {
+		gateway["Spec"] = request.Param("Spec")
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	})
+	fc.HandleRPC(connectivity.VPCCode, "DeleteIpv6Gateway", func(request *fakeCloudRequest) (int, interface{}) {
+		gateway = map[string]interface{}{}
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	})
+
+	fc.HandleRPC(connectivity.VPCCode, "DescribeIpv6Addresses", func(request *fakeCloudRequest) (int, interface{}) {
+		var addresses []map[string]interface{}
+		bandwidth := address["Ipv6InternetBandwidth"].(map[string]interface{})
+		if id := request.Param("Ipv6InternetBandwidthId"); id == "" || bandwidth["Ipv6InternetBandwidthId"] == id {
+			if id := request.Param("AssociatedInstanceId"); id == "" || address["AssociatedInstanceId"] == id {
+				addresses = append(addresses, address)
+			}
+		}
+		return 200, map[string]interface{}{
+			"RequestId":     "fake-request",
+			"TotalCount":    len(addresses),
+			"Ipv6Addresses": map[string]interface{}{"Ipv6Address": addresses},
+		}
+	})
+	fc.HandleRPC(connectivity.VPCCode, "AllocateIpv6InternetBandwidth", func(request *fakeCloudRequest) (int, interface{}) {
+		address["Ipv6GatewayId"] = request.Param("Ipv6GatewayId")
+		address["Ipv6InternetBandwidth"] = map[string]interface{}{
+			"Ipv6InternetBandwidthId": "ipv6bw-fake0001",
+			"Bandwidth":               request.Param("Bandwidth"),
+			"InternetChargeType":      request.Param("InternetChargeType"),
+			"BusinessStatus":          string(Normal),
+		}
+		return 200, map[string]interface{}{"RequestId": "fake-request", "Ipv6AddressId": request.Param("Ipv6AddressId"), "InternetBandwidthId": "ipv6bw-fake0001"}
+	})
+	fc.HandleRPC(connectivity.VPCCode, "ModifyIpv6InternetBandwidth", func(request *fakeCloudRequest) (int, interface{}) {
+		address["Ipv6InternetBandwidth"].(map[string]interface{})["Bandwidth"] = request.Param("Bandwidth")
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	})
+	fc.HandleRPC(connectivity.VPCCode, "DeleteIpv6InternetBandwidth", func(request *fakeCloudRequest) (int, interface{}) {
+		address["Ipv6InternetBandwidth"] = map[string]interface{}{}
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	})
+
+	fc.HandleRPC(connectivity.VPCCode, "CreateIpv6EgressOnlyRule", func(request *fakeCloudRequest) (int, interface{}) {
+		rules["ipv6py-fake0001"] = map[string]interface{}{
+			"Ipv6EgressOnlyRuleId": "ipv6py-fake0001",
+			"InstanceId":           request.Param("InstanceId"),
+			"InstanceType":         request.Param("InstanceType"),
+			"Status":               string(Available),
+		}
+		return 200, map[string]interface{}{"RequestId": "fake-request", "Ipv6EgressRuleId": "ipv6py-fake0001"}
+	})
+	fc.HandleRPC(connectivity.VPCCode, "DescribeIpv6EgressOnlyRules", func(request *fakeCloudRequest) (int, interface{}) {
+		var found []map[string]interface{}
+		if rule, ok := rules[request.Param("Ipv6EgressOnlyRuleId")]; ok && request.Param("Ipv6GatewayId") == "ipv6gw-fake0001" {
+			found = append(found, rule)
+		}
+		return 200, map[string]interface{}{
+			"RequestId":           "fake-request",
+			"TotalCount":          len(found),
+			"Ipv6EgressOnlyRules": map[string]interface{}{"Ipv6EgressOnlyRule": found},
+		}
+	})
+	fc.HandleRPC(connectivity.VPCCode, "DeleteIpv6EgressOnlyRule", func(request *fakeCloudRequest) (int, interface{}) {
+		delete(rules, request.Param("Ipv6EgressOnlyRuleId"))
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	})
+
+	resourceId := "alicloud_vpc_ipv6_internet_bandwidth.default"
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		CheckDestroy: func(*terraform.State) error {
+			if len(gateway) > 0 || len(rules) > 0 || len(address["Ipv6InternetBandwidth"].(map[string]interface{})) > 0 {
+				return fmt.Errorf("the IPv6 gateway, its egress-only rules and its Internet bandwidth are not all deleted")
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpcIpv6InternetBandwidthConfigFake(fc, "Small", 10),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("alicloud_vpc_ipv6_gateway.default", "id", "ipv6gw-fake0001"),
+					resource.TestCheckResourceAttr(resourceId, "id", "ipv6bw-fake0001"),
+					resource.TestCheckResourceAttr(resourceId, "ipv6_address_id", "ipv6-fake0001"),
+					resource.TestCheckResourceAttr(resourceId, "ipv6_address", "2408:4002:10c4:4e03::1"),
+					resource.TestCheckResourceAttr(resourceId, "bandwidth", "10"),
+					resource.TestCheckResourceAttr("alicloud_vpc_ipv6_egress_rule.default", "id", "ipv6gw-fake0001:ipv6py-fake0001"),
+					resource.TestCheckResourceAttr("alicloud_vpc_ipv6_egress_rule.default", "instance_id", "ipv6-fake0001"),
+					fc.CheckRequests(connectivity.VPCCode, "AllocateIpv6InternetBandwidth", 1, map[string]string{"Ipv6GatewayId": "ipv6gw-fake0001"}),
+					fc.CheckRequests(connectivity.VPCCode, "CreateIpv6EgressOnlyRule", 1, map[string]string{"InstanceType": "IPv6Address"}),
+				),
+			},
+			{
+				Config: testAccVpcIpv6InternetBandwidthConfigFake(fc, "Medium", 20),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("alicloud_vpc_ipv6_gateway.default", "spec", "Medium"),
+					resource.TestCheckResourceAttr(resourceId, "bandwidth", "20"),
+					fc.CheckRequests(connectivity.VPCCode, "ModifyIpv6GatewaySpec", 1, map[string]string{"Spec": "Medium"}),
+					fc.CheckRequests(connectivity.VPCCode, "ModifyIpv6InternetBandwidth", 1, map[string]string{"Bandwidth": "20"}),
+				),
+			},
+		},
+	})
+}
+
+func testAccVpcIpv6InternetBandwidthConfigFake(fc *fakeCloud, spec string, bandwidth int) string {
+	return fmt.Sprintf(`
+%s
+
+data "alicloud_vpc_ipv6_addresses" "default" {
+  associated_instance_id = "i-fake0001"
+  status                 = "Available"
+}
+
+resource "alicloud_vpc_ipv6_gateway" "default" {
+  vpc_id = "vpc-fake0001"
+  name   = "tf-ipv6"
+  spec   = "%s"
+}
+
+resource "alicloud_vpc_ipv6_internet_bandwidth" "default" {
+  ipv6_gateway_id = "${alicloud_vpc_ipv6_gateway.default.id}"
+  ipv6_address_id = "${data.alicloud_vpc_ipv6_addresses.default.ids.0}"
+  bandwidth       = %d
+}
+
+resource "alicloud_vpc_ipv6_egress_rule" "default" {
+  ipv6_gateway_id = "${alicloud_vpc_ipv6_gateway.default.id}"
+  instance_id     = "${data.alicloud_vpc_ipv6_addresses.default.addresses.0.id}"
+}
+`, fc.ProviderConfig(), spec, bandwidth)
+}
+
+func resourceVpcIpv6AddressConfigDependence(name string) string {
+	return fmt.Sprintf(`
+variable "name" {
+  default = "%s"
+}
+
+data "alicloud_zones" "default" {
+  available_disk_category     = "cloud_efficiency"
+  available_resource_creation = "VSwitch"
+}
+
+data "alicloud_instance_types" "default" {
+  availability_zone = "${data.alicloud_zones.default.zones.0.id}"
+  cpu_core_count    = 2
+  memory_size       = 8
+}
+
+data "alicloud_images" "default" {
+  name_regex  = "^ubuntu_18.*64"
+  most_recent = true
+  owners      = "system"
+}
+
+resource "alicloud_vpc" "default" {
+  name        = "${var.name}"
+  cidr_block  = "172.16.0.0/16"
+  enable_ipv6 = true
+}
+
+resource "alicloud_vswitch" "default" {
+  vpc_id               = "${alicloud_vpc.default.id}"
+  cidr_block           = "172.16.0.0/24"
+  ipv6_cidr_block_mask = 1
+  availability_zone    = "${data.alicloud_zones.default.zones.0.id}"
+  name                 = "${var.name}"
+}
+
+resource "alicloud_security_group" "default" {
+  name   = "${var.name}"
+  vpc_id = "${alicloud_vpc.default.id}"
+}
+
+resource "alicloud_instance" "default" {
+  instance_name      = "${var.name}"
+  image_id           = "${data.alicloud_images.default.images.0.id}"
+  instance_type      = "${data.alicloud_instance_types.default.instance_types.0.id}"
+  security_groups    = ["${alicloud_security_group.default.id}"]
+  vswitch_id         = "${alicloud_vswitch.default.id}"
+  ipv6_address_count = 1
+}
+
+resource "alicloud_vpc_ipv6_gateway" "default" {
+  vpc_id = "${alicloud_vpc.default.id}"
+  name   = "${var.name}"
+}
+
+data "alicloud_vpc_ipv6_addresses" "default" {
+  associated_instance_id = "${alicloud_instance.default.id}"
+}
+`, name)
+}
@@ -3,6 +3,7 @@ package alicloud
 import (
 	"fmt"
 	"log"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -211,6 +212,15 @@ func TestAccAlicloudVpcBasic(t *testing.T) {
 					}),
 				),
 			},
+			{
+				Config: testAccCheckVpcConfig_ipv6(rand),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"enable_ipv6":     "true",
+						"ipv6_cidr_block": CHECKSET,
+					}),
+				),
+			},
 		},
 	})
 
@@ -250,6 +260,185 @@ func TestAccAlicloudVpcMulti(t *testing.T) {
 
 }
 
+// TestUnitAlicloudVpcBasic runs the VPC lifecycle against the local fake cloud, and it does not need any credential.
+func TestUnitAlicloudVpcBasic(t *testing.T) {
+	fc := newFakeCloud(t, connectivity.VPCCode).LoadCassette("vpc_basic")
+	defer fc.Close()
+
+	resourceId := "alicloud_vpc.default"
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckVpcDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckVpcConfigFake(fc, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "id", "vpc-fake0001"),
+					resource.TestCheckResourceAttr(resourceId, "name", "tf-testAccVpcFake"),
+					resource.TestCheckResourceAttr(resourceId, "cidr_block", "172.16.0.0/12"),
+					resource.TestCheckResourceAttr(resourceId, "router_id", "vrt-fake0001"),
+					resource.TestCheckResourceAttr(resourceId, "route_table_id", "vtb-fake0001"),
+				),
+			},
+			{
+				// The import step needs the provider block as well to reach the fake cloud
+				Config:            fc.ProviderConfig(),
+				ResourceName:      resourceId,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccCheckVpcConfigFake(fc, "tf-testAccVpcFake-description"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "description", "tf-testAccVpcFake-description"),
+					resource.TestCheckResourceAttr(resourceId, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceId, "tags.Created", "tf-testAcc"),
+					func(s *terraform.State) error {
+						requests := fc.Requests(connectivity.VPCCode, "ModifyVpcAttribute")
+						if len(requests) != 1 || requests[0].Param("Description") != "tf-testAccVpcFake-description" {
+							return fmt.Errorf("expected one ModifyVpcAttribute request with the new description, got %d", len(requests))
+						}
+						requests = fc.Requests(connectivity.VPCCode, "TagResources")
+						if len(requests) != 1 || requests[0].Param("ResourceType") != "VPC" || requests[0].Param("Tag.1.Key") != "Created" {
+							return fmt.Errorf("expected one TagResources request of the VPC, got %d", len(requests))
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+// TestUnitAlicloudVpcIpv6 checks IPv6 is enabled with its CIDR block after the VPC is created, the CIDR block requires
+// IPv6, and the VPC is replaced when IPv6 is disabled, against the local fake cloud.
+func TestUnitAlicloudVpcIpv6(t *testing.T) {
+	fc := newFakeCloud(t, connectivity.VPCCode).LoadCassette("vpc_basic")
+	defer fc.Close()
+
+	ipv6CidrBlock, deleted := "", false
+	fc.HandleRPC(connectivity.VPCCode, "DescribeVpcAttribute", func(request *fakeCloudRequest) (int, interface{}) {
+		if deleted {
+			return 404, map[string]interface{}{"RequestId": "fake-request", "Code": InvalidVpcIDNotFound, "Message": "Specified VPC does not exist."}
+		}
+		return 200, map[string]interface{}{
+			"RequestId":       "fake-request",
+			"VpcId":           "vpc-fake0001",
+			"RegionId":        "cn-hangzhou",
+			"Status":          string(Available),
+			"VpcName":         "tf-testAccVpcFake",
+			"CidrBlock":       "172.16.0.0/12",
+			"Ipv6CidrBlock":   ipv6CidrBlock,
+			"VRouterId":       "vrt-fake0001",
+			"ResourceGroupId": "rg-fake0001",
+		}
+	})
+	fc.HandleRPC(connectivity.VPCCode, "ModifyVpcAttribute", func(request *fakeCloudRequest) (int, interface{}) {
+		if request.Param("EnableIPv6") == "true" {
+			ipv6CidrBlock = "2408:4002:10c4:4e00::/56"
+			if v := request.Param("Ipv6CidrBlock"); v != "" {
+				ipv6CidrBlock = v
+			}
+		}
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	})
+	fc.HandleRPC(connectivity.VPCCode, "DeleteVpc", func(request *fakeCloudRequest) (int, interface{}) {
+		ipv6CidrBlock, deleted = "", true
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	})
+	fc.HandleRPC(connectivity.VPCCode, "CreateVpc", func(request *fakeCloudRequest) (int, interface{}) {
+		deleted = false
+		return 200, map[string]interface{}{
+			"RequestId":       "fake-request",
+			"VpcId":           "vpc-fake0001",
+			"VRouterId":       "vrt-fake0001",
+			"RouteTableId":    "vtb-fake0001",
+			"ResourceGroupId": "rg-fake0001",
+		}
+	})
+
+	resourceId := "alicloud_vpc.default"
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckVpcDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckVpcConfigIpv6Fake(fc, false, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "enable_ipv6", "false"),
+					resource.TestCheckResourceAttr(resourceId, "ipv6_cidr_block", ""),
+					func(s *terraform.State) error {
+						if value := fc.Requests(connectivity.VPCCode, "CreateVpc")[0].Param("EnableIpv6"); value != "" {
+							return fmt.Errorf("expected CreateVpc not to enable IPv6, got %s", value)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config:      testAccCheckVpcConfigIpv6Fake(fc, false, "2408:4002:10c4:4f00::/56"),
+				ExpectError: regexp.MustCompile("The ipv6_cidr_block 2408:4002:10c4:4f00::/56 requires enable_ipv6 to be true"),
+			},
+			{
+				// The IPv6 CIDR block is allocated in place along with IPv6
+				Config: testAccCheckVpcConfigIpv6Fake(fc, true, "2408:4002:10c4:4f00::/56"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "enable_ipv6", "true"),
+					resource.TestCheckResourceAttr(resourceId, "ipv6_cidr_block", "2408:4002:10c4:4f00::/56"),
+					fc.CheckRequests(connectivity.VPCCode, "ModifyVpcAttribute", 1, map[string]string{"EnableIPv6": "true", "Ipv6CidrBlock": "2408:4002:10c4:4f00::/56"}),
+					fc.CheckRequests(connectivity.VPCCode, "CreateVpc", 1, nil),
+				),
+			},
+			{
+				Config: testAccCheckVpcConfigIpv6Fake(fc, false, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "enable_ipv6", "false"),
+					resource.TestCheckResourceAttr(resourceId, "ipv6_cidr_block", ""),
+					fc.CheckRequests(connectivity.VPCCode, "ModifyVpcAttribute", 1, nil),
+					fc.CheckRequests(connectivity.VPCCode, "DeleteVpc", 1, nil),
+					fc.CheckRequests(connectivity.VPCCode, "CreateVpc", 2, nil),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckVpcConfigIpv6Fake(fc *fakeCloud, enableIpv6 bool, ipv6CidrBlock string) string {
+	if ipv6CidrBlock != "" {
+		ipv6CidrBlock = fmt.Sprintf(`ipv6_cidr_block = "%s"`, ipv6CidrBlock)
+	}
+	return fmt.Sprintf(`
+%s
+
+resource "alicloud_vpc" "default" {
+  name        = "tf-testAccVpcFake"
+  cidr_block  = "172.16.0.0/12"
+  enable_ipv6 = %t
+  %s
+}
+`, fc.ProviderConfig(), enableIpv6, ipv6CidrBlock)
+}
+
+func testAccCheckVpcConfigFake(fc *fakeCloud, description string) string {
+	if description != "" {
+		description = fmt.Sprintf(`description = "%s"
+  tags = {
+    Created = "tf-testAcc"
+  }`, description)
+	}
+	return fmt.Sprintf(`
+%s
+
+resource "alicloud_vpc" "default" {
+  name       = "tf-testAccVpcFake"
+  cidr_block = "172.16.0.0/12"
+  %s
+}
+`, fc.ProviderConfig(), description)
+}
+
 func testAccCheckVpcConfigBasic(rand int) string {
 	return fmt.Sprintf(
 		`
@@ -308,6 +497,22 @@ resource "alicloud_vpc" "default" {
 `, rand)
 }
 
+func testAccCheckVpcConfig_ipv6(rand int) string {
+	return fmt.Sprintf(
+		`
+variable "name" {
+	default = "tf_testAccVpcConfigName%d"
+}
+
+resource "alicloud_vpc" "default" {
+	cidr_block = "172.16.0.0/12"
+	name = "${var.name}_all"
+	description = "${var.name}_decription_all"
+	enable_ipv6 = true
+}
+`, rand)
+}
+
 func testAccCheckVpcConfigMulti(rand int) string {
 	return fmt.Sprintf(
 		`
@@ -327,6 +532,8 @@ var testAccCheckVpcCheckMap = map[string]string{
 	"cidr_block":        "172.16.0.0/12",
 	"name":              "",
 	"description":       "",
+	"enable_ipv6":       "false",
+	"ipv6_cidr_block":   "",
 	"resource_group_id": CHECKSET,
 	"router_id":         CHECKSET,
 	"router_table_id":   CHECKSET,
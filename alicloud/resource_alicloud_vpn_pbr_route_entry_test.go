@@ -0,0 +1,88 @@
+package alicloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func TestAccAlicloudVpnPbrRouteEntryBasic(t *testing.T) {
+	var v vpc.VpnPbrRouteEntry
+
+	resourceId := "alicloud_vpn_pbr_route_entry.default"
+	ra := resourceAttrInit(resourceId, map[string]string{
+		"vpn_gateway_id": CHECKSET,
+		"next_hop":       CHECKSET,
+		"route_source":   "172.16.0.0/24",
+		"route_dest":     "10.0.0.0/24",
+		"weight":         "0",
+		"publish_vpc":    "false",
+	})
+
+	serviceFunc := func() interface{} {
+		return &VpnGatewayService{testAccProvider.Meta().(*connectivity.AliyunClient)}
+	}
+	rc := resourceCheckInit(resourceId, &v, serviceFunc)
+
+	rac := resourceAttrCheckInit(rc, ra)
+
+	testAccCheck := rac.resourceAttrMapUpdateSet()
+	rand := acctest.RandInt()
+	name := fmt.Sprintf("tf-testaccVpnPbrRouteEntryBasic%d", rand)
+	testAccConfig := resourceTestAccConfigFunc(resourceId, name, resourceVpnRouteEntryConfigDependence)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckWithAccountSiteType(t, IntlSite)
+		},
+
+		// module name
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		CheckDestroy:  rac.checkResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"vpn_gateway_id": "${alicloud_vpn_gateway.default.id}",
+					"next_hop":       "${alicloud_vpn_connection.default.id}",
+					"route_source":   "172.16.0.0/24",
+					"route_dest":     "10.0.0.0/24",
+					"weight":         "0",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(nil),
+				),
+			},
+			{
+				ResourceName:      resourceId,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"weight": "100",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"weight": "100",
+					}),
+				),
+			},
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"publish_vpc": "true",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"publish_vpc": "true",
+					}),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,261 @@
+package alicloud
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func resourceAliyunVpnRouteEntry() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAliyunVpnRouteEntryCreate,
+		Read:   resourceAliyunVpnRouteEntryRead,
+		Update: resourceAliyunVpnRouteEntryUpdate,
+		Delete: resourceAliyunVpnRouteEntryDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"vpn_gateway_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"route_dest": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCIDRNetworkAddress,
+			},
+
+			"next_hop": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"weight": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validateAllowedIntValue([]int{0, 100}),
+			},
+
+			"publish_vpc": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+func resourceAliyunVpnRouteEntryCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpnGatewayService := VpnGatewayService{client}
+	request := vpc.CreateCreateVpnRouteEntryRequest()
+	request.RegionId = client.RegionId
+	request.VpnGatewayId = d.Get("vpn_gateway_id").(string)
+	request.RouteDest = d.Get("route_dest").(string)
+	request.NextHop = d.Get("next_hop").(string)
+	request.Weight = requests.NewInteger(d.Get("weight").(int))
+	request.PublishVpc = requests.NewBoolean(d.Get("publish_vpc").(bool))
+	request.ClientToken = buildClientToken(request.GetActionName())
+
+	err := resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+		args := *request
+		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.CreateVpnRouteEntry(&args)
+		})
+		if err != nil {
+			if IsExceptedErrors(err, []string{VpnConfiguring, Throttling}) {
+				time.Sleep(10 * time.Second)
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw)
+		return nil
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, "alicloud_vpn_route_entry", request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+
+	d.SetId(fmt.Sprintf("%s%s%s%s%s", request.VpnGatewayId, COLON_SEPARATED, request.NextHop, COLON_SEPARATED, request.RouteDest))
+
+	stateConf := BuildStateConf([]string{"", string(VpnRouteEntryPending)}, []string{vpnRouteEntryTargetState(d)}, d.Timeout(schema.TimeoutCreate), 3*time.Second,
+		vpnGatewayService.VpnRouteEntryStateRefreshFunc(d.Id(), []string{}))
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+
+	return resourceAliyunVpnRouteEntryRead(d, meta)
+}
+
+func resourceAliyunVpnRouteEntryRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpnGatewayService := VpnGatewayService{client}
+	object, err := vpnGatewayService.DescribeVpnRouteEntry(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+	parts, err := ParseResourceId(d.Id(), 3)
+	if err != nil {
+		return WrapError(err)
+	}
+
+	d.Set("vpn_gateway_id", parts[0])
+	d.Set("next_hop", object.NextHop)
+	d.Set("route_dest", object.RouteDest)
+	d.Set("weight", object.Weight)
+	d.Set("publish_vpc", object.State == string(VpnRouteEntryPublished))
+
+	return nil
+}
+
+func resourceAliyunVpnRouteEntryUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpnGatewayService := VpnGatewayService{client}
+	d.Partial(true)
+
+	update := false
+	if d.HasChange("weight") {
+		oldWeight, newWeight := d.GetChange("weight")
+		request := vpc.CreateModifyVpnRouteEntryWeightRequest()
+		request.RegionId = client.RegionId
+		request.VpnGatewayId = d.Get("vpn_gateway_id").(string)
+		request.RouteDest = d.Get("route_dest").(string)
+		request.NextHop = d.Get("next_hop").(string)
+		request.Weight = requests.NewInteger(oldWeight.(int))
+		request.NewWeight = requests.NewInteger(newWeight.(int))
+		request.ClientToken = buildClientToken(request.GetActionName())
+		err := resource.Retry(d.Timeout(schema.TimeoutUpdate), func() *resource.RetryError {
+			args := *request
+			raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+				return vpcClient.ModifyVpnRouteEntryWeight(&args)
+			})
+			if err != nil {
+				if IsExceptedErrors(err, []string{VpnConfiguring, Throttling}) {
+					time.Sleep(10 * time.Second)
+					return resource.RetryableError(err)
+				}
+				return resource.NonRetryableError(err)
+			}
+			addDebug(request.GetActionName(), raw)
+			return nil
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
+		}
+		update = true
+		d.SetPartial("weight")
+	}
+
+	if d.HasChange("publish_vpc") {
+		request := vpc.CreatePublishVpnRouteEntryRequest()
+		request.RegionId = client.RegionId
+		request.VpnGatewayId = d.Get("vpn_gateway_id").(string)
+		request.RouteDest = d.Get("route_dest").(string)
+		request.NextHop = d.Get("next_hop").(string)
+		request.RouteType = "dbr"
+		request.PublishVpc = requests.NewBoolean(d.Get("publish_vpc").(bool))
+		request.ClientToken = buildClientToken(request.GetActionName())
+		err := resource.Retry(d.Timeout(schema.TimeoutUpdate), func() *resource.RetryError {
+			args := *request
+			raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+				return vpcClient.PublishVpnRouteEntry(&args)
+			})
+			if err != nil {
+				if IsExceptedErrors(err, []string{VpnConfiguring, Throttling}) {
+					time.Sleep(10 * time.Second)
+					return resource.RetryableError(err)
+				}
+				return resource.NonRetryableError(err)
+			}
+			addDebug(request.GetActionName(), raw)
+			return nil
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
+		}
+		update = true
+		d.SetPartial("publish_vpc")
+	}
+
+	if update {
+		stateConf := BuildStateConf([]string{string(VpnRouteEntryPending), string(VpnRouteEntryNormal), string(VpnRouteEntryPublished)}, []string{vpnRouteEntryTargetState(d)},
+			d.Timeout(schema.TimeoutUpdate), 3*time.Second, vpnGatewayService.VpnRouteEntryStateRefreshFunc(d.Id(), []string{}))
+		if _, err := stateConf.WaitForState(); err != nil {
+			return WrapErrorf(err, IdMsg, d.Id())
+		}
+	}
+
+	d.Partial(false)
+	return resourceAliyunVpnRouteEntryRead(d, meta)
+}
+
+func resourceAliyunVpnRouteEntryDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpnGatewayService := VpnGatewayService{client}
+	request := vpc.CreateDeleteVpnRouteEntryRequest()
+	request.RegionId = client.RegionId
+	request.VpnGatewayId = d.Get("vpn_gateway_id").(string)
+	request.RouteDest = d.Get("route_dest").(string)
+	request.NextHop = d.Get("next_hop").(string)
+	request.Weight = requests.NewInteger(d.Get("weight").(int))
+	request.ClientToken = buildClientToken(request.GetActionName())
+
+	err := resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		args := *request
+		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.DeleteVpnRouteEntry(&args)
+		})
+		if err != nil {
+			if IsExceptedErrors(err, []string{VpnConfiguring, Throttling}) {
+				time.Sleep(10 * time.Second)
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw)
+		return nil
+	})
+	if err != nil {
+		if IsExceptedErrors(err, []string{VpnNotFound}) {
+			return nil
+		}
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+
+	stateConf := BuildStateConf([]string{string(VpnRouteEntryPending), string(VpnRouteEntryNormal), string(VpnRouteEntryPublished)}, []string{},
+		d.Timeout(schema.TimeoutDelete), 3*time.Second, vpnGatewayService.VpnRouteEntryStateRefreshFunc(d.Id(), []string{}))
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+	return nil
+}
+
+// vpnRouteEntryTargetState returns the state which a route entry reaches once it is published to the VPC or withdrawn from it.
+// It is shared by the destination-based and the policy-based route entries.
+func vpnRouteEntryTargetState(d *schema.ResourceData) string {
+	if d.Get("publish_vpc").(bool) {
+		return string(VpnRouteEntryPublished)
+	}
+	return string(VpnRouteEntryNormal)
+}
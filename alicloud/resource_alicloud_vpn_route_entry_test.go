@@ -0,0 +1,275 @@
+package alicloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func TestAccAlicloudVpnRouteEntryBasic(t *testing.T) {
+	var v vpc.VpnRouteEntry
+
+	resourceId := "alicloud_vpn_route_entry.default"
+	ra := resourceAttrInit(resourceId, map[string]string{
+		"vpn_gateway_id": CHECKSET,
+		"next_hop":       CHECKSET,
+		"route_dest":     "10.0.0.0/24",
+		"weight":         "0",
+		"publish_vpc":    "false",
+	})
+
+	serviceFunc := func() interface{} {
+		return &VpnGatewayService{testAccProvider.Meta().(*connectivity.AliyunClient)}
+	}
+	rc := resourceCheckInit(resourceId, &v, serviceFunc)
+
+	rac := resourceAttrCheckInit(rc, ra)
+
+	testAccCheck := rac.resourceAttrMapUpdateSet()
+	rand := acctest.RandInt()
+	name := fmt.Sprintf("tf-testaccVpnRouteEntryBasic%d", rand)
+	testAccConfig := resourceTestAccConfigFunc(resourceId, name, resourceVpnRouteEntryConfigDependence)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckWithAccountSiteType(t, IntlSite)
+		},
+
+		// module name
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		CheckDestroy:  rac.checkResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"vpn_gateway_id": "${alicloud_vpn_gateway.default.id}",
+					"next_hop":       "${alicloud_vpn_connection.default.id}",
+					"route_dest":     "10.0.0.0/24",
+					"weight":         "0",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(nil),
+				),
+			},
+			{
+				ResourceName:      resourceId,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"weight": "100",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"weight": "100",
+					}),
+				),
+			},
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"publish_vpc": "true",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"publish_vpc": "true",
+					}),
+				),
+			},
+		},
+	})
+}
+
+var resourceVpnRouteEntryConfigDependence = func(name string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "alicloud_vpn_connection" "default" {
+	name = "${var.name}"
+	vpn_gateway_id = "${alicloud_vpn_gateway.default.id}"
+	customer_gateway_id = "${alicloud_vpn_customer_gateway.default.id}"
+	local_subnet = ["0.0.0.0/0"]
+	remote_subnet = ["0.0.0.0/0"]
+}
+`, resourceVpnConnectionConfigDependence(name))
+}
+
+// TestUnitAlicloudVpnRouteEntry switches the weights of a destination-based and a policy-based route entry, which is
+// how the standby tunnel takes over, and publishes them to the VPC, against the local fake cloud.
+func TestUnitAlicloudVpnRouteEntry(t *testing.T) {
+	fc := newFakeCloud(t, connectivity.VPCCode)
+	defer fc.Close()
+
+	routes := map[string]map[string]interface{}{}
+	pbrRoutes := map[string]map[string]interface{}{}
+	createRoute := func(entries map[string]map[string]interface{}, request *fakeCloudRequest) map[string]interface{} {
+		state := string(VpnRouteEntryNormal)
+		if request.Param("PublishVpc") == "true" {
+			state = string(VpnRouteEntryPublished)
+		}
+		entry := map[string]interface{}{
+			"VpnInstanceId": request.Param("VpnGatewayId"),
+			"RouteSource":   request.Param("RouteSource"),
+			"RouteDest":     request.Param("RouteDest"),
+			"NextHop":       request.Param("NextHop"),
+			"Weight":        request.Param("Weight"),
+			"State":         state,
+		}
+		entries[request.Param("NextHop")+request.Param("RouteSource")+request.Param("RouteDest")] = entry
+		return entry
+	}
+	listRoutes := func(entries map[string]map[string]interface{}, request *fakeCloudRequest) []map[string]interface{} {
+		var found []map[string]interface{}
+		for _, entry := range entries {
+			if entry["VpnInstanceId"] == request.Param("VpnGatewayId") {
+				found = append(found, entry)
+			}
+		}
+		return found
+	}
+	modifyWeight := func(entries map[string]map[string]interface{}, request *fakeCloudRequest) (int, interface{}) {
+		entry, ok := entries[request.Param("NextHop")+request.Param("RouteSource")+request.Param("RouteDest")]
+		if !ok || entry["Weight"] != request.Param("Weight") {
+			return 400, map[string]interface{}{"RequestId": "fake-request", "Code": "InvalidParameter", "Message": "The weight does not match the route entry."}
+		}
+		entry["Weight"] = request.Param("NewWeight")
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	}
+
+	fc.HandleRPC(connectivity.VPCCode, "CreateVpnRouteEntry", func(request *fakeCloudRequest) (int, interface{}) {
+		createRoute(routes, request)
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	})
+	fc.HandleRPC(connectivity.VPCCode, "DescribeVpnRouteEntries", func(request *fakeCloudRequest) (int, interface{}) {
+		found := listRoutes(routes, request)
+		return 200, map[string]interface{}{
+			"RequestId":       "fake-request",
+			"TotalCount":      len(found),
+			"VpnRouteEntries": map[string]interface{}{"VpnRouteEntry": found},
+		}
+	})
+	fc.HandleRPC(connectivity.VPCCode, "ModifyVpnRouteEntryWeight", func(request *fakeCloudRequest) (int, interface{}) {
+		return modifyWeight(routes, request)
+	})
+	fc.HandleRPC(connectivity.VPCCode, "DeleteVpnRouteEntry", func(request *fakeCloudRequest) (int, interface{}) {
+		delete(routes, request.Param("NextHop")+request.Param("RouteDest"))
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	})
+
+	fc.HandleRPC(connectivity.VPCCode, "CreateVpnPbrRouteEntry", func(request *fakeCloudRequest) (int, interface{}) {
+		createRoute(pbrRoutes, request)
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	})
+	fc.HandleRPC(connectivity.VPCCode, "DescribeVpnPbrRouteEntries", func(request *fakeCloudRequest) (int, interface{}) {
+		found := listRoutes(pbrRoutes, request)
+		return 200, map[string]interface{}{
+			"RequestId":          "fake-request",
+			"TotalCount":         len(found),
+			"VpnPbrRouteEntries": map[string]interface{}{"VpnPbrRouteEntry": found},
+		}
+	})
+	fc.HandleRPC(connectivity.VPCCode, "ModifyVpnPbrRouteEntryWeight", func(request *fakeCloudRequest) (int, interface{}) {
+		return modifyWeight(pbrRoutes, request)
+	})
+	fc.HandleRPC(connectivity.VPCCode, "DeleteVpnPbrRouteEntry", func(request *fakeCloudRequest) (int, interface{}) {
+		delete(pbrRoutes, request.Param("NextHop")+request.Param("RouteSource")+request.Param("RouteDest"))
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	})
+
+	fc.HandleRPC(connectivity.VPCCode, "PublishVpnRouteEntry", func(request *fakeCloudRequest) (int, interface{}) {
+		state := string(VpnRouteEntryNormal)
+		if request.Param("PublishVpc") == "true" {
+			state = string(VpnRouteEntryPublished)
+		}
+		entries := routes
+		if request.Param("RouteType") == "pbr" {
+			entries = pbrRoutes
+		}
+		for _, entry := range entries {
+			if entry["NextHop"] == request.Param("NextHop") && entry["RouteDest"] == request.Param("RouteDest") {
+				entry["State"] = state
+			}
+		}
+		return 200, map[string]interface{}{"RequestId": "fake-request"}
+	})
+
+	resourceId := "alicloud_vpn_route_entry.active"
+	pbrResourceId := "alicloud_vpn_pbr_route_entry.default"
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		CheckDestroy: func(*terraform.State) error {
+			if len(routes) > 0 || len(pbrRoutes) > 0 {
+				return fmt.Errorf("the VPN route entries are not all deleted")
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpnRouteEntryConfigFake(fc, 100, 0, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "id", "vpn-fake0001:vco-fake0001:10.0.0.0/24"),
+					resource.TestCheckResourceAttr(resourceId, "weight", "100"),
+					resource.TestCheckResourceAttr(resourceId, "publish_vpc", "false"),
+					resource.TestCheckResourceAttr("alicloud_vpn_route_entry.standby", "weight", "0"),
+					resource.TestCheckResourceAttr(pbrResourceId, "id", "vpn-fake0001:vco-fake0001:172.16.0.0/24:10.1.0.0/24"),
+					resource.TestCheckResourceAttr(pbrResourceId, "route_source", "172.16.0.0/24"),
+					resource.TestCheckResourceAttr(pbrResourceId, "weight", "100"),
+				),
+			},
+			{
+				Config: testAccVpnRouteEntryConfigFake(fc, 0, 100, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "id", "vpn-fake0001:vco-fake0001:10.0.0.0/24"),
+					resource.TestCheckResourceAttr(resourceId, "weight", "0"),
+					resource.TestCheckResourceAttr(resourceId, "publish_vpc", "true"),
+					resource.TestCheckResourceAttr("alicloud_vpn_route_entry.standby", "weight", "100"),
+					resource.TestCheckResourceAttr(pbrResourceId, "weight", "0"),
+					resource.TestCheckResourceAttr(pbrResourceId, "publish_vpc", "true"),
+					// The weights are switched in place, the entries are not replaced
+					fc.CheckRequests(connectivity.VPCCode, "CreateVpnRouteEntry", 2, nil),
+					fc.CheckRequests(connectivity.VPCCode, "ModifyVpnRouteEntryWeight", 2, nil),
+					fc.CheckRequests(connectivity.VPCCode, "ModifyVpnPbrRouteEntryWeight", 1, nil),
+					fc.CheckRequests(connectivity.VPCCode, "PublishVpnRouteEntry", 3, nil),
+				),
+			},
+		},
+	})
+}
+
+func testAccVpnRouteEntryConfigFake(fc *fakeCloud, activeWeight, standbyWeight int, publishVpc bool) string {
+	return fmt.Sprintf(`
+%s
+
+resource "alicloud_vpn_route_entry" "active" {
+  vpn_gateway_id = "vpn-fake0001"
+  next_hop       = "vco-fake0001"
+  route_dest     = "10.0.0.0/24"
+  weight         = %d
+  publish_vpc    = %t
+}
+
+resource "alicloud_vpn_route_entry" "standby" {
+  vpn_gateway_id = "vpn-fake0001"
+  next_hop       = "vco-fake0002"
+  route_dest     = "10.0.0.0/24"
+  weight         = %d
+  publish_vpc    = %t
+}
+
+resource "alicloud_vpn_pbr_route_entry" "default" {
+  vpn_gateway_id = "vpn-fake0001"
+  next_hop       = "vco-fake0001"
+  route_source   = "172.16.0.0/24"
+  route_dest     = "10.1.0.0/24"
+  weight         = %d
+  publish_vpc    = %t
+}
+`, fc.ProviderConfig(), activeWeight, publishVpc, standbyWeight, publishVpc, activeWeight, publishVpc)
+}
@@ -1,8 +1,10 @@
 package alicloud
 
 import (
+	"net"
 	"time"
 
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
@@ -18,6 +20,7 @@ func resourceAliyunSubnet() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: defaultTagsCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"availability_zone": {
@@ -36,6 +39,16 @@ func resourceAliyunSubnet() *schema.Resource {
 				ForceNew:     true,
 				ValidateFunc: validateSwitchCIDRNetworkAddress,
 			},
+			"ipv6_cidr_block_mask": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateIntegerInRange(0, 255),
+			},
+			"ipv6_cidr_block": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"name": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -44,6 +57,8 @@ func resourceAliyunSubnet() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 		},
 	}
 }
@@ -81,7 +96,7 @@ func resourceAliyunSwitchCreate(d *schema.ResourceData, meta interface{}) error
 	if err := vpcService.WaitForVSwitch(vswitchID, Available, DefaultTimeoutMedium); err != nil {
 		return WrapError(err)
 	}
-	return resourceAliyunSwitchRead(d, meta)
+	return resourceAliyunSwitchUpdate(d, meta)
 }
 
 func resourceAliyunSwitchRead(d *schema.ResourceData, meta interface{}) error {
@@ -99,14 +114,41 @@ func resourceAliyunSwitchRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("availability_zone", vswitch.ZoneId)
 	d.Set("vpc_id", vswitch.VpcId)
 	d.Set("cidr_block", vswitch.CidrBlock)
+	d.Set("ipv6_cidr_block", vswitch.Ipv6CidrBlock)
+	if vswitch.Ipv6CidrBlock != "" {
+		mask, err := getIpv6CidrBlockMask(vswitch.Ipv6CidrBlock)
+		if err != nil {
+			return WrapError(err)
+		}
+		d.Set("ipv6_cidr_block_mask", mask)
+	}
 	d.Set("name", vswitch.VSwitchName)
 	d.Set("description", vswitch.Description)
 
+	tagService := TagService{client}
+	tags, err := tagService.ListTags(d.Id(), TagResourceVSwitch)
+	if err != nil {
+		return WrapError(err)
+	}
+	setTagsWithDefaults(client, d, tags)
+
 	return nil
 }
 
 func resourceAliyunSwitchUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*connectivity.AliyunClient)
+	tagService := TagService{client}
+	d.Partial(true)
+
+	if err := tagService.SetTags(d, TagResourceVSwitch); err != nil {
+		return WrapError(err)
+	}
+	d.SetPartial("tags")
+
+	if d.IsNewResource() {
+		d.Partial(false)
+		return resourceAliyunSwitchRead(d, meta)
+	}
 
 	update := false
 	request := vpc.CreateModifyVSwitchAttributeRequest()
@@ -121,6 +163,11 @@ func resourceAliyunSwitchUpdate(d *schema.ResourceData, meta interface{}) error
 		request.Description = d.Get("description").(string)
 		update = true
 	}
+
+	if v, ok := d.GetOkExists("ipv6_cidr_block_mask"); ok && d.HasChange("ipv6_cidr_block_mask") {
+		request.Ipv6CidrBlock = requests.NewInteger(v.(int))
+		update = true
+	}
 	if update {
 		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
 			return vpcClient.ModifyVSwitchAttribute(request)
@@ -129,7 +176,12 @@ func resourceAliyunSwitchUpdate(d *schema.ResourceData, meta interface{}) error
 			return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
 		}
 		addDebug(request.GetActionName(), raw)
+		d.SetPartial("name")
+		d.SetPartial("description")
+		d.SetPartial("ipv6_cidr_block_mask")
 	}
+
+	d.Partial(false)
 	return resourceAliyunSwitchRead(d, meta)
 }
 
@@ -161,6 +213,16 @@ func resourceAliyunSwitchDelete(d *schema.ResourceData, meta interface{}) error
 	return WrapError(vpcService.WaitForVSwitch(d.Id(), Deleted, DefaultTimeout))
 }
 
+// getIpv6CidrBlockMask returns the last 8 bits of the /64 IPv6 CIDR block of the vswitch, which tell it from the
+// other vswitches in the /56 IPv6 CIDR block of the VPC.
+func getIpv6CidrBlockMask(cidrBlock string) (int, error) {
+	ip, _, err := net.ParseCIDR(cidrBlock)
+	if err != nil {
+		return 0, WrapError(err)
+	}
+	return int(ip.To16()[7]), nil
+}
+
 func buildAliyunSwitchArgs(d *schema.ResourceData, meta interface{}) (*vpc.CreateVSwitchRequest, error) {
 	client := meta.(*connectivity.AliyunClient)
 	ecsService := EcsService{client}
@@ -185,6 +247,10 @@ func buildAliyunSwitchArgs(d *schema.ResourceData, meta interface{}) (*vpc.Creat
 	if v, ok := d.GetOk("description"); ok && v != "" {
 		request.Description = v.(string)
 	}
+
+	if v, ok := d.GetOkExists("ipv6_cidr_block_mask"); ok {
+		request.Ipv6CidrBlock = requests.NewInteger(v.(int))
+	}
 	request.ClientToken = buildClientToken(request.GetActionName())
 
 	return request, nil
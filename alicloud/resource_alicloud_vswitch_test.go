@@ -224,12 +224,25 @@ func TestAccAlicloudVSwitchBasic(t *testing.T) {
 					}),
 				),
 			},
+			{
+				Config: testAccVSwitchConfig_tags(rand),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"tags.%":       "2",
+						"tags.Created": "TF",
+						"tags.For":     "acceptance test",
+					}),
+				),
+			},
 			{
 				Config: testAccVSwitchConfig_all(rand),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheck(map[string]string{
-						"name":        fmt.Sprintf("tf-testAccVswitchConfig%d_all", rand),
-						"description": fmt.Sprintf("tf-testAccVswitchConfig%d_description_all", rand),
+						"name":         fmt.Sprintf("tf-testAccVswitchConfig%d_all", rand),
+						"description":  fmt.Sprintf("tf-testAccVswitchConfig%d_description_all", rand),
+						"tags.%":       REMOVEKEY,
+						"tags.Created": REMOVEKEY,
+						"tags.For":     REMOVEKEY,
 					}),
 				),
 			},
@@ -237,6 +250,55 @@ func TestAccAlicloudVSwitchBasic(t *testing.T) {
 	})
 }
 
+func TestAccAlicloudVSwitchIpv6(t *testing.T) {
+	var v vpc.DescribeVSwitchAttributesResponse
+	resourceId := "alicloud_vswitch.default"
+	ra := resourceAttrInit(resourceId, testAccCheckVSwitchCheckMap)
+	serviceFunc := func() interface{} {
+		return &VpcService{testAccProvider.Meta().(*connectivity.AliyunClient)}
+	}
+	rc := resourceCheckInitWithDescribeMethod(resourceId, &v, serviceFunc, "DescribeVSwitch")
+	rac := resourceAttrCheckInit(rc, ra)
+
+	rand := acctest.RandInt()
+	testAccCheck := rac.resourceAttrMapUpdateSet()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		// module name
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckVSwitchDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVSwitchConfigIpv6(rand, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"name": fmt.Sprintf("tf-testAccVswitchConfig%d", rand),
+					}),
+				),
+			},
+			{
+				Config: testAccVSwitchConfigIpv6(rand, "ipv6_cidr_block_mask = 1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"ipv6_cidr_block_mask": "1",
+						"ipv6_cidr_block":      CHECKSET,
+					}),
+				),
+			},
+			{
+				ResourceName:      resourceId,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func TestAccAlicloudVSwitchMulti(t *testing.T) {
 	var v vpc.DescribeVSwitchAttributesResponse
 	resourceId := "alicloud_vswitch.default.2"
@@ -343,6 +405,34 @@ resource "alicloud_vswitch" "default" {
 `, rand)
 }
 
+func testAccVSwitchConfig_tags(rand int) string {
+	return fmt.Sprintf(
+		`
+data "alicloud_zones" "default" {
+	available_resource_creation= "VSwitch"
+}
+variable "name" {
+  default = "tf-testAccVswitchConfig%d"
+}
+resource "alicloud_vpc" "default" {
+  name = "${var.name}"
+  cidr_block = "172.16.0.0/12"
+}
+
+resource "alicloud_vswitch" "default" {
+  vpc_id = "${alicloud_vpc.default.id}"
+  cidr_block = "172.16.0.0/24"
+  availability_zone = "${data.alicloud_zones.default.zones.0.id}"
+  name = "${var.name}_change"
+  description = "${var.name}_description"
+  tags = {
+    Created = "TF"
+    For     = "acceptance test"
+  }
+}
+`, rand)
+}
+
 func testAccVSwitchConfig_all(rand int) string {
 	return fmt.Sprintf(
 		`
@@ -367,6 +457,31 @@ resource "alicloud_vswitch" "default" {
 `, rand)
 }
 
+func testAccVSwitchConfigIpv6(rand int, ipv6CidrBlockMask string) string {
+	return fmt.Sprintf(
+		`
+data "alicloud_zones" "default" {
+	available_resource_creation= "VSwitch"
+}
+variable "name" {
+  default = "tf-testAccVswitchConfig%d"
+}
+resource "alicloud_vpc" "default" {
+  name = "${var.name}"
+  cidr_block = "172.16.0.0/12"
+  enable_ipv6 = true
+}
+
+resource "alicloud_vswitch" "default" {
+  vpc_id = "${alicloud_vpc.default.id}"
+  cidr_block = "172.16.0.0/24"
+  availability_zone = "${data.alicloud_zones.default.zones.0.id}"
+  name = "${var.name}"
+  %s
+}
+`, rand, ipv6CidrBlockMask)
+}
+
 func testAccVSwitchConfigMulti(rand int) string {
 	return fmt.Sprintf(
 		`
@@ -1,6 +1,7 @@
 package alicloud
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -250,6 +251,41 @@ func (s *EcsService) DescribeSecurityGroupRule(id string) (rule ecs.Permission,
 
 }
 
+// DescribeSecurityGroupPermissions returns all of the ingress and egress rules of the security group. The rules of a
+// classic network group are described by each nic type.
+func (s *EcsService) DescribeSecurityGroupPermissions(id string) (group ecs.DescribeSecurityGroupAttributeResponse, permissions []ecs.Permission, err error) {
+	group, err = s.DescribeSecurityGroup(id)
+	if err != nil {
+		return
+	}
+	nicTypes := []GroupRuleNicType{GroupRuleIntranet}
+	if group.VpcId == "" {
+		nicTypes = append(nicTypes, GroupRuleInternet)
+	}
+	for _, nicType := range nicTypes {
+		request := ecs.CreateDescribeSecurityGroupAttributeRequest()
+		request.RegionId = s.client.RegionId
+		request.SecurityGroupId = id
+		request.NicType = string(nicType)
+		raw, e := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.DescribeSecurityGroupAttribute(request)
+		})
+		if e != nil {
+			err = WrapErrorf(e, DefaultErrorMsg, id, request.GetActionName(), AlibabaCloudSdkGoERROR)
+			return
+		}
+		addDebug(request.GetActionName(), raw)
+		response, _ := raw.(*ecs.DescribeSecurityGroupAttributeResponse)
+		for _, permission := range response.Permissions.Permission {
+			if permission.NicType == "" {
+				permission.NicType = string(nicType)
+			}
+			permissions = append(permissions, permission)
+		}
+	}
+	return
+}
+
 func (s *EcsService) DescribeAvailableResources(d *schema.ResourceData, meta interface{}, destination DestinationResource) (zoneId string, validZones []ecs.AvailableZone, err error) {
 	client := meta.(*connectivity.AliyunClient)
 	// Before creating resources, check input parameters validity according available zone.
@@ -283,6 +319,11 @@ func (s *EcsService) DescribeAvailableResources(d *schema.ResourceData, meta int
 		request.IoOptimized = string(NoneOptimized)
 	}
 
+	// Only the resources of the dedicated host are available to the instances placed on it
+	if v, ok := d.GetOk("dedicated_host_id"); ok && strings.TrimSpace(v.(string)) != "" {
+		request.DedicatedHostId = strings.TrimSpace(v.(string))
+	}
+
 	raw, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
 		return ecsClient.DescribeAvailableResource(request)
 	})
@@ -655,6 +696,140 @@ func (s *EcsService) WaitForDisk(id string, status Status, timeout int) error {
 	}
 }
 
+// diskPerformanceLevelResponse is the performance level of the ESSD disks, which the Disk of the ECS SDK does not have yet.
+type diskPerformanceLevelResponse struct {
+	Disks struct {
+		Disk []struct {
+			DiskId           string `json:"DiskId"`
+			PerformanceLevel string `json:"PerformanceLevel"`
+		} `json:"Disk"`
+	} `json:"Disks"`
+}
+
+func (s *EcsService) DescribeDiskPerformanceLevel(id string) (string, error) {
+	request := ecs.CreateDescribeDisksRequest()
+	request.RegionId = s.client.RegionId
+	request.DiskIds = convertListToJsonString([]interface{}{id})
+
+	raw, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.DescribeDisks(request)
+	})
+	if err != nil {
+		return "", WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+	response, _ := raw.(*ecs.DescribeDisksResponse)
+	var object diskPerformanceLevelResponse
+	if err := json.Unmarshal(response.GetHttpContentBytes(), &object); err != nil {
+		return "", WrapError(err)
+	}
+	for _, disk := range object.Disks.Disk {
+		if disk.DiskId == id {
+			return disk.PerformanceLevel, nil
+		}
+	}
+	return "", WrapErrorf(Error("%s", GetNotFoundMessage("Disk", id)), NotFoundMsg, ProviderERROR)
+}
+
+// ModifyDiskSpec changes the category or the performance level of the disk in place and waits for the disk
+// to have them and to be back to its former status.
+func (s *EcsService) ModifyDiskSpec(id, category, performanceLevel string) error {
+	disk, err := s.DescribeDisk(id)
+	if err != nil {
+		return WrapError(err)
+	}
+
+	// ModifyDiskSpec is not supported by the ECS SDK yet
+	request := requests.NewCommonRequest()
+	request.Product = "Ecs"
+	request.ServiceCode = "ecs"
+	request.Version = string(connectivity.ApiVersion20140526)
+	request.ApiName = "ModifyDiskSpec"
+	request.RegionId = s.client.RegionId
+	request.QueryParams["RegionId"] = s.client.RegionId
+	request.QueryParams["DiskId"] = id
+	if category != "" && category != disk.Category {
+		request.QueryParams["DiskCategory"] = category
+	}
+	if performanceLevel != "" {
+		request.QueryParams["PerformanceLevel"] = performanceLevel
+	}
+	err = resource.Retry(5*time.Minute, func() *resource.RetryError {
+		raw, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.ProcessCommonRequest(request)
+		})
+		if err != nil {
+			if IsExceptedErrors(err, DiskInvalidOperation) {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.ApiName, raw)
+		return nil
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, id, request.ApiName, AlibabaCloudSdkGoERROR)
+	}
+
+	// The request returns before the disk is changed, and the disk keeps its status while it is being changed
+	if category == "" {
+		category = disk.Category
+	}
+	stateConf := BuildStateConf([]string{"Modifying"}, []string{"Modified"}, time.Duration(DefaultTimeoutMedium)*time.Second, 3*time.Second, s.DiskSpecStateRefreshFunc(id, category, performanceLevel))
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, id)
+	}
+	return WrapError(s.WaitForDisk(id, Status(disk.Status), DefaultTimeoutMedium))
+}
+
+// DiskSpecStateRefreshFunc reports the disk as Modified once it has the category and the performance level, which is
+// not checked if it is empty.
+func (s *EcsService) DiskSpecStateRefreshFunc(id, category, performanceLevel string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		object, err := s.DescribeDisk(id)
+		if err != nil {
+			return nil, "", WrapError(err)
+		}
+		if object.Category != category {
+			return object, "Modifying", nil
+		}
+		if performanceLevel != "" {
+			current, err := s.DescribeDiskPerformanceLevel(id)
+			if err != nil {
+				return nil, "", WrapError(err)
+			}
+			if current != performanceLevel {
+				return object, "Modifying", nil
+			}
+		}
+		return object, "Modified", nil
+	}
+}
+
+// ResizeDisk expands the disk online, which takes effect without restarting the instance, and falls back to
+// the offline resizing when the disk or its instance does not support it.
+func (s *EcsService) ResizeDisk(id string, size int) error {
+	request := ecs.CreateResizeDiskRequest()
+	request.RegionId = s.client.RegionId
+	request.DiskId = id
+	request.NewSize = requests.NewInteger(size)
+	request.Type = string(DiskResizeTypeOnline)
+	raw, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.ResizeDisk(request)
+	})
+	if IsExceptedErrors(err, DiskNotSupportOnlineChangeErrors) {
+		request.Type = string(DiskResizeTypeOffline)
+		raw, err = s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.ResizeDisk(request)
+		})
+	}
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+	return nil
+}
+
 func (s *EcsService) WaitForSecurityGroup(id string, status Status, timeout int) error {
 	deadline := time.Now().Add(time.Duration(timeout) * time.Second)
 
@@ -816,31 +991,173 @@ func (s *EcsService) WaitForPrivateIpsListChanged(eniId string, ipList []string)
 			return fmt.Errorf("Query private IP failed, %s", err)
 		}
 
-		if len(ips) != len(ipList) {
-			continue
+		if sameIpList(ips, ipList) {
+			return nil
 		}
+	}
+}
 
-		diff := false
-		for i := range ips {
-			exist := false
-			for j := range ipList {
-				if ips[i] == ipList[j] {
-					exist = true
-					break
-				}
-			}
-			if !exist {
-				diff = true
+// sameIpList reports whether the two lists contain the same IP addresses regardless of the order.
+func sameIpList(ips, ipList []string) bool {
+	if len(ips) != len(ipList) {
+		return false
+	}
+	for i := range ips {
+		exist := false
+		for j := range ipList {
+			if ips[i] == ipList[j] {
+				exist = true
 				break
 			}
 		}
+		if !exist {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *EcsService) QueryIpv6Addresses(eniId string) ([]string, error) {
+	eni, err := s.DescribeNetworkInterface(eniId)
+	if err != nil {
+		return nil, WrapError(err)
+	}
+	ips := make([]string, 0, len(eni.Ipv6Sets.Ipv6Set))
+	for _, ip := range eni.Ipv6Sets.Ipv6Set {
+		ips = append(ips, ip.Ipv6Address)
+	}
+	return ips, nil
+}
+
+func (s *EcsService) WaitForIpv6AddressesCountChanged(eniId string, count int) error {
+	deadline := time.Now().Add(DefaultTimeout * time.Second)
+	for {
+		if time.Now().After(deadline) {
+			return WrapError(Error("Wait for IPv6 addresses count changed timeout"))
+		}
+		time.Sleep(DefaultIntervalShort * time.Second)
+
+		ips, err := s.QueryIpv6Addresses(eniId)
+		if err != nil {
+			return WrapError(err)
+		}
+		if len(ips) == count {
+			return nil
+		}
+	}
+}
+
+func (s *EcsService) WaitForIpv6AddressesListChanged(eniId string, ipList []string) error {
+	deadline := time.Now().Add(DefaultTimeout * time.Second)
+	for {
+		if time.Now().After(deadline) {
+			return WrapError(Error("Wait for IPv6 addresses list changed timeout"))
+		}
+		time.Sleep(DefaultIntervalShort * time.Second)
 
-		if !diff {
+		ips, err := s.QueryIpv6Addresses(eniId)
+		if err != nil {
+			return WrapError(err)
+		}
+		if sameIpList(ips, ipList) {
 			return nil
 		}
 	}
 }
 
+// AssignPrivateIpAddresses assigns the given secondary private IP addresses, or the count of them when ips is empty, to the ENI.
+func (s *EcsService) AssignPrivateIpAddresses(eniId string, ips []string, count int) error {
+	request := ecs.CreateAssignPrivateIpAddressesRequest()
+	request.RegionId = s.client.RegionId
+	request.NetworkInterfaceId = eniId
+	if len(ips) > 0 {
+		request.PrivateIpAddress = &ips
+	} else {
+		request.SecondaryPrivateIpAddressCount = requests.NewInteger(count)
+	}
+	return s.invokeNetworkInterfaceRequest(eniId, request.GetActionName(), func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.AssignPrivateIpAddresses(request)
+	})
+}
+
+func (s *EcsService) UnassignPrivateIpAddresses(eniId string, ips []string) error {
+	request := ecs.CreateUnassignPrivateIpAddressesRequest()
+	request.RegionId = s.client.RegionId
+	request.NetworkInterfaceId = eniId
+	request.PrivateIpAddress = &ips
+	return s.invokeNetworkInterfaceRequest(eniId, request.GetActionName(), func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.UnassignPrivateIpAddresses(request)
+	})
+}
+
+// AssignIpv6Addresses assigns the given IPv6 addresses, or the count of them when ips is empty, to the ENI.
+func (s *EcsService) AssignIpv6Addresses(eniId string, ips []string, count int) error {
+	request := ecs.CreateAssignIpv6AddressesRequest()
+	request.RegionId = s.client.RegionId
+	request.NetworkInterfaceId = eniId
+	if len(ips) > 0 {
+		request.Ipv6Address = &ips
+	} else {
+		request.Ipv6AddressCount = requests.NewInteger(count)
+	}
+	return s.invokeNetworkInterfaceRequest(eniId, request.GetActionName(), func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.AssignIpv6Addresses(request)
+	})
+}
+
+func (s *EcsService) UnassignIpv6Addresses(eniId string, ips []string) error {
+	request := ecs.CreateUnassignIpv6AddressesRequest()
+	request.RegionId = s.client.RegionId
+	request.NetworkInterfaceId = eniId
+	request.Ipv6Address = &ips
+	return s.invokeNetworkInterfaceRequest(eniId, request.GetActionName(), func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.UnassignIpv6Addresses(request)
+	})
+}
+
+// invokeNetworkInterfaceRequest retries the request while the ENI or its instance is being changed.
+func (s *EcsService) invokeNetworkInterfaceRequest(eniId, action string, do func(*ecs.Client) (interface{}, error)) error {
+	err := resource.Retry(DefaultTimeout*time.Second, func() *resource.RetryError {
+		raw, err := s.client.WithEcsClient(do)
+		if err != nil {
+			if IsExceptedErrors(err, NetworkInterfaceInvalidOperations) {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(action, raw)
+		return nil
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, eniId, action, AlibabaCloudSdkGoERROR)
+	}
+	return nil
+}
+
+// DescribeInstancePrimaryNetworkInterface returns the primary ENI of the VPC instance.
+func (s *EcsService) DescribeInstancePrimaryNetworkInterface(instanceId string) (eni ecs.NetworkInterfaceSet, err error) {
+	request := ecs.CreateDescribeNetworkInterfacesRequest()
+	request.RegionId = s.client.RegionId
+	request.InstanceId = instanceId
+	request.Type = "Primary"
+	raw, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.DescribeNetworkInterfaces(request)
+	})
+	if err != nil {
+		err = WrapErrorf(err, DefaultErrorMsg, instanceId, request.GetActionName(), AlibabaCloudSdkGoERROR)
+		return
+	}
+	addDebug(request.GetActionName(), raw)
+	response := raw.(*ecs.DescribeNetworkInterfacesResponse)
+	for _, object := range response.NetworkInterfaceSets.NetworkInterfaceSet {
+		if object.InstanceId == instanceId {
+			return object, nil
+		}
+	}
+	err = WrapErrorf(Error("%s", GetNotFoundMessage("PrimaryNetworkInterface", instanceId)), NotFoundMsg, ProviderERROR)
+	return
+}
+
 func (s *EcsService) WaitForModifySecurityGroupPolicy(id, target string, timeout int) error {
 	deadline := time.Now().Add(time.Duration(timeout) * time.Second)
 	for {
@@ -938,6 +1255,317 @@ func (s *EcsService) DescribeSnapshot(id string) (*ecs.Snapshot, error) {
 	return &response.Snapshots.Snapshot[0], nil
 }
 
+// DescribeImage returns the custom image in any status, since DescribeImages only returns the available images by default.
+func (s *EcsService) DescribeImage(id string) (*ecs.Image, error) {
+	request := ecs.CreateDescribeImagesRequest()
+	request.RegionId = s.client.RegionId
+	request.ImageId = id
+	request.ImageOwnerAlias = "self"
+	request.Status = strings.Join([]string{string(ImageWaiting), string(ImageCreating), string(ImageAvailable), string(ImageUnAvailable), string(ImageCreateFailed)}, ",")
+	raw, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.DescribeImages(request)
+	})
+	if err != nil {
+		return nil, WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+	response := raw.(*ecs.DescribeImagesResponse)
+	if len(response.Images.Image) != 1 || response.Images.Image[0].ImageId != id {
+		return nil, WrapErrorf(Error("%s", GetNotFoundMessage("Image", id)), NotFoundMsg, ProviderERROR)
+	}
+	return &response.Images.Image[0], nil
+}
+
+func (s *EcsService) ImageStateRefreshFunc(id string, failStates []string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		object, err := s.DescribeImage(id)
+		if err != nil {
+			if NotFoundError(err) {
+				// Set this to nil as if we didn't find anything.
+				return nil, "", nil
+			}
+			return nil, "", WrapError(err)
+		}
+
+		for _, failState := range failStates {
+			if object.Status == failState {
+				return object, object.Status, WrapError(Error(FailedToReachTargetStatus, object.Status))
+			}
+		}
+		return object, object.Status, nil
+	}
+}
+
+// DescribeImageSharePermission returns the account which the image is shared with. The id is <image id>:<account id>.
+func (s *EcsService) DescribeImageSharePermission(id string) (*ecs.Account, error) {
+	parts := strings.Split(id, COLON_SEPARATED)
+	if len(parts) != 2 {
+		return nil, WrapError(Error("invalid resource id %s, it should be <image id>:<account id>", id))
+	}
+	request := ecs.CreateDescribeImageSharePermissionRequest()
+	request.RegionId = s.client.RegionId
+	request.ImageId = parts[0]
+	request.PageSize = requests.NewInteger(PageSizeLarge)
+	request.PageNumber = requests.NewInteger(1)
+	for {
+		raw, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.DescribeImageSharePermission(request)
+		})
+		if err != nil {
+			if IsExceptedError(err, ImageNotFound) {
+				return nil, WrapErrorf(err, NotFoundMsg, AlibabaCloudSdkGoERROR)
+			}
+			return nil, WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), AlibabaCloudSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw)
+		response := raw.(*ecs.DescribeImageSharePermissionResponse)
+		for _, account := range response.Accounts.Account {
+			if account.AliyunId == parts[1] {
+				return &account, nil
+			}
+		}
+		if len(response.Accounts.Account) < PageSizeLarge {
+			break
+		}
+		page, err := getNextpageNumber(request.PageNumber)
+		if err != nil {
+			return nil, WrapError(err)
+		}
+		request.PageNumber = page
+	}
+	return nil, WrapErrorf(Error("%s", GetNotFoundMessage("ImageSharePermission", id)), NotFoundMsg, ProviderERROR)
+}
+
+func (s *EcsService) DescribeEcsDeploymentSet(id string) (*ecs.DeploymentSet, error) {
+	request := ecs.CreateDescribeDeploymentSetsRequest()
+	request.RegionId = s.client.RegionId
+	request.DeploymentSetIds = convertListToJsonString([]interface{}{id})
+	raw, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.DescribeDeploymentSets(request)
+	})
+	if err != nil {
+		return nil, WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+	response := raw.(*ecs.DescribeDeploymentSetsResponse)
+	if len(response.DeploymentSets.DeploymentSet) != 1 || response.DeploymentSets.DeploymentSet[0].DeploymentSetId != id {
+		return nil, WrapErrorf(Error("%s", GetNotFoundMessage("DeploymentSet", id)), NotFoundMsg, ProviderERROR)
+	}
+	return &response.DeploymentSets.DeploymentSet[0], nil
+}
+
+func (s *EcsService) DescribeEcsDedicatedHost(id string) (*ecs.DedicatedHost, error) {
+	request := ecs.CreateDescribeDedicatedHostsRequest()
+	request.RegionId = s.client.RegionId
+	request.DedicatedHostIds = convertListToJsonString([]interface{}{id})
+	raw, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.DescribeDedicatedHosts(request)
+	})
+	if err != nil {
+		return nil, WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+	response := raw.(*ecs.DescribeDedicatedHostsResponse)
+	if len(response.DedicatedHosts.DedicatedHost) != 1 || response.DedicatedHosts.DedicatedHost[0].DedicatedHostId != id {
+		return nil, WrapErrorf(Error("%s", GetNotFoundMessage("DedicatedHost", id)), NotFoundMsg, ProviderERROR)
+	}
+	return &response.DedicatedHosts.DedicatedHost[0], nil
+}
+
+func (s *EcsService) EcsDedicatedHostStateRefreshFunc(id string, failStates []string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		object, err := s.DescribeEcsDedicatedHost(id)
+		if err != nil {
+			if NotFoundError(err) {
+				// Set this to nil as if we didn't find anything.
+				return nil, "", nil
+			}
+			return nil, "", WrapError(err)
+		}
+
+		for _, failState := range failStates {
+			if object.Status == failState {
+				return object, object.Status, WrapError(Error(FailedToReachTargetStatus, object.Status))
+			}
+		}
+		return object, object.Status, nil
+	}
+}
+
+func (s *EcsService) DescribeEcsHpcCluster(id string) (*ecs.HpcCluster, error) {
+	request := ecs.CreateDescribeHpcClustersRequest()
+	request.RegionId = s.client.RegionId
+	request.HpcClusterIds = convertListToJsonString([]interface{}{id})
+	raw, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.DescribeHpcClusters(request)
+	})
+	if err != nil {
+		return nil, WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+	response := raw.(*ecs.DescribeHpcClustersResponse)
+	if len(response.HpcClusters.HpcCluster) != 1 || response.HpcClusters.HpcCluster[0].HpcClusterId != id {
+		return nil, WrapErrorf(Error("%s", GetNotFoundMessage("HpcCluster", id)), NotFoundMsg, ProviderERROR)
+	}
+	return &response.HpcClusters.HpcCluster[0], nil
+}
+
+func (s *EcsService) DescribeEcsCommand(id string) (*ecs.Command, error) {
+	request := ecs.CreateDescribeCommandsRequest()
+	request.RegionId = s.client.RegionId
+	request.CommandId = id
+	raw, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.DescribeCommands(request)
+	})
+	if err != nil {
+		return nil, WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+	response := raw.(*ecs.DescribeCommandsResponse)
+	if len(response.Commands.Command) != 1 || response.Commands.Command[0].CommandId != id {
+		return nil, WrapErrorf(Error("%s", GetNotFoundMessage("Command", id)), NotFoundMsg, ProviderERROR)
+	}
+	return &response.Commands.Command[0], nil
+}
+
+func (s *EcsService) DescribeEcsInvocation(id string) (*ecs.Invocation, error) {
+	request := ecs.CreateDescribeInvocationsRequest()
+	request.RegionId = s.client.RegionId
+	request.InvokeId = id
+	raw, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.DescribeInvocations(request)
+	})
+	if err != nil {
+		return nil, WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+	response := raw.(*ecs.DescribeInvocationsResponse)
+	if len(response.Invocations.Invocation) != 1 || response.Invocations.Invocation[0].InvokeId != id {
+		return nil, WrapErrorf(Error("%s", GetNotFoundMessage("Invocation", id)), NotFoundMsg, ProviderERROR)
+	}
+	return &response.Invocations.Invocation[0], nil
+}
+
+func (s *EcsService) EcsInvocationStateRefreshFunc(id string, failStates []string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		object, err := s.DescribeEcsInvocation(id)
+		if err != nil {
+			if NotFoundError(err) {
+				// Set this to nil as if we didn't find anything.
+				return nil, "", nil
+			}
+			return nil, "", WrapError(err)
+		}
+
+		for _, failState := range failStates {
+			if object.InvokeStatus == failState {
+				return object, object.InvokeStatus, WrapError(Error(FailedToReachTargetStatus, object.InvokeStatus))
+			}
+		}
+		return object, object.InvokeStatus, nil
+	}
+}
+
+// DescribeEcsInvocationResults returns the results of the invocation on each of its instances.
+func (s *EcsService) DescribeEcsInvocationResults(id string) ([]ecs.InvocationResult, error) {
+	request := ecs.CreateDescribeInvocationResultsRequest()
+	request.RegionId = s.client.RegionId
+	request.InvokeId = id
+	request.PageSize = requests.NewInteger(PageSizeLarge)
+	request.PageNumber = requests.NewInteger(1)
+	var results []ecs.InvocationResult
+	for {
+		raw, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.DescribeInvocationResults(request)
+		})
+		if err != nil {
+			return nil, WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), AlibabaCloudSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw)
+		response := raw.(*ecs.DescribeInvocationResultsResponse)
+		results = append(results, response.Invocation.InvocationResults.InvocationResult...)
+		if len(response.Invocation.InvocationResults.InvocationResult) < PageSizeLarge {
+			break
+		}
+		page, err := getNextpageNumber(request.PageNumber)
+		if err != nil {
+			return nil, WrapError(err)
+		}
+		request.PageNumber = page
+	}
+	return results, nil
+}
+
+// DescribeEcsFleet returns the fleet, a fleet which has been deleted is reported as not found
+// although it is still listed with a deleted status for a while.
+func (s *EcsService) DescribeEcsFleet(id string) (*ecs.Fleet, error) {
+	request := ecs.CreateDescribeFleetsRequest()
+	request.RegionId = s.client.RegionId
+	request.FleetId = &[]string{id}
+	raw, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.DescribeFleets(request)
+	})
+	if err != nil {
+		return nil, WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+	response := raw.(*ecs.DescribeFleetsResponse)
+	if len(response.Fleets.Fleet) != 1 || response.Fleets.Fleet[0].FleetId != id ||
+		strings.HasPrefix(response.Fleets.Fleet[0].Status, "deleted") {
+		return nil, WrapErrorf(Error("%s", GetNotFoundMessage("Fleet", id)), NotFoundMsg, ProviderERROR)
+	}
+	return &response.Fleets.Fleet[0], nil
+}
+
+func (s *EcsService) EcsFleetStateRefreshFunc(id string, failStates []string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		object, err := s.DescribeEcsFleet(id)
+		if err != nil {
+			if NotFoundError(err) {
+				// Set this to nil as if we didn't find anything.
+				return nil, "", nil
+			}
+			return nil, "", WrapError(err)
+		}
+
+		for _, failState := range failStates {
+			if object.Status == failState {
+				return object, object.Status, WrapError(Error(FailedToReachTargetStatus, object.Status))
+			}
+		}
+		return object, object.Status, nil
+	}
+}
+
+func (s *EcsService) DescribeEcsFleetInstances(id string) ([]ecs.Instance, error) {
+	request := ecs.CreateDescribeFleetInstancesRequest()
+	request.RegionId = s.client.RegionId
+	request.FleetId = id
+	request.PageSize = requests.NewInteger(PageSizeLarge)
+	request.PageNumber = requests.NewInteger(1)
+	var instances []ecs.Instance
+	for {
+		raw, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.DescribeFleetInstances(request)
+		})
+		if err != nil {
+			return nil, WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), AlibabaCloudSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw)
+		response := raw.(*ecs.DescribeFleetInstancesResponse)
+		instances = append(instances, response.Instances.Instance...)
+		if len(response.Instances.Instance) < PageSizeLarge {
+			break
+		}
+		page, err := getNextpageNumber(request.PageNumber)
+		if err != nil {
+			return nil, WrapError(err)
+		}
+		request.PageNumber = page
+	}
+	return instances, nil
+}
+
 func (s *EcsService) DescribeSnapshotPolicy(id string) (*ecs.AutoSnapshotPolicy, error) {
 	request := ecs.CreateDescribeAutoSnapshotPolicyExRequest()
 	request.AutoSnapshotPolicyId = id
@@ -983,6 +1611,64 @@ func (s *EcsService) WaitForSnapshotPolicy(id string, status Status, timeout int
 	}
 }
 
+// DescribeSnapshotPolicyDisks returns the disks which the auto snapshot policy is applied to.
+func (s *EcsService) DescribeSnapshotPolicyDisks(id string) (disks []ecs.Disk, err error) {
+	request := ecs.CreateDescribeDisksRequest()
+	request.RegionId = s.client.RegionId
+	request.AutoSnapshotPolicyId = id
+	request.PageSize = requests.NewInteger(PageSizeLarge)
+	request.PageNumber = requests.NewInteger(1)
+	for {
+		raw, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.DescribeDisks(request)
+		})
+		if err != nil {
+			return nil, WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), AlibabaCloudSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw)
+		response, _ := raw.(*ecs.DescribeDisksResponse)
+		disks = append(disks, response.Disks.Disk...)
+		if len(response.Disks.Disk) < PageSizeLarge {
+			break
+		}
+		page, err := getNextpageNumber(request.PageNumber)
+		if err != nil {
+			return nil, WrapError(err)
+		}
+		request.PageNumber = page
+	}
+	return disks, nil
+}
+
+func (s *EcsService) ApplyAutoSnapshotPolicy(id string, diskIds []string) error {
+	request := ecs.CreateApplyAutoSnapshotPolicyRequest()
+	request.RegionId = s.client.RegionId
+	request.AutoSnapshotPolicyId = id
+	request.DiskIds = convertListToJsonString(flattenStringList(diskIds))
+	raw, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.ApplyAutoSnapshotPolicy(request)
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+	return nil
+}
+
+func (s *EcsService) CancelAutoSnapshotPolicy(diskIds []string) error {
+	request := ecs.CreateCancelAutoSnapshotPolicyRequest()
+	request.RegionId = s.client.RegionId
+	request.DiskIds = convertListToJsonString(flattenStringList(diskIds))
+	raw, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.CancelAutoSnapshotPolicy(request)
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, strings.Join(diskIds, ","), request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+	return nil
+}
+
 func (s *EcsService) DescribeLaunchTemplate(id string) (set ecs.LaunchTemplateSet, err error) {
 
 	request := ecs.CreateDescribeLaunchTemplatesRequest()
@@ -1008,7 +1694,23 @@ func (s *EcsService) DescribeLaunchTemplate(id string) (set ecs.LaunchTemplateSe
 
 }
 
-func (s *EcsService) DescribeLaunchTemplateVersion(id string, version int) (set ecs.LaunchTemplateVersionSet, err error) {
+// launchTemplatePlacement is the placement of the instances in the data of a launch template version, which the
+// LaunchTemplateData of the ECS SDK does not have yet.
+type launchTemplatePlacement struct {
+	DeploymentSetId string `json:"DeploymentSetId"`
+	DedicatedHostId string `json:"DedicatedHostId"`
+	HpcClusterId    string `json:"HpcClusterId"`
+}
+
+type launchTemplateVersionsPlacementResponse struct {
+	LaunchTemplateVersionSets struct {
+		LaunchTemplateVersionSet []struct {
+			LaunchTemplateData launchTemplatePlacement `json:"LaunchTemplateData"`
+		} `json:"LaunchTemplateVersionSet"`
+	} `json:"LaunchTemplateVersionSets"`
+}
+
+func (s *EcsService) DescribeLaunchTemplateVersion(id string, version int) (set ecs.LaunchTemplateVersionSet, placement launchTemplatePlacement, err error) {
 
 	request := ecs.CreateDescribeLaunchTemplateVersionsRequest()
 	request.RegionId = s.client.RegionId
@@ -1033,7 +1735,16 @@ func (s *EcsService) DescribeLaunchTemplateVersion(id string, version int) (set
 		return
 	}
 
-	return response.LaunchTemplateVersionSets.LaunchTemplateVersionSet[0], nil
+	var placementResponse launchTemplateVersionsPlacementResponse
+	if err = json.Unmarshal(response.GetHttpContentBytes(), &placementResponse); err != nil {
+		err = WrapError(err)
+		return
+	}
+	if len(placementResponse.LaunchTemplateVersionSets.LaunchTemplateVersionSet) == 1 {
+		placement = placementResponse.LaunchTemplateVersionSets.LaunchTemplateVersionSet[0].LaunchTemplateData
+	}
+
+	return response.LaunchTemplateVersionSets.LaunchTemplateVersionSet[0], placement, nil
 
 }
 
@@ -2,8 +2,6 @@ package alicloud
 
 import (
 	"encoding/json"
-	"log"
-	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -963,149 +961,3 @@ func (s *RdsService) NotFoundDBInstance(err error) bool {
 
 	return false
 }
-
-const tagsMaxNumPerTime = 5
-
-func (s *RdsService) setInstanceTags(d *schema.ResourceData) error {
-	if d.HasChange("tags") {
-		oraw, nraw := d.GetChange("tags")
-		o := oraw.(map[string]interface{})
-		n := nraw.(map[string]interface{})
-		create, remove := diffTags(tagsFromMap(o), tagsFromMap(n))
-
-		if len(remove) > 0 {
-			if err := s.removeTags(remove, d.Id()); err != nil {
-				return err
-			}
-		}
-
-		if len(create) > 0 {
-			if err := s.addTags(create, d.Id()); err != nil {
-				return err
-			}
-		}
-
-		d.SetPartial("tags")
-	}
-
-	return nil
-}
-
-func (s *RdsService) addTags(tags []Tag, instanceId string) error {
-	return s.doBatchTags(s.addTagsPerTime, tags, instanceId)
-}
-
-func (s *RdsService) addTagsPerTime(tags []Tag, id string) error {
-	request := rds.CreateAddTagsToResourceRequest()
-	request.DBInstanceId = id
-	request.Tags = s.tagsToString(tags)
-
-	raw, err := s.client.WithRdsClient(func(client *rds.Client) (interface{}, error) {
-		return client.AddTagsToResource(request)
-	})
-	if err != nil {
-		return WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), AlibabaCloudSdkGoERROR)
-	}
-	addDebug(request.GetActionName(), raw)
-	return nil
-}
-
-func (s *RdsService) removeTags(tags []Tag, id string) error {
-	return s.doBatchTags(s.removeTagsPerTime, tags, id)
-}
-
-func (s *RdsService) removeTagsPerTime(tags []Tag, id string) error {
-	request := rds.CreateRemoveTagsFromResourceRequest()
-	request.DBInstanceId = id
-	request.Tags = s.tagsToString(tags)
-
-	raw, err := s.client.WithRdsClient(func(client *rds.Client) (interface{}, error) {
-		return client.RemoveTagsFromResource(request)
-	})
-	if err != nil {
-		return WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), AlibabaCloudSdkGoERROR)
-	}
-	addDebug(request.GetActionName(), raw)
-	return nil
-}
-
-func (s *RdsService) doBatchTags(batchFunc func([]Tag, string) error, tags []Tag, instanceId string) error {
-	num := len(tags)
-	if num <= 0 {
-		return nil
-	}
-
-	start, end := 0, 0
-	for end < num {
-		start = end
-		end += tagsMaxNumPerTime
-		if end > num {
-			end = num
-		}
-		if err := batchFunc(tags[start:end], instanceId); err != nil {
-			return WrapError(err)
-		}
-	}
-	return nil
-}
-
-func (s *RdsService) describeTags(d *schema.ResourceData) (tags []Tag, err error) {
-	request := rds.CreateDescribeTagsRequest()
-	request.DBInstanceId = d.Id()
-
-	raw, err := s.client.WithRdsClient(func(client *rds.Client) (interface{}, error) {
-		return client.DescribeTags(request)
-	})
-	if err != nil {
-		tmp := make([]Tag, 0)
-		return tmp, WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
-	}
-
-	addDebug(request.GetActionName(), raw)
-
-	response, _ := raw.(*rds.DescribeTagsResponse)
-	return s.respToTags(response.Items.TagInfos), nil
-}
-
-func (s *RdsService) respToTags(tagSet []rds.TagInfos) (tags []Tag) {
-	result := make([]Tag, 0, len(tagSet))
-	for _, t := range tagSet {
-		tag := Tag{
-			Key:   t.TagKey,
-			Value: t.TagValue,
-		}
-		result = append(result, tag)
-	}
-
-	return result
-}
-
-func (s *RdsService) tagsToMap(tags []Tag) map[string]string {
-	result := make(map[string]string)
-	for _, t := range tags {
-		if !s.ignoreTag(t) {
-			result[t.Key] = t.Value
-		}
-	}
-
-	return result
-}
-
-func (s *RdsService) ignoreTag(t Tag) bool {
-	filter := []string{"^aliyun", "^acs:", "^http://", "^https://"}
-	for _, v := range filter {
-		log.Printf("[DEBUG] Matching prefix %v with %v\n", v, t.Key)
-		ok, _ := regexp.MatchString(v, t.Key)
-		if ok {
-			log.Printf("[DEBUG] Found Alibaba Cloud specific t %s (val: %s), ignoring.\n", t.Key, t.Value)
-			return true
-		}
-	}
-	return false
-}
-
-func (s *RdsService) tagsToString(tags []Tag) string {
-	v, _ := json.Marshal(s.tagsToMap(tags))
-
-	return string(v)
-}
@@ -0,0 +1,594 @@
+package alicloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/responses"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/cbn"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/dds"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/kms"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/r-kvstore"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/rds"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/slb"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+// TagService manages the tags of the resources of every TagResourceType. Most of the products share the TagResources,
+// UntagResources and ListTagResources APIs, which are called by the typed requests of the vendored ECS and R-KVStore
+// SDKs, and as common requests for VPC, DDS and CEN whose vendored SDKs do not have them. KMS, RDS and SLB have
+// their own tag APIs.
+type TagService struct {
+	client *connectivity.AliyunClient
+}
+
+// tagProduct adds, removes and lists the tags of one type of resource.
+type tagProduct interface {
+	tagResources(client *connectivity.AliyunClient, ids []string, tags []Tag) error
+	untagResources(client *connectivity.AliyunClient, ids []string, tags []Tag) error
+	// listTags returns all of the tags of the resource, including the ones added by Alibaba Cloud itself
+	listTags(client *connectivity.AliyunClient, id string) ([]Tag, error)
+}
+
+var tagProducts = map[TagResourceType]tagProduct{
+	TagResourceImage:           &ecsTagProduct{"image"},
+	TagResourceInstance:        &ecsTagProduct{"instance"},
+	TagResourceSnapshot:        &ecsTagProduct{"snapshot"},
+	TagResourceDisk:            &ecsTagProduct{"disk"},
+	TagResourceSecurityGroup:   &ecsTagProduct{"securitygroup"},
+	TagResourceEni:             &ecsTagProduct{"eni"},
+	TagResourceDedicatedHost:   &ecsTagProduct{"ddh"},
+	TagResourceVpc:             &commonTagProduct{"Vpc", "vpc", "2016-04-28", "VPC", processVpcCommonRequest},
+	TagResourceVSwitch:         &commonTagProduct{"Vpc", "vpc", "2016-04-28", "VSWITCH", processVpcCommonRequest},
+	TagResourceEip:             &commonTagProduct{"Vpc", "vpc", "2016-04-28", "EIP", processVpcCommonRequest},
+	TagResourceNatGateway:      &commonTagProduct{"Vpc", "vpc", "2016-04-28", "NATGATEWAY", processVpcCommonRequest},
+	TagResourceKVStoreInstance: &kvstoreTagProduct{"INSTANCE"},
+	TagResourceMongoDBInstance: &commonTagProduct{"Dds", "dds", "2015-12-01", "INSTANCE", processDdsCommonRequest},
+	TagResourceCenInstance:     &commonTagProduct{"Cbn", "cbn", "2017-09-12", "cen", processCenCommonRequest},
+	TagResourceKmsKey:          &kmsTagProduct{},
+	TagResourceDBInstance:      &rdsTagProduct{},
+	TagResourceSlb:             &slbTagProduct{},
+}
+
+// SetTags writes the "tags" of the resource merged with the provider default_tags when they have been changed.
+func (s *TagService) SetTags(d *schema.ResourceData, resourceType TagResourceType) error {
+	if tagsChanged(s.client, d) {
+		oraw, nraw := d.GetChange("tags")
+		return s.UpdateTags([]string{d.Id()}, resourceType, oraw, mergeDefaultTags(s.client, nraw))
+	}
+
+	return nil
+}
+
+// UpdateTags removes the tags of oraw which are not in nraw and adds all of the tags in nraw.
+func (s *TagService) UpdateTags(ids []string, resourceType TagResourceType, oraw, nraw interface{}) error {
+	product, ok := tagProducts[resourceType]
+	if !ok {
+		return WrapError(fmt.Errorf("The resource type %s does not support tags.", resourceType))
+	}
+	o := oraw.(map[string]interface{})
+	n := nraw.(map[string]interface{})
+	create, remove := diffTags(tagsFromMap(o), tagsFromMap(n))
+
+	if len(remove) > 0 {
+		log.Printf("[DEBUG] Removing tags: %#v from %#v", remove, ids)
+		if err := product.untagResources(s.client, ids, remove); err != nil {
+			return WrapError(err)
+		}
+	}
+
+	if len(create) > 0 {
+		log.Printf("[DEBUG] Creating tags: %#v for %#v", create, ids)
+		if err := product.tagResources(s.client, ids, create); err != nil {
+			return WrapError(err)
+		}
+	}
+
+	return nil
+}
+
+// ListTags returns the tags of the resource without the ones added by Alibaba Cloud itself.
+func (s *TagService) ListTags(id string, resourceType TagResourceType) (map[string]string, error) {
+	result := make(map[string]string)
+	product, ok := tagProducts[resourceType]
+	if !ok {
+		return result, WrapError(fmt.Errorf("The resource type %s does not support tags.", resourceType))
+	}
+	tags, err := product.listTags(s.client, id)
+	if err != nil {
+		return result, WrapError(err)
+	}
+	for _, t := range tags {
+		if !tagIgnored(t.Key, t.Value) {
+			result[t.Key] = t.Value
+		}
+	}
+
+	return result, nil
+}
+
+// runTagRequest runs the tag API call of the resources and retries it when the service is busy or throttled.
+func runTagRequest(ids interface{}, action string, do func() (interface{}, error)) (interface{}, error) {
+	var raw interface{}
+	invoker := NewInvoker()
+	err := invoker.Run(func() error {
+		resp, err := do()
+		raw = resp
+		return err
+	})
+	if err != nil {
+		return nil, WrapErrorf(err, DefaultErrorMsg, ids, action, AlibabaCloudSdkGoERROR)
+	}
+	addDebug(action, raw)
+	return raw, nil
+}
+
+// batchTags calls do with the tags in batches of size, for the APIs which limit the number of tags of a call.
+func batchTags(tags []Tag, size int, do func([]Tag) error) error {
+	for start := 0; start < len(tags); start += size {
+		end := start + size
+		if end > len(tags) {
+			end = len(tags)
+		}
+		if err := do(tags[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type ecsTagProduct struct {
+	resourceType string
+}
+
+func (p *ecsTagProduct) tagResources(client *connectivity.AliyunClient, ids []string, tags []Tag) error {
+	request := ecs.CreateTagResourcesRequest()
+	request.ResourceType = p.resourceType
+	request.ResourceId = &ids
+	var requestTags []ecs.TagResourcesTag
+	for _, t := range tags {
+		requestTags = append(requestTags, ecs.TagResourcesTag{Key: t.Key, Value: t.Value})
+	}
+	request.Tag = &requestTags
+	_, err := runTagRequest(ids, request.GetActionName(), func() (interface{}, error) {
+		return client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.TagResources(request)
+		})
+	})
+	return err
+}
+
+func (p *ecsTagProduct) untagResources(client *connectivity.AliyunClient, ids []string, tags []Tag) error {
+	request := ecs.CreateUntagResourcesRequest()
+	request.ResourceType = p.resourceType
+	request.ResourceId = &ids
+	keys := tagKeys(tags)
+	request.TagKey = &keys
+	_, err := runTagRequest(ids, request.GetActionName(), func() (interface{}, error) {
+		return client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.UntagResources(request)
+		})
+	})
+	return err
+}
+
+func (p *ecsTagProduct) listTags(client *connectivity.AliyunClient, id string) ([]Tag, error) {
+	var result []Tag
+	request := ecs.CreateListTagResourcesRequest()
+	request.ResourceType = p.resourceType
+	request.ResourceId = &[]string{id}
+	for {
+		raw, err := runTagRequest(id, request.GetActionName(), func() (interface{}, error) {
+			return client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+				return ecsClient.ListTagResources(request)
+			})
+		})
+		if err != nil {
+			return result, err
+		}
+		response, _ := raw.(*ecs.ListTagResourcesResponse)
+		for _, t := range response.TagResources.TagResource {
+			if t.ResourceId == id {
+				result = append(result, Tag{Key: t.TagKey, Value: t.TagValue})
+			}
+		}
+		if response.NextToken == "" {
+			return result, nil
+		}
+		request.NextToken = response.NextToken
+	}
+}
+
+type kvstoreTagProduct struct {
+	resourceType string
+}
+
+func (p *kvstoreTagProduct) tagResources(client *connectivity.AliyunClient, ids []string, tags []Tag) error {
+	request := r_kvstore.CreateTagResourcesRequest()
+	request.ResourceType = p.resourceType
+	request.ResourceId = &ids
+	var requestTags []r_kvstore.TagResourcesTag
+	for _, t := range tags {
+		requestTags = append(requestTags, r_kvstore.TagResourcesTag{Key: t.Key, Value: t.Value})
+	}
+	request.Tag = &requestTags
+	_, err := runTagRequest(ids, request.GetActionName(), func() (interface{}, error) {
+		return client.WithRkvClient(func(rkvClient *r_kvstore.Client) (interface{}, error) {
+			return rkvClient.TagResources(request)
+		})
+	})
+	return err
+}
+
+func (p *kvstoreTagProduct) untagResources(client *connectivity.AliyunClient, ids []string, tags []Tag) error {
+	request := r_kvstore.CreateUntagResourcesRequest()
+	request.ResourceType = p.resourceType
+	request.ResourceId = &ids
+	keys := tagKeys(tags)
+	request.TagKey = &keys
+	_, err := runTagRequest(ids, request.GetActionName(), func() (interface{}, error) {
+		return client.WithRkvClient(func(rkvClient *r_kvstore.Client) (interface{}, error) {
+			return rkvClient.UntagResources(request)
+		})
+	})
+	return err
+}
+
+func (p *kvstoreTagProduct) listTags(client *connectivity.AliyunClient, id string) ([]Tag, error) {
+	var result []Tag
+	request := r_kvstore.CreateListTagResourcesRequest()
+	request.ResourceType = p.resourceType
+	request.ResourceId = &[]string{id}
+	for {
+		raw, err := runTagRequest(id, request.GetActionName(), func() (interface{}, error) {
+			return client.WithRkvClient(func(rkvClient *r_kvstore.Client) (interface{}, error) {
+				return rkvClient.ListTagResources(request)
+			})
+		})
+		if err != nil {
+			return result, err
+		}
+		response, _ := raw.(*r_kvstore.ListTagResourcesResponse)
+		for _, t := range response.TagResources.TagResource {
+			if t.ResourceId == id {
+				result = append(result, Tag{Key: t.TagKey, Value: t.TagValue})
+			}
+		}
+		if response.NextToken == "" {
+			return result, nil
+		}
+		request.NextToken = response.NextToken
+	}
+}
+
+// commonTagProduct calls the tag APIs as common requests of the product.
+type commonTagProduct struct {
+	// The product, location service code and API version of the common requests, like Vpc, vpc and 2016-04-28
+	product     string
+	serviceCode string
+	version     string
+	// The value of the parameter ResourceType
+	resourceType string
+	process      func(client *connectivity.AliyunClient, request *requests.CommonRequest) (interface{}, error)
+}
+
+func processVpcCommonRequest(client *connectivity.AliyunClient, request *requests.CommonRequest) (interface{}, error) {
+	return client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+		return vpcClient.ProcessCommonRequest(request)
+	})
+}
+
+func processDdsCommonRequest(client *connectivity.AliyunClient, request *requests.CommonRequest) (interface{}, error) {
+	return client.WithDdsClient(func(ddsClient *dds.Client) (interface{}, error) {
+		return ddsClient.ProcessCommonRequest(request)
+	})
+}
+
+func processCenCommonRequest(client *connectivity.AliyunClient, request *requests.CommonRequest) (interface{}, error) {
+	return client.WithCenClient(func(cbnClient *cbn.Client) (interface{}, error) {
+		return cbnClient.ProcessCommonRequest(request)
+	})
+}
+
+type tagResourcesResponse struct {
+	NextToken    string `json:"NextToken"`
+	TagResources struct {
+		TagResource []struct {
+			ResourceId string `json:"ResourceId"`
+			TagKey     string `json:"TagKey"`
+			TagValue   string `json:"TagValue"`
+		} `json:"TagResource"`
+	} `json:"TagResources"`
+}
+
+func (p *commonTagProduct) tagResources(client *connectivity.AliyunClient, ids []string, tags []Tag) error {
+	request := p.buildCommonRequest(client, "TagResources", ids)
+	for i, t := range tags {
+		request.QueryParams[fmt.Sprintf("Tag.%d.Key", i+1)] = t.Key
+		request.QueryParams[fmt.Sprintf("Tag.%d.Value", i+1)] = t.Value
+	}
+	_, err := p.doCommonRequest(client, request, ids)
+	return err
+}
+
+func (p *commonTagProduct) untagResources(client *connectivity.AliyunClient, ids []string, tags []Tag) error {
+	request := p.buildCommonRequest(client, "UntagResources", ids)
+	for i, t := range tags {
+		request.QueryParams[fmt.Sprintf("TagKey.%d", i+1)] = t.Key
+	}
+	_, err := p.doCommonRequest(client, request, ids)
+	return err
+}
+
+func (p *commonTagProduct) listTags(client *connectivity.AliyunClient, id string) ([]Tag, error) {
+	var result []Tag
+	nextToken := ""
+	for {
+		request := p.buildCommonRequest(client, "ListTagResources", []string{id})
+		if nextToken != "" {
+			request.QueryParams["NextToken"] = nextToken
+		}
+		response, err := p.doCommonRequest(client, request, []string{id})
+		if err != nil {
+			return result, err
+		}
+		var object tagResourcesResponse
+		if err := json.Unmarshal(response.GetHttpContentBytes(), &object); err != nil {
+			return result, WrapError(err)
+		}
+		for _, t := range object.TagResources.TagResource {
+			if t.ResourceId == id {
+				result = append(result, Tag{Key: t.TagKey, Value: t.TagValue})
+			}
+		}
+		if object.NextToken == "" {
+			return result, nil
+		}
+		nextToken = object.NextToken
+	}
+}
+
+func (p *commonTagProduct) buildCommonRequest(client *connectivity.AliyunClient, action string, ids []string) *requests.CommonRequest {
+	request := requests.NewCommonRequest()
+	request.Product = p.product
+	request.ServiceCode = p.serviceCode
+	request.Version = p.version
+	request.ApiName = action
+	request.RegionId = client.RegionId
+	request.QueryParams["RegionId"] = client.RegionId
+	request.QueryParams["ResourceType"] = p.resourceType
+	for i, id := range ids {
+		request.QueryParams[fmt.Sprintf("ResourceId.%d", i+1)] = id
+	}
+	return request
+}
+
+func (p *commonTagProduct) doCommonRequest(client *connectivity.AliyunClient, request *requests.CommonRequest, ids []string) (*responses.CommonResponse, error) {
+	raw, err := runTagRequest(ids, request.ApiName, func() (interface{}, error) {
+		return p.process(client, request)
+	})
+	if err != nil {
+		return nil, err
+	}
+	response, _ := raw.(*responses.CommonResponse)
+	return response, nil
+}
+
+type kmsTagProduct struct{}
+
+type kmsTag struct {
+	TagKey   string `json:"TagKey"`
+	TagValue string `json:"TagValue"`
+}
+
+func (p *kmsTagProduct) tagResources(client *connectivity.AliyunClient, ids []string, tags []Tag) error {
+	var kmsTags []kmsTag
+	for _, t := range tags {
+		kmsTags = append(kmsTags, kmsTag{TagKey: t.Key, TagValue: t.Value})
+	}
+	b, _ := json.Marshal(kmsTags)
+	for _, id := range ids {
+		request := kms.CreateTagResourceRequest()
+		request.KeyId = id
+		request.Tags = string(b)
+		if _, err := runTagRequest(id, request.GetActionName(), func() (interface{}, error) {
+			return client.WithKmsClient(func(kmsClient *kms.Client) (interface{}, error) {
+				return kmsClient.TagResource(request)
+			})
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *kmsTagProduct) untagResources(client *connectivity.AliyunClient, ids []string, tags []Tag) error {
+	b, _ := json.Marshal(tagKeys(tags))
+	for _, id := range ids {
+		request := kms.CreateUntagResourceRequest()
+		request.KeyId = id
+		request.TagKeys = string(b)
+		if _, err := runTagRequest(id, request.GetActionName(), func() (interface{}, error) {
+			return client.WithKmsClient(func(kmsClient *kms.Client) (interface{}, error) {
+				return kmsClient.UntagResource(request)
+			})
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *kmsTagProduct) listTags(client *connectivity.AliyunClient, id string) ([]Tag, error) {
+	request := kms.CreateListResourceTagsRequest()
+	request.KeyId = id
+	raw, err := runTagRequest(id, request.GetActionName(), func() (interface{}, error) {
+		return client.WithKmsClient(func(kmsClient *kms.Client) (interface{}, error) {
+			return kmsClient.ListResourceTags(request)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	response, _ := raw.(*kms.ListResourceTagsResponse)
+	var result []Tag
+	for _, t := range response.Tags.Tag {
+		result = append(result, Tag{Key: t.TagKey, Value: t.TagValue})
+	}
+	return result, nil
+}
+
+// The RDS and SLB tag APIs take at most 5 tags per call.
+const tagsMaxNumPerTime = 5
+
+// rdsTagProduct tags the RDS instances by the tags map of the AddTagsToResource and RemoveTagsFromResource APIs.
+type rdsTagProduct struct{}
+
+func rdsTagsString(tags []Tag) string {
+	m := make(map[string]string)
+	for _, t := range tags {
+		m[t.Key] = t.Value
+	}
+	b, _ := json.Marshal(m)
+	return string(b)
+}
+
+func (p *rdsTagProduct) tagResources(client *connectivity.AliyunClient, ids []string, tags []Tag) error {
+	for _, id := range ids {
+		err := batchTags(tags, tagsMaxNumPerTime, func(batch []Tag) error {
+			request := rds.CreateAddTagsToResourceRequest()
+			request.DBInstanceId = id
+			request.Tags = rdsTagsString(batch)
+			_, err := runTagRequest(id, request.GetActionName(), func() (interface{}, error) {
+				return client.WithRdsClient(func(rdsClient *rds.Client) (interface{}, error) {
+					return rdsClient.AddTagsToResource(request)
+				})
+			})
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *rdsTagProduct) untagResources(client *connectivity.AliyunClient, ids []string, tags []Tag) error {
+	for _, id := range ids {
+		err := batchTags(tags, tagsMaxNumPerTime, func(batch []Tag) error {
+			request := rds.CreateRemoveTagsFromResourceRequest()
+			request.DBInstanceId = id
+			request.Tags = rdsTagsString(batch)
+			_, err := runTagRequest(id, request.GetActionName(), func() (interface{}, error) {
+				return client.WithRdsClient(func(rdsClient *rds.Client) (interface{}, error) {
+					return rdsClient.RemoveTagsFromResource(request)
+				})
+			})
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *rdsTagProduct) listTags(client *connectivity.AliyunClient, id string) ([]Tag, error) {
+	request := rds.CreateDescribeTagsRequest()
+	request.DBInstanceId = id
+	raw, err := runTagRequest(id, request.GetActionName(), func() (interface{}, error) {
+		return client.WithRdsClient(func(rdsClient *rds.Client) (interface{}, error) {
+			return rdsClient.DescribeTags(request)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	response, _ := raw.(*rds.DescribeTagsResponse)
+	var result []Tag
+	for _, t := range response.Items.TagInfos {
+		result = append(result, Tag{Key: t.TagKey, Value: t.TagValue})
+	}
+	return result, nil
+}
+
+// slbTagProduct tags the load balancers by the tags list of the AddTags and RemoveTags APIs.
+type slbTagProduct struct{}
+
+func (p *slbTagProduct) tagResources(client *connectivity.AliyunClient, ids []string, tags []Tag) error {
+	for _, id := range ids {
+		err := batchTags(tags, tagsMaxNumPerTime, func(batch []Tag) error {
+			request := slb.CreateAddTagsRequest()
+			request.LoadBalancerId = id
+			request.Tags = toSlbTagsString(batch)
+			_, err := runTagRequest(id, request.GetActionName(), func() (interface{}, error) {
+				return client.WithSlbClient(func(slbClient *slb.Client) (interface{}, error) {
+					return slbClient.AddTags(request)
+				})
+			})
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *slbTagProduct) untagResources(client *connectivity.AliyunClient, ids []string, tags []Tag) error {
+	for _, id := range ids {
+		err := batchTags(tags, tagsMaxNumPerTime, func(batch []Tag) error {
+			request := slb.CreateRemoveTagsRequest()
+			request.LoadBalancerId = id
+			request.Tags = toSlbTagsString(batch)
+			_, err := runTagRequest(id, request.GetActionName(), func() (interface{}, error) {
+				return client.WithSlbClient(func(slbClient *slb.Client) (interface{}, error) {
+					return slbClient.RemoveTags(request)
+				})
+			})
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *slbTagProduct) listTags(client *connectivity.AliyunClient, id string) ([]Tag, error) {
+	var result []Tag
+	for page := 1; ; page++ {
+		request := slb.CreateDescribeTagsRequest()
+		request.LoadBalancerId = id
+		request.PageNumber = requests.NewInteger(page)
+		request.PageSize = requests.NewInteger(tags_max_page_size)
+		raw, err := runTagRequest(id, request.GetActionName(), func() (interface{}, error) {
+			return client.WithSlbClient(func(slbClient *slb.Client) (interface{}, error) {
+				return slbClient.DescribeTags(request)
+			})
+		})
+		if err != nil {
+			return result, err
+		}
+		response, _ := raw.(*slb.DescribeTagsResponse)
+		for _, t := range response.TagSets.TagSet {
+			result = append(result, Tag{Key: t.TagKey, Value: t.TagValue})
+		}
+		if len(response.TagSets.TagSet) == 0 || len(result) >= response.TotalCount {
+			return result, nil
+		}
+	}
+}
+
+func tagKeys(tags []Tag) []string {
+	keys := make([]string, 0, len(tags))
+	for _, t := range tags {
+		keys = append(keys, t.Key)
+	}
+	return keys
+}
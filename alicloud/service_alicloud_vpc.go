@@ -5,6 +5,7 @@ import (
 
 	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
 )
 
@@ -1009,3 +1010,327 @@ func (s *VpcService) WaitForNetworkAclAttachment(id string, resource []vpc.Resou
 		time.Sleep(DefaultIntervalShort * time.Second)
 	}
 }
+
+func (s *VpcService) DescribeVpcFlowLog(id string) (*vpc.FlowLog, error) {
+	request := vpc.CreateDescribeFlowLogsRequest()
+	request.RegionId = s.client.RegionId
+	request.FlowLogId = id
+	raw, err := s.client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+		return vpcClient.DescribeFlowLogs(request)
+	})
+	if err != nil {
+		return nil, WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+	response := raw.(*vpc.DescribeFlowLogsResponse)
+	if len(response.FlowLogs.FlowLog) != 1 || response.FlowLogs.FlowLog[0].FlowLogId != id {
+		return nil, WrapErrorf(Error("%s", GetNotFoundMessage("FlowLog", id)), NotFoundMsg, ProviderERROR)
+	}
+	return &response.FlowLogs.FlowLog[0], nil
+}
+
+func (s *VpcService) VpcFlowLogStateRefreshFunc(id string, failStates []string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		object, err := s.DescribeVpcFlowLog(id)
+		if err != nil {
+			if NotFoundError(err) {
+				// Set this to nil as if we didn't find anything.
+				return nil, "", nil
+			}
+			return nil, "", WrapError(err)
+		}
+
+		for _, failState := range failStates {
+			if object.Status == failState {
+				return object, object.Status, WrapError(Error(FailedToReachTargetStatus, object.Status))
+			}
+		}
+		return object, object.Status, nil
+	}
+}
+
+func (s *VpcService) DescribeVpcIpv6Gateway(id string) (*vpc.DescribeIpv6GatewayAttributeResponse, error) {
+	request := vpc.CreateDescribeIpv6GatewayAttributeRequest()
+	request.RegionId = s.client.RegionId
+	request.Ipv6GatewayId = id
+	raw, err := s.client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+		return vpcClient.DescribeIpv6GatewayAttribute(request)
+	})
+	if err != nil {
+		if IsExceptedErrors(err, []string{Ipv6GatewayNotFound}) {
+			return nil, WrapErrorf(err, NotFoundMsg, AlibabaCloudSdkGoERROR)
+		}
+		return nil, WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+	response := raw.(*vpc.DescribeIpv6GatewayAttributeResponse)
+	if response.Ipv6GatewayId != id {
+		return nil, WrapErrorf(Error("%s", GetNotFoundMessage("Ipv6Gateway", id)), NotFoundMsg, ProviderERROR)
+	}
+	return response, nil
+}
+
+func (s *VpcService) VpcIpv6GatewayStateRefreshFunc(id string, failStates []string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		object, err := s.DescribeVpcIpv6Gateway(id)
+		if err != nil {
+			if NotFoundError(err) {
+				// Set this to nil as if we didn't find anything.
+				return nil, "", nil
+			}
+			return nil, "", WrapError(err)
+		}
+
+		for _, failState := range failStates {
+			if object.Status == failState {
+				return object, object.Status, WrapError(Error(FailedToReachTargetStatus, object.Status))
+			}
+		}
+		return object, object.Status, nil
+	}
+}
+
+// DescribeVpcIpv6EgressRule describes the egress-only rule by the id which is spliced from the IPv6 gateway ID and the rule ID.
+func (s *VpcService) DescribeVpcIpv6EgressRule(id string) (*vpc.Ipv6EgressOnlyRule, error) {
+	parts, err := ParseResourceId(id, 2)
+	if err != nil {
+		return nil, WrapError(err)
+	}
+	request := vpc.CreateDescribeIpv6EgressOnlyRulesRequest()
+	request.RegionId = s.client.RegionId
+	request.Ipv6GatewayId = parts[0]
+	request.Ipv6EgressOnlyRuleId = parts[1]
+	raw, err := s.client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+		return vpcClient.DescribeIpv6EgressOnlyRules(request)
+	})
+	if err != nil {
+		if IsExceptedErrors(err, []string{Ipv6GatewayNotFound}) {
+			return nil, WrapErrorf(err, NotFoundMsg, AlibabaCloudSdkGoERROR)
+		}
+		return nil, WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+	response := raw.(*vpc.DescribeIpv6EgressOnlyRulesResponse)
+	rules := response.Ipv6EgressOnlyRules.Ipv6EgressOnlyRule
+	if len(rules) != 1 || rules[0].Ipv6EgressOnlyRuleId != parts[1] {
+		return nil, WrapErrorf(Error("%s", GetNotFoundMessage("Ipv6EgressRule", id)), NotFoundMsg, ProviderERROR)
+	}
+	return &rules[0], nil
+}
+
+func (s *VpcService) VpcIpv6EgressRuleStateRefreshFunc(id string, failStates []string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		object, err := s.DescribeVpcIpv6EgressRule(id)
+		if err != nil {
+			if NotFoundError(err) {
+				// Set this to nil as if we didn't find anything.
+				return nil, "", nil
+			}
+			return nil, "", WrapError(err)
+		}
+
+		for _, failState := range failStates {
+			if object.Status == failState {
+				return object, object.Status, WrapError(Error(FailedToReachTargetStatus, object.Status))
+			}
+		}
+		return object, object.Status, nil
+	}
+}
+
+// DescribeVpcIpv6InternetBandwidth describes the IPv6 address which the Internet bandwidth is allocated to.
+func (s *VpcService) DescribeVpcIpv6InternetBandwidth(id string) (*vpc.Ipv6Address, error) {
+	request := vpc.CreateDescribeIpv6AddressesRequest()
+	request.RegionId = s.client.RegionId
+	request.Ipv6InternetBandwidthId = id
+	raw, err := s.client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+		return vpcClient.DescribeIpv6Addresses(request)
+	})
+	if err != nil {
+		return nil, WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+	response := raw.(*vpc.DescribeIpv6AddressesResponse)
+	addresses := response.Ipv6Addresses.Ipv6Address
+	if len(addresses) != 1 || addresses[0].Ipv6InternetBandwidth.Ipv6InternetBandwidthId != id {
+		return nil, WrapErrorf(Error("%s", GetNotFoundMessage("Ipv6InternetBandwidth", id)), NotFoundMsg, ProviderERROR)
+	}
+	return &addresses[0], nil
+}
+
+func (s *VpcService) VpcIpv6InternetBandwidthStateRefreshFunc(id string, failStates []string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		object, err := s.DescribeVpcIpv6InternetBandwidth(id)
+		if err != nil {
+			if NotFoundError(err) {
+				// Set this to nil as if we didn't find anything.
+				return nil, "", nil
+			}
+			return nil, "", WrapError(err)
+		}
+
+		status := object.Ipv6InternetBandwidth.BusinessStatus
+		for _, failState := range failStates {
+			if status == failState {
+				return object, status, WrapError(Error(FailedToReachTargetStatus, status))
+			}
+		}
+		return object, status, nil
+	}
+}
+
+func (s *VpcService) DescribeExpressConnectVirtualBorderRouter(id string) (*vpc.VirtualBorderRouterType, error) {
+	request := vpc.CreateDescribeVirtualBorderRoutersRequest()
+	request.RegionId = s.client.RegionId
+	request.Filter = &[]vpc.DescribeVirtualBorderRoutersFilter{
+		{Key: "VbrId", Value: &[]string{id}},
+	}
+	raw, err := s.client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+		return vpcClient.DescribeVirtualBorderRouters(request)
+	})
+	if err != nil {
+		return nil, WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+	response := raw.(*vpc.DescribeVirtualBorderRoutersResponse)
+	routers := response.VirtualBorderRouterSet.VirtualBorderRouterType
+	if len(routers) != 1 || routers[0].VbrId != id {
+		return nil, WrapErrorf(Error("%s", GetNotFoundMessage("VirtualBorderRouter", id)), NotFoundMsg, ProviderERROR)
+	}
+	return &routers[0], nil
+}
+
+func (s *VpcService) ExpressConnectVirtualBorderRouterStateRefreshFunc(id string, failStates []string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		object, err := s.DescribeExpressConnectVirtualBorderRouter(id)
+		if err != nil {
+			if NotFoundError(err) {
+				// Set this to nil as if we didn't find anything.
+				return nil, "", nil
+			}
+			return nil, "", WrapError(err)
+		}
+
+		for _, failState := range failStates {
+			if object.Status == failState {
+				return object, object.Status, WrapError(Error(FailedToReachTargetStatus, object.Status))
+			}
+		}
+		return object, object.Status, nil
+	}
+}
+
+func (s *VpcService) DescribeVpcBgpGroup(id string) (*vpc.BgpGroup, error) {
+	request := vpc.CreateDescribeBgpGroupsRequest()
+	request.RegionId = s.client.RegionId
+	request.BgpGroupId = id
+	raw, err := s.client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+		return vpcClient.DescribeBgpGroups(request)
+	})
+	if err != nil {
+		return nil, WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+	response := raw.(*vpc.DescribeBgpGroupsResponse)
+	groups := response.BgpGroups.BgpGroup
+	if len(groups) != 1 || groups[0].BgpGroupId != id {
+		return nil, WrapErrorf(Error("%s", GetNotFoundMessage("BgpGroup", id)), NotFoundMsg, ProviderERROR)
+	}
+	return &groups[0], nil
+}
+
+func (s *VpcService) VpcBgpGroupStateRefreshFunc(id string, failStates []string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		object, err := s.DescribeVpcBgpGroup(id)
+		if err != nil {
+			if NotFoundError(err) {
+				// Set this to nil as if we didn't find anything.
+				return nil, "", nil
+			}
+			return nil, "", WrapError(err)
+		}
+
+		for _, failState := range failStates {
+			if object.Status == failState {
+				return object, object.Status, WrapError(Error(FailedToReachTargetStatus, object.Status))
+			}
+		}
+		return object, object.Status, nil
+	}
+}
+
+func (s *VpcService) DescribeVpcBgpPeer(id string) (*vpc.BgpPeer, error) {
+	request := vpc.CreateDescribeBgpPeersRequest()
+	request.RegionId = s.client.RegionId
+	request.BgpPeerId = id
+	raw, err := s.client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+		return vpcClient.DescribeBgpPeers(request)
+	})
+	if err != nil {
+		return nil, WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+	response := raw.(*vpc.DescribeBgpPeersResponse)
+	peers := response.BgpPeers.BgpPeer
+	if len(peers) != 1 || peers[0].BgpPeerId != id {
+		return nil, WrapErrorf(Error("%s", GetNotFoundMessage("BgpPeer", id)), NotFoundMsg, ProviderERROR)
+	}
+	return &peers[0], nil
+}
+
+func (s *VpcService) VpcBgpPeerStateRefreshFunc(id string, failStates []string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		object, err := s.DescribeVpcBgpPeer(id)
+		if err != nil {
+			if NotFoundError(err) {
+				// Set this to nil as if we didn't find anything.
+				return nil, "", nil
+			}
+			return nil, "", WrapError(err)
+		}
+
+		for _, failState := range failStates {
+			if object.Status == failState {
+				return object, object.Status, WrapError(Error(FailedToReachTargetStatus, object.Status))
+			}
+		}
+		return object, object.Status, nil
+	}
+}
+
+// DescribeVpcBgpNetwork describes the BGP network by the id which is spliced from the router ID and the CIDR block.
+func (s *VpcService) DescribeVpcBgpNetwork(id string) (*vpc.BgpNetwork, error) {
+	parts, err := ParseResourceId(id, 2)
+	if err != nil {
+		return nil, WrapError(err)
+	}
+	request := vpc.CreateDescribeBgpNetworksRequest()
+	request.RegionId = s.client.RegionId
+	request.RouterId = parts[0]
+	request.PageSize = requests.NewInteger(PageSizeLarge)
+	request.PageNumber = requests.NewInteger(1)
+	for {
+		raw, err := s.client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.DescribeBgpNetworks(request)
+		})
+		if err != nil {
+			return nil, WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), AlibabaCloudSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw)
+		response := raw.(*vpc.DescribeBgpNetworksResponse)
+		for _, network := range response.BgpNetworks.BgpNetwork {
+			if network.DstCidrBlock == parts[1] {
+				return &network, nil
+			}
+		}
+		if len(response.BgpNetworks.BgpNetwork) < PageSizeLarge {
+			break
+		}
+		if page, err := getNextpageNumber(request.PageNumber); err != nil {
+			return nil, WrapError(err)
+		} else {
+			request.PageNumber = page
+		}
+	}
+	return nil, WrapErrorf(Error("%s", GetNotFoundMessage("BgpNetwork", id)), NotFoundMsg, ProviderERROR)
+}
@@ -8,7 +8,9 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
 )
 
@@ -122,6 +124,148 @@ func (s *VpnGatewayService) DescribeSslVpnClientCert(id string) (v vpc.DescribeS
 	return *response, nil
 }
 
+// DescribeVpnConnectionConfig downloads the configuration of the VPN connection for the customer gateway side.
+func (s *VpnGatewayService) DescribeVpnConnectionConfig(id string) (v vpc.VpnConnectionConfig, err error) {
+	request := vpc.CreateDownloadVpnConnectionConfigRequest()
+	request.RegionId = s.client.RegionId
+	request.VpnConnectionId = id
+
+	raw, err := s.client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+		return vpcClient.DownloadVpnConnectionConfig(request)
+	})
+	if err != nil {
+		if IsExceptedErrors(err, []string{VpnForbidden, VpnConnNotFound}) {
+			return v, WrapErrorf(Error("%s", GetNotFoundMessage("VpnConnection", id)), NotFoundMsg, ProviderERROR)
+		}
+		return v, WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw)
+	response, _ := raw.(*vpc.DownloadVpnConnectionConfigResponse)
+	return response.VpnConnectionConfig, nil
+}
+
+// DescribeVpnRouteEntry describes the destination-based route entry by the id which is spliced from
+// the VPN gateway ID, the next hop and the destination CIDR block.
+func (s *VpnGatewayService) DescribeVpnRouteEntry(id string) (v vpc.VpnRouteEntry, err error) {
+	parts, err := ParseResourceId(id, 3)
+	if err != nil {
+		return v, WrapError(err)
+	}
+	request := vpc.CreateDescribeVpnRouteEntriesRequest()
+	request.RegionId = s.client.RegionId
+	request.VpnGatewayId = parts[0]
+	request.PageSize = requests.NewInteger(PageSizeLarge)
+	request.PageNumber = requests.NewInteger(1)
+	for {
+		raw, err := s.client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.DescribeVpnRouteEntries(request)
+		})
+		if err != nil {
+			if IsExceptedErrors(err, []string{VpnForbidden, VpnNotFound}) {
+				return v, WrapErrorf(Error("%s", GetNotFoundMessage("VpnRouteEntry", id)), NotFoundMsg, ProviderERROR)
+			}
+			return v, WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), AlibabaCloudSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw)
+		response, _ := raw.(*vpc.DescribeVpnRouteEntriesResponse)
+		for _, entry := range response.VpnRouteEntries.VpnRouteEntry {
+			if entry.NextHop == parts[1] && entry.RouteDest == parts[2] {
+				return entry, nil
+			}
+		}
+		if len(response.VpnRouteEntries.VpnRouteEntry) < PageSizeLarge {
+			break
+		}
+		if page, err := getNextpageNumber(request.PageNumber); err != nil {
+			return v, WrapError(err)
+		} else {
+			request.PageNumber = page
+		}
+	}
+	return v, WrapErrorf(Error("%s", GetNotFoundMessage("VpnRouteEntry", id)), NotFoundMsg, ProviderERROR)
+}
+
+// DescribeVpnPbrRouteEntry describes the policy-based route entry by the id which is spliced from
+// the VPN gateway ID, the next hop, the source CIDR block and the destination CIDR block.
+func (s *VpnGatewayService) DescribeVpnPbrRouteEntry(id string) (v vpc.VpnPbrRouteEntry, err error) {
+	parts, err := ParseResourceId(id, 4)
+	if err != nil {
+		return v, WrapError(err)
+	}
+	request := vpc.CreateDescribeVpnPbrRouteEntriesRequest()
+	request.RegionId = s.client.RegionId
+	request.VpnGatewayId = parts[0]
+	request.PageSize = requests.NewInteger(PageSizeLarge)
+	request.PageNumber = requests.NewInteger(1)
+	for {
+		raw, err := s.client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.DescribeVpnPbrRouteEntries(request)
+		})
+		if err != nil {
+			if IsExceptedErrors(err, []string{VpnForbidden, VpnNotFound}) {
+				return v, WrapErrorf(Error("%s", GetNotFoundMessage("VpnPbrRouteEntry", id)), NotFoundMsg, ProviderERROR)
+			}
+			return v, WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), AlibabaCloudSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw)
+		response, _ := raw.(*vpc.DescribeVpnPbrRouteEntriesResponse)
+		for _, entry := range response.VpnPbrRouteEntries.VpnPbrRouteEntry {
+			if entry.NextHop == parts[1] && entry.RouteSource == parts[2] && entry.RouteDest == parts[3] {
+				return entry, nil
+			}
+		}
+		if len(response.VpnPbrRouteEntries.VpnPbrRouteEntry) < PageSizeLarge {
+			break
+		}
+		if page, err := getNextpageNumber(request.PageNumber); err != nil {
+			return v, WrapError(err)
+		} else {
+			request.PageNumber = page
+		}
+	}
+	return v, WrapErrorf(Error("%s", GetNotFoundMessage("VpnPbrRouteEntry", id)), NotFoundMsg, ProviderERROR)
+}
+
+func (s *VpnGatewayService) VpnRouteEntryStateRefreshFunc(id string, failStates []string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		object, err := s.DescribeVpnRouteEntry(id)
+		if err != nil {
+			if NotFoundError(err) {
+				// Set this to nil as if we didn't find anything.
+				return nil, "", nil
+			}
+			return nil, "", WrapError(err)
+		}
+
+		for _, failState := range failStates {
+			if object.State == failState {
+				return object, object.State, WrapError(Error(FailedToReachTargetStatus, object.State))
+			}
+		}
+		return object, object.State, nil
+	}
+}
+
+func (s *VpnGatewayService) VpnPbrRouteEntryStateRefreshFunc(id string, failStates []string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		object, err := s.DescribeVpnPbrRouteEntry(id)
+		if err != nil {
+			if NotFoundError(err) {
+				// Set this to nil as if we didn't find anything.
+				return nil, "", nil
+			}
+			return nil, "", WrapError(err)
+		}
+
+		for _, failState := range failStates {
+			if object.State == failState {
+				return object, object.State, WrapError(Error(FailedToReachTargetStatus, object.State))
+			}
+		}
+		return object, object.State, nil
+	}
+}
+
 func (s *VpnGatewayService) WaitForVpnGateway(id string, status Status, timeout int) error {
 	deadline := time.Now().Add(time.Duration(timeout) * time.Second)
 	for {
@@ -5,8 +5,6 @@ import (
 	"log"
 	"strings"
 
-	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
-
 	"regexp"
 
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
@@ -28,6 +26,15 @@ func tagsSchema() *schema.Schema {
 	}
 }
 
+// tagsAllSchema returns the schema of tags_all, which are the tags of a resource on the cloud, including the ones inherited
+// from the provider default_tags.
+func tagsAllSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeMap,
+		Computed: true,
+	}
+}
+
 func tagsSchemaComputed() *schema.Schema {
 	return &schema.Schema{
 		Type:     schema.TypeMap,
@@ -39,14 +46,84 @@ func tagsSchemaComputed() *schema.Schema {
 // setTags is a helper to set the tags for a resource. It expects the
 // tags field to be named "tags"
 func setTags(client *connectivity.AliyunClient, resourceType TagResourceType, d *schema.ResourceData) error {
-	if d.HasChange("tags") {
+	if tagsChanged(client, d) {
 		oraw, nraw := d.GetChange("tags")
-		return updateTags(client, []string{d.Id()}, resourceType, oraw, nraw)
+		return updateTags(client, []string{d.Id()}, resourceType, oraw, mergeDefaultTags(client, nraw))
 	}
 
 	return nil
 }
 
+// tagsChanged reports whether the tags of a resource need to be written. A new resource needs it as well
+// when the provider default_tags are set, even if its own "tags" is empty, and so does a resource whose tags_all are
+// planned to change by defaultTagsCustomizeDiff.
+func tagsChanged(client *connectivity.AliyunClient, d *schema.ResourceData) bool {
+	return d.HasChange("tags") || d.HasChange("tags_all") || (d.IsNewResource() && len(client.DefaultTags) > 0)
+}
+
+// defaultTagsCustomizeDiff plans the tags_all of a resource, which are its tags merged with the provider default_tags.
+// A default tag which the resource does not have on the cloud, like one added to default_tags after it was created,
+// shows up as a change of tags_all, and the update assigns it.
+func defaultTagsCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("tags") {
+		return d.SetNewComputed("tags_all")
+	}
+	client := meta.(*connectivity.AliyunClient)
+	tags := mergeDefaultTags(client, d.Get("tags"))
+	actual := make(map[string]string)
+	for k, v := range d.Get("tags_all").(map[string]interface{}) {
+		actual[k] = v.(string)
+	}
+	if tagsMapEqual(tags, actual) {
+		return nil
+	}
+	return d.SetNew("tags_all", tags)
+}
+
+// mergeDefaultTags returns the provider default_tags merged with the tags of a resource. The keys of the resource win.
+func mergeDefaultTags(client *connectivity.AliyunClient, tags interface{}) map[string]interface{} {
+	result := make(map[string]interface{})
+	for k, v := range client.DefaultTags {
+		result[k] = v
+	}
+	if m, ok := tags.(map[string]interface{}); ok {
+		for k, v := range m {
+			result[k] = v
+		}
+	}
+
+	return result
+}
+
+// setTagsWithDefaults sets the tags of the resource on the cloud to its tags_all, and the ones which are not inherited from
+// the provider default_tags to its "tags".
+func setTagsWithDefaults(client *connectivity.AliyunClient, d *schema.ResourceData, tags map[string]string) error {
+	if err := d.Set("tags_all", tags); err != nil {
+		return err
+	}
+	return d.Set("tags", ignoreDefaultTags(client, d, tags))
+}
+
+// ignoreDefaultTags removes the tags inherited from the provider default_tags, so they do not show up as a diff of
+// the resource's "tags". A key is kept when the resource sets it itself or when its value differs from the default.
+func ignoreDefaultTags(client *connectivity.AliyunClient, d *schema.ResourceData, tags map[string]string) map[string]string {
+	if len(client.DefaultTags) == 0 {
+		return tags
+	}
+	own, _ := d.Get("tags").(map[string]interface{})
+	result := make(map[string]string)
+	for k, v := range tags {
+		if value, ok := client.DefaultTags[k]; ok && value == v {
+			if _, ok := own[k]; !ok {
+				continue
+			}
+		}
+		result[k] = v
+	}
+
+	return result
+}
+
 func setVolumeTags(client *connectivity.AliyunClient, resourceType TagResourceType, d *schema.ResourceData) error {
 	if d.HasChange("volume_tags") {
 		resp, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
@@ -76,56 +153,8 @@ func setVolumeTags(client *connectivity.AliyunClient, resourceType TagResourceTy
 }
 
 func updateTags(client *connectivity.AliyunClient, ids []string, resourceType TagResourceType, oraw, nraw interface{}) error {
-	o := oraw.(map[string]interface{})
-	n := nraw.(map[string]interface{})
-	create, remove := diffTags(tagsFromMap(o), tagsFromMap(n))
-
-	// Set tags
-	if len(remove) > 0 {
-		log.Printf("[DEBUG] Removing tags: %#v from %#v", remove, ids)
-		args := ecs.CreateUntagResourcesRequest()
-		args.ResourceType = string(resourceType)
-		args.ResourceId = &ids
-
-		var tagsKey []string
-		for _, t := range remove {
-			tagsKey = append(tagsKey, t.Key)
-		}
-		args.TagKey = &tagsKey
-		args.All = requests.NewBoolean(true)
-
-		_, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
-			return ecsClient.UntagResources(args)
-		})
-		if err != nil {
-			return fmt.Errorf("Remove tags got error: %s", err)
-		}
-	}
-
-	if len(create) > 0 {
-		log.Printf("[DEBUG] Creating tags: %s for %#v", create, ids)
-		args := ecs.CreateTagResourcesRequest()
-		args.ResourceType = string(resourceType)
-		args.ResourceId = &ids
-
-		var tags []ecs.TagResourcesTag
-		for _, t := range create {
-			tags = append(tags, ecs.TagResourcesTag{
-				Key:   t.Key,
-				Value: t.Value,
-			})
-		}
-		args.Tag = &tags
-
-		_, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
-			return ecsClient.TagResources(args)
-		})
-		if err != nil {
-			return fmt.Errorf("Creating tags got error: %s", err)
-		}
-	}
-
-	return nil
+	tagService := TagService{client}
+	return tagService.UpdateTags(ids, resourceType, oraw, nraw)
 }
 
 // diffTags takes our tags locally and the ones remotely and returns
@@ -233,12 +262,17 @@ func tagsToString(tags []ecs.Tag) string {
 
 // tagIgnored compares a tag against a list of strings and checks if it should be ignored or not
 func ecsTagIgnored(t ecs.Tag) bool {
+	return tagIgnored(t.TagKey, t.TagValue)
+}
+
+// tagIgnored checks whether the tag is added by Alibaba Cloud itself and should be ignored
+func tagIgnored(key, value string) bool {
 	filter := []string{"^aliyun", "^acs:", "^http://", "^https://"}
 	for _, v := range filter {
-		log.Printf("[DEBUG] Matching prefix %v with %v\n", v, t.TagKey)
-		ok, _ := regexp.MatchString(v, t.TagKey)
+		log.Printf("[DEBUG] Matching prefix %v with %v\n", v, key)
+		ok, _ := regexp.MatchString(v, key)
 		if ok {
-			log.Printf("[DEBUG] Found Alibaba Cloud specific tag %s (val: %s), ignoring.\n", t.TagKey, t.TagValue)
+			log.Printf("[DEBUG] Found Alibaba Cloud specific tag %s (val: %s), ignoring.\n", key, value)
 			return true
 		}
 	}
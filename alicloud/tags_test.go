@@ -2,6 +2,9 @@ package alicloud
 
 import (
 	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
 )
 
 func TestTagsMapEqual(t *testing.T) {
@@ -48,3 +51,78 @@ func TestTagsMapEqual(t *testing.T) {
 		t.Fatal("Tag maps is equal.")
 	}
 }
+
+func TestMergeDefaultTags(t *testing.T) {
+	client := &connectivity.AliyunClient{
+		DefaultTags: map[string]string{
+			"Owner":      "terraform",
+			"CostCenter": "tf-testAcc",
+		},
+	}
+	tags := mergeDefaultTags(client, map[string]interface{}{
+		"Owner": "override",
+		"Name":  "tf-testAcc",
+	})
+	expected := map[string]string{
+		"Owner":      "override",
+		"CostCenter": "tf-testAcc",
+		"Name":       "tf-testAcc",
+	}
+	if !tagsMapEqual(tags, expected) {
+		t.Fatalf("Merged tags %v are not equal to %v.", tags, expected)
+	}
+
+	if tags := mergeDefaultTags(&connectivity.AliyunClient{}, nil); len(tags) != 0 {
+		t.Fatalf("Merged tags %v are not empty.", tags)
+	}
+}
+
+func TestIgnoreDefaultTags(t *testing.T) {
+	client := &connectivity.AliyunClient{
+		DefaultTags: map[string]string{
+			"Owner":      "terraform",
+			"CostCenter": "tf-testAcc",
+			"Env":        "test",
+		},
+	}
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{"tags": tagsSchema()}, map[string]interface{}{
+		"tags": map[string]interface{}{
+			"Name": "tf-testAcc",
+			"Env":  "test",
+		},
+	})
+	tags := ignoreDefaultTags(client, d, map[string]string{
+		"Name":       "tf-testAcc",
+		"Env":        "test",
+		"Owner":      "terraform",
+		"CostCenter": "changed",
+	})
+	// Owner is inherited, Env is set by the resource itself and CostCenter has drifted from the default value
+	expected := map[string]interface{}{
+		"Name":       "tf-testAcc",
+		"Env":        "test",
+		"CostCenter": "changed",
+	}
+	if !tagsMapEqual(expected, tags) {
+		t.Fatalf("Tags %v are not equal to %v.", tags, expected)
+	}
+
+	// The default key Env, which is added after the resource was created, is missing on the cloud. It is planned by
+	// defaultTagsCustomizeDiff rather than written to the state.
+	d = schema.TestResourceDataRaw(t, map[string]*schema.Schema{"tags": tagsSchema()}, map[string]interface{}{
+		"tags": map[string]interface{}{
+			"Name": "tf-testAcc",
+		},
+	})
+	tags = ignoreDefaultTags(client, d, map[string]string{
+		"Name":       "tf-testAcc",
+		"Owner":      "terraform",
+		"CostCenter": "tf-testAcc",
+	})
+	expected = map[string]interface{}{
+		"Name": "tf-testAcc",
+	}
+	if !tagsMapEqual(expected, tags) {
+		t.Fatalf("Tags %v are not equal to %v.", tags, expected)
+	}
+}
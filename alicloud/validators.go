@@ -1680,3 +1680,11 @@ func stringMatch(r *regexp.Regexp, message string) schema.SchemaValidateFunc {
 		return nil, nil
 	}
 }
+
+func validateDuration(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if d, err := time.ParseDuration(value); err != nil || d < 0 {
+		errors = append(errors, fmt.Errorf("%q must be a non-negative duration like \"500ms\" or \"30s\", got %q", k, value))
+	}
+	return
+}